@@ -12,12 +12,13 @@ import (
 
 // ActionConfig defines an action to take on event
 type ActionConfig struct {
-	Mode       *ChargeMode `mapstructure:"mode,omitempty"`       // Charge Mode
-	MinCurrent *float64    `mapstructure:"minCurrent,omitempty"` // Minimum Current
-	MaxCurrent *float64    `mapstructure:"maxCurrent,omitempty"` // Maximum Current
-	MinSoc     *int        `mapstructure:"minSoc,omitempty"`     // Minimum Soc
-	TargetSoc  *int        `mapstructure:"targetSoc,omitempty"`  // Target Soc
-	Priority   *int        `mapstructure:"priority,omitempty"`   // Priority
+	Mode       *ChargeMode `mapstructure:"mode,omitempty" json:"mode,omitempty"`             // Charge Mode
+	MinCurrent *float64    `mapstructure:"minCurrent,omitempty" json:"minCurrent,omitempty"` // Minimum Current
+	MaxCurrent *float64    `mapstructure:"maxCurrent,omitempty" json:"maxCurrent,omitempty"` // Maximum Current
+	Phases     *int        `mapstructure:"phases,omitempty" json:"phases,omitempty"`         // Phases (switchable chargers only)
+	MinSoc     *int        `mapstructure:"minSoc,omitempty" json:"minSoc,omitempty"`         // Minimum Soc
+	TargetSoc  *int        `mapstructure:"targetSoc,omitempty" json:"targetSoc,omitempty"`   // Target Soc
+	Priority   *int        `mapstructure:"priority,omitempty" json:"priority,omitempty"`     // Priority
 }
 
 // Merge merges all non-nil properties of the additional config into the base config.
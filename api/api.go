@@ -9,7 +9,7 @@ import (
 	"time"
 )
 
-//go:generate mockgen -package mock -destination ../mock/mock_api.go github.com/evcc-io/evcc/api Charger,ChargeState,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff
+//go:generate mockgen -package mock -destination ../mock/mock_api.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentGetter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff
 
 // ChargeMode is the charge operation mode. Valid values are off, now, minpv and pv
 type ChargeMode string
@@ -69,6 +69,16 @@ type MeterEnergy interface {
 	TotalEnergy() (float64, error)
 }
 
+// MeterCurtailment provides the inverter's power curtailment status, e.g. due to grid export limitation
+type MeterCurtailment interface {
+	Curtailed() (bool, error)
+}
+
+// MeterTariffEnergy provides import energy of tariff registers 1 and 2 (HT/NT) in kWh
+type MeterTariffEnergy interface {
+	TariffEnergy() (float64, float64, error)
+}
+
 // PhaseCurrents provides per-phase current A
 type PhaseCurrents interface {
 	Currents() (float64, float64, float64, error)
@@ -84,6 +94,11 @@ type PhasePowers interface {
 	Powers() (float64, float64, float64, error)
 }
 
+// MeterFrequency provides grid frequency in Hz
+type MeterFrequency interface {
+	Frequency() (float64, error)
+}
+
 // Battery provides battery Soc in %
 type Battery interface {
 	Soc() (float64, error)
@@ -94,6 +109,11 @@ type BatteryCapacity interface {
 	Capacity() float64
 }
 
+// BatteryController optionally allows to control home battery (dis)charging behaviour
+type BatteryController interface {
+	SetBatteryMode(BatteryMode) error
+}
+
 // ChargeState provides current charging status
 type ChargeState interface {
 	Status() (ChargeStatus, error)
@@ -117,6 +137,16 @@ type ChargerEx interface {
 	MaxCurrentMillis(current float64) error
 }
 
+// CurrentGetter provides reading back the active charger current limit
+type CurrentGetter interface {
+	GetMaxCurrent() (float64, error)
+}
+
+// PowerLimiter provides power-based charger control in W, e.g. for DC chargers
+type PowerLimiter interface {
+	MaxPower(power float64) error
+}
+
 // PhaseSwitcher provides 1p3p switching
 type PhaseSwitcher interface {
 	Phases1p3p(phases int) error
@@ -147,6 +177,11 @@ type Authorizer interface {
 	Authorize(key string) error
 }
 
+// ChargerFault provides the charger error state. A zero code indicates that no fault is present.
+type ChargerFault interface {
+	Fault() (int64, string, error)
+}
+
 // Vehicle represents the EV and it's battery
 type Vehicle interface {
 	Battery
@@ -175,6 +210,17 @@ type VehicleClimater interface {
 	Climater() (bool, error)
 }
 
+// VehicleClimateTemperature provides the vehicles climatisation target and outside temperature
+type VehicleClimateTemperature interface {
+	ClimateTemperature() (float64, float64, error)
+}
+
+// VehicleClimateController allows to start/stop climatisation on the vehicle side
+type VehicleClimateController interface {
+	StartClimate() error
+	StopClimate() error
+}
+
 // VehicleOdometer returns the vehicles milage
 type VehicleOdometer interface {
 	Odometer() (float64, error)
@@ -185,17 +231,53 @@ type VehiclePosition interface {
 	Position() (float64, float64, error)
 }
 
+// VehicleChargePort provides the vehicles charge port door state
+type VehicleChargePort interface {
+	ChargePortOpen() (bool, error)
+}
+
+// VehicleCableLock provides the vehicles charge cable lock state
+type VehicleCableLock interface {
+	CableLocked() (bool, error)
+}
+
 // SocLimiter returns the vehicles charge limit
 type SocLimiter interface {
 	TargetSoc() (float64, error)
 }
 
+// SocLimitController allows to set the vehicles charge limit
+type SocLimitController interface {
+	SetSocLimit(soc int) error
+}
+
+// VehicleCalibration provides the interval for recurring battery calibration charges to 100%
+type VehicleCalibration interface {
+	CalibrationInterval() time.Duration
+}
+
+// PriorityBoost raises the loadpoint priority in PV mode while the vehicle soc is below the threshold
+type PriorityBoost struct {
+	Soc      int `mapstructure:"soc"`      // boost while soc is below this value
+	Priority int `mapstructure:"priority"` // added to the loadpoint priority
+}
+
+// VehiclePriorityBoost provides the vehicle's soc-based loadpoint priority boost
+type VehiclePriorityBoost interface {
+	PriorityBoost() PriorityBoost
+}
+
 // VehicleChargeController allows to start/stop the charging session on the vehicle side
 type VehicleChargeController interface {
 	StartCharge() error
 	StopCharge() error
 }
 
+// UpdateNotifier is implemented by push-capable devices to trigger immediate loadpoint updates
+type UpdateNotifier interface {
+	UpdateNotify() <-chan struct{}
+}
+
 // Resurrector provides wakeup calls to the vehicle with an API call or a CP interrupt from the charger
 type Resurrector interface {
 	WakeUp() error
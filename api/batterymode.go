@@ -0,0 +1,12 @@
+package api
+
+//go:generate enumer -type BatteryMode -trimprefix Battery -transform=lower
+
+// BatteryMode is the home battery operation mode
+type BatteryMode int
+
+const (
+	BatteryUnknown BatteryMode = iota
+	BatteryNormal
+	BatteryHold
+)
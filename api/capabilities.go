@@ -0,0 +1,40 @@
+package api
+
+// MeterCapabilities are optional meter capabilities. Unlike interfaces like MeterEnergy they are
+// looked up instead of decorated since every decorated interface doubles the generated combinations.
+// Nil functions are not supported by the meter.
+type MeterCapabilities struct {
+	Frequency      func() (float64, error)          // grid frequency in Hz
+	TariffEnergy   func() (float64, float64, error) // import energy of tariff registers 1 and 2 in kWh
+	Curtailed      func() (bool, error)             // inverter power curtailment status
+	SetBatteryMode func(BatteryMode) error          // home battery (dis)charging control
+}
+
+// MeterCapabilityProvider provides optional meter capabilities
+type MeterCapabilityProvider interface {
+	MeterCapabilities() MeterCapabilities
+}
+
+// GetMeterCapabilities returns the optional capabilities of the meter.
+// Capabilities implemented as interfaces take precedence over looked up capabilities.
+func GetMeterCapabilities(meter any) MeterCapabilities {
+	var res MeterCapabilities
+	if m, ok := meter.(MeterCapabilityProvider); ok {
+		res = m.MeterCapabilities()
+	}
+
+	if m, ok := meter.(MeterFrequency); ok {
+		res.Frequency = m.Frequency
+	}
+	if m, ok := meter.(MeterTariffEnergy); ok {
+		res.TariffEnergy = m.TariffEnergy
+	}
+	if m, ok := meter.(MeterCurtailment); ok {
+		res.Curtailed = m.Curtailed
+	}
+	if m, ok := meter.(BatteryController); ok {
+		res.SetBatteryMode = m.SetBatteryMode
+	}
+
+	return res
+}
@@ -46,6 +46,8 @@ const (
 	amtronRegName       = 0x0311
 	amtronRegPower      = 0x030F
 	amtronRegAmpsConfig = 0x0400
+
+	amtronWriteRetries = 2 // box may silently drop writes
 )
 
 func init() {
@@ -129,8 +131,7 @@ func (wb *Amtron) Enable(enable bool) error {
 		u = wb.curr
 	}
 
-	_, err := wb.conn.WriteSingleRegister(amtronRegAmpsConfig, u)
-	return err
+	return wb.conn.WriteSingleRegisterVerified(amtronRegAmpsConfig, u, amtronWriteRetries)
 }
 
 // MaxCurrent implements the api.Charger interface
@@ -141,7 +142,7 @@ func (wb *Amtron) MaxCurrent(current int64) error {
 
 	cur := uint16(current)
 
-	_, err := wb.conn.WriteSingleRegister(amtronRegAmpsConfig, cur)
+	err := wb.conn.WriteSingleRegisterVerified(amtronRegAmpsConfig, cur, amtronWriteRetries)
 	if err == nil {
 		wb.curr = cur
 	}
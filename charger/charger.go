@@ -21,14 +21,14 @@ func init() {
 	registry.Add(api.Custom, NewConfigurableFromConfig)
 }
 
-// go:generate go run ../cmd/tools/decorate.go -f decorateCustom -b *Charger -r api.Charger -t "api.Identifier,Identify,func() (string, error)" -t "api.PhaseSwitcher,Phases1p3p,func(int) (error)" -t "api.Resurrector,WakeUp,func() (error)"
+// go:generate go run ../cmd/tools/decorate.go -f decorateCustom -b *Charger -r api.Charger -t "api.Identifier,Identify,func() (string, error)" -t "api.PhaseSwitcher,Phases1p3p,func(int) (error)" -t "api.Resurrector,WakeUp,func() (error)" -t "api.PowerLimiter,MaxPower,func(float64) (error)"
 
 // NewConfigurableFromConfig creates a new configurable charger
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error) {
 	var cc struct {
 		embed                               `mapstructure:",squash"`
 		Status, Enable, Enabled, MaxCurrent provider.Config
-		Identify, Phases1p3p                *provider.Config
+		Identify, Phases1p3p, MaxPower      *provider.Config
 		Wakeup                              *provider.Config
 	}
 
@@ -46,14 +46,28 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error
 		return nil, fmt.Errorf("enabled: %w", err)
 	}
 
-	enable, err := provider.NewBoolSetterFromConfig("enable", cc.Enable)
-	if err != nil {
-		return nil, fmt.Errorf("enable: %w", err)
+	// charger may not support switching, use vehicle charge control instead
+	enable := func(bool) error {
+		return api.ErrNotAvailable
 	}
 
-	maxcurrent, err := provider.NewIntSetterFromConfig("maxcurrent", cc.MaxCurrent)
-	if err != nil {
-		return nil, fmt.Errorf("maxcurrent: %w", err)
+	if cc.Enable.Source != "" {
+		enable, err = provider.NewBoolSetterFromConfig("enable", cc.Enable)
+		if err != nil {
+			return nil, fmt.Errorf("enable: %w", err)
+		}
+	}
+
+	// power controlled chargers may not support current control
+	maxcurrent := func(int64) error {
+		return api.ErrNotAvailable
+	}
+
+	if cc.MaxPower == nil || cc.MaxCurrent.Source != "" {
+		maxcurrent, err = provider.NewIntSetterFromConfig("maxcurrent", cc.MaxCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("maxcurrent: %w", err)
+		}
 	}
 
 	c, err := NewConfigurable(status, enabled, enable, maxcurrent)
@@ -98,7 +112,18 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error
 		}
 	}
 
-	return decorateCustom(c, identify, phases1p3p, wakeup), nil
+	// decorate power control
+	var maxPower func(float64) error
+	if cc.MaxPower != nil {
+		maxPowerS, err := provider.NewFloatSetterFromConfig("maxpower", *cc.MaxPower)
+		if err != nil {
+			return nil, fmt.Errorf("maxpower: %w", err)
+		}
+
+		maxPower = maxPowerS
+	}
+
+	return decorateCustom(c, identify, phases1p3p, wakeup, maxPower), nil
 }
 
 // NewConfigurable creates a new charger
@@ -6,12 +6,12 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitcher func(int) error, resurrector func() error) api.Charger {
+func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitcher func(int) error, resurrector func() error, powerLimiter func(float64) error) api.Charger {
 	switch {
-	case identifier == nil && phaseSwitcher == nil && resurrector == nil:
+	case identifier == nil && phaseSwitcher == nil && powerLimiter == nil && resurrector == nil:
 		return base
 
-	case identifier != nil && phaseSwitcher == nil && resurrector == nil:
+	case identifier != nil && phaseSwitcher == nil && powerLimiter == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -22,7 +22,7 @@ func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitc
 			},
 		}
 
-	case identifier == nil && phaseSwitcher != nil && resurrector == nil:
+	case identifier == nil && phaseSwitcher != nil && powerLimiter == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.PhaseSwitcher
@@ -33,7 +33,7 @@ func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitc
 			},
 		}
 
-	case identifier != nil && phaseSwitcher != nil && resurrector == nil:
+	case identifier != nil && phaseSwitcher != nil && powerLimiter == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -48,7 +48,7 @@ func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitc
 			},
 		}
 
-	case identifier == nil && phaseSwitcher == nil && resurrector != nil:
+	case identifier == nil && phaseSwitcher == nil && powerLimiter == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Resurrector
@@ -59,7 +59,7 @@ func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitc
 			},
 		}
 
-	case identifier != nil && phaseSwitcher == nil && resurrector != nil:
+	case identifier != nil && phaseSwitcher == nil && powerLimiter == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -74,7 +74,7 @@ func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitc
 			},
 		}
 
-	case identifier == nil && phaseSwitcher != nil && resurrector != nil:
+	case identifier == nil && phaseSwitcher != nil && powerLimiter == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.PhaseSwitcher
@@ -89,7 +89,7 @@ func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitc
 			},
 		}
 
-	case identifier != nil && phaseSwitcher != nil && resurrector != nil:
+	case identifier != nil && phaseSwitcher != nil && powerLimiter == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -107,6 +107,142 @@ func decorateCustom(base *Charger, identifier func() (string, error), phaseSwitc
 				resurrector: resurrector,
 			},
 		}
+
+	case identifier == nil && phaseSwitcher == nil && powerLimiter != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.PowerLimiter
+		}{
+			Charger: base,
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+		}
+
+	case identifier != nil && phaseSwitcher == nil && powerLimiter != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.PowerLimiter
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+		}
+
+	case identifier == nil && phaseSwitcher != nil && powerLimiter != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.PhaseSwitcher
+			api.PowerLimiter
+		}{
+			Charger: base,
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+		}
+
+	case identifier != nil && phaseSwitcher != nil && powerLimiter != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.PhaseSwitcher
+			api.PowerLimiter
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+		}
+
+	case identifier == nil && phaseSwitcher == nil && powerLimiter != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.PowerLimiter
+			api.Resurrector
+		}{
+			Charger: base,
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case identifier != nil && phaseSwitcher == nil && powerLimiter != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.PowerLimiter
+			api.Resurrector
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case identifier == nil && phaseSwitcher != nil && powerLimiter != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.PhaseSwitcher
+			api.PowerLimiter
+			api.Resurrector
+		}{
+			Charger: base,
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case identifier != nil && phaseSwitcher != nil && powerLimiter != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.PhaseSwitcher
+			api.PowerLimiter
+			api.Resurrector
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			PowerLimiter: &decorateCustomPowerLimiterImpl{
+				powerLimiter: powerLimiter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
 	}
 
 	return nil
@@ -128,6 +264,14 @@ func (impl *decorateCustomPhaseSwitcherImpl) Phases1p3p(phases int) error {
 	return impl.phaseSwitcher(phases)
 }
 
+type decorateCustomPowerLimiterImpl struct {
+	powerLimiter func(float64) error
+}
+
+func (impl *decorateCustomPowerLimiterImpl) MaxPower(power float64) error {
+	return impl.powerLimiter(power)
+}
+
 type decorateCustomResurrectorImpl struct {
 	resurrector func() error
 }
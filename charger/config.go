@@ -13,6 +13,9 @@ func (r chargerRegistry) Add(name string, factory func(map[string]interface{}) (
 	if _, exists := r[name]; exists {
 		panic(fmt.Sprintf("cannot register duplicate charger type: %s", name))
 	}
+	if name != strings.ToLower(name) {
+		panic(fmt.Sprintf("cannot register charger type with upper case characters: %s", name))
+	}
 	r[name] = factory
 }
 
@@ -48,6 +48,7 @@ type Easee struct {
 	log                   *util.Logger
 	mux                   sync.Mutex
 	done                  chan struct{}
+	notifyC               chan struct{}
 	dynamicChargerCurrent float64
 	current               float64
 	currentUpdated        time.Time
@@ -101,6 +102,7 @@ func NewEasee(user, password, charger string, timeout time.Duration) (*Easee, er
 		log:     log,
 		current: 6, // default current
 		done:    make(chan struct{}),
+		notifyC: make(chan struct{}, 1),
 	}
 
 	c.Client.Timeout = timeout
@@ -302,10 +304,24 @@ func (c *Easee) ProductUpdate(i json.RawMessage) {
 			c.log.DEBUG.Printf("current mismatch, expected %.1f, got %.1f", c.current, c.dynamicChargerCurrent)
 		}
 	case easee.CHARGER_OP_MODE:
-		c.opMode = value.(int)
+		if opMode := value.(int); opMode != c.opMode {
+			c.opMode = opMode
+
+			select {
+			case c.notifyC <- struct{}{}:
+			default:
+			}
+		}
 	}
 }
 
+var _ api.UpdateNotifier = (*Easee)(nil)
+
+// UpdateNotify implements the api.UpdateNotifier interface
+func (c *Easee) UpdateNotify() <-chan struct{} {
+	return c.notifyC
+}
+
 // ChargerUpdate implements the signalr receiver
 func (c *Easee) ChargerUpdate(i json.RawMessage) {
 	// c.observe("ChargerUpdate", i)
@@ -138,6 +138,18 @@ func (c *GoE) MaxCurrent(current int64) error {
 	return c.api.Update(fmt.Sprintf("%s=%d", param, current))
 }
 
+var _ api.CurrentGetter = (*GoE)(nil)
+
+// GetMaxCurrent implements the api.CurrentGetter interface
+func (c *GoE) GetMaxCurrent() (float64, error) {
+	resp, err := c.api.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.MaxCurrent(), err
+}
+
 var _ api.Meter = (*GoE)(nil)
 
 // CurrentPower implements the api.Meter interface
@@ -201,6 +213,19 @@ func (c *GoE) Identify() (string, error) {
 	return resp.Identify(), nil
 }
 
+var _ api.ChargerFault = (*GoE)(nil)
+
+// Fault implements the api.ChargerFault interface
+func (c *GoE) Fault() (int64, string, error) {
+	resp, err := c.api.Status()
+	if err != nil {
+		return 0, "", err
+	}
+
+	code, msg := resp.Fault()
+	return code, msg, nil
+}
+
 var _ api.MeterEnergy = (*GoE)(nil)
 
 // totalEnergy implements the api.MeterEnergy interface - v2 only
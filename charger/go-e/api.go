@@ -16,12 +16,14 @@ const CloudURI = "https://api.go-e.co"
 type Response interface {
 	Status() int
 	Enabled() bool
+	MaxCurrent() float64
 	CurrentPower() float64
 	ChargedEnergy() float64
 	TotalEnergy() float64
 	Currents() (float64, float64, float64)
 	Voltages() (float64, float64, float64)
 	Identify() string
+	Fault() (int64, string)
 }
 
 type UpdateResponse map[string]interface{}
@@ -88,7 +90,7 @@ func (c *LocalAPI) Status() (res Response, err error) {
 	if time.Since(c.updated) > c.cache {
 		if c.v2 {
 			c.status = new(StatusResponse2)
-			err = c.response("status?filter=alw,car,eto,nrg,wh,trx,cards", &c.status)
+			err = c.response("status?filter=alw,amp,car,eto,nrg,wh,trx,cards", &c.status)
 		} else {
 			c.status = new(StatusResponse)
 			err = c.response("status", &c.status)
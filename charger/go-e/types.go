@@ -41,6 +41,10 @@ func (g *StatusResponse) Enabled() bool {
 	return g.Alw == 1
 }
 
+func (g *StatusResponse) MaxCurrent() float64 {
+	return float64(g.Amp)
+}
+
 func (g *StatusResponse) CurrentPower() float64 {
 	if len(g.Nrg) == 16 {
 		return g.Nrg[11] * 10
@@ -96,3 +100,18 @@ func (g *StatusResponse) Identify() string {
 		return ""
 	}
 }
+
+func (g *StatusResponse) Fault() (int64, string) {
+	switch g.Err {
+	case 0:
+		return 0, ""
+	case 1:
+		return 1, "residual current device tripped"
+	case 3:
+		return 3, "phase error"
+	case 8:
+		return 8, "no ground"
+	default:
+		return int64(g.Err), "internal error"
+	}
+}
@@ -32,6 +32,10 @@ func (g *StatusResponse2) Enabled() bool {
 	return g.Alw
 }
 
+func (g *StatusResponse2) MaxCurrent() float64 {
+	return float64(g.Amp)
+}
+
 func (g *StatusResponse2) CurrentPower() float64 {
 	if len(g.Nrg) == 16 {
 		return g.Nrg[11]
@@ -71,3 +75,34 @@ func (g *StatusResponse2) Identify() string {
 
 	return ""
 }
+
+// faults2 are the v2 error descriptions
+var faults2 = map[int]string{
+	1:  "residual current device tripped (ac)",
+	2:  "residual current device tripped (dc)",
+	3:  "phase error",
+	4:  "overvoltage",
+	5:  "overcurrent",
+	6:  "diode error",
+	7:  "invalid proximity pilot",
+	8:  "invalid ground",
+	9:  "contactor stuck",
+	10: "contactor missing",
+	11: "residual current device error",
+	13: "overtemperature",
+	14: "no communication",
+	15: "cable lock stuck open",
+	16: "cable lock stuck locked",
+}
+
+func (g *StatusResponse2) Fault() (int64, string) {
+	if g.Err == 0 {
+		return 0, ""
+	}
+
+	if s, ok := faults2[g.Err]; ok {
+		return int64(g.Err), s
+	}
+
+	return int64(g.Err), "unknown error"
+}
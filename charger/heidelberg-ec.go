@@ -21,6 +21,8 @@ package charger
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
@@ -60,19 +62,34 @@ func init() {
 
 // NewHeidelbergECFromConfig creates a HeidelbergEC charger from generic config
 func NewHeidelbergECFromConfig(other map[string]interface{}) (api.Charger, error) {
-	cc := modbus.Settings{
-		ID: 1,
+	cc := struct {
+		modbus.Settings `mapstructure:",squash"`
+		Timeout         time.Duration // communication watchdog timeout
+		FailSafeCurrent float64       // current applied on communication loss
+	}{
+		Settings: modbus.Settings{
+			ID: 1,
+		},
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
 
-	return NewHeidelbergEC(cc.URI, cc.Device, cc.Comset, cc.Baudrate, modbus.ProtocolFromRTU(cc.RTU), cc.ID)
+	wb, err := NewHeidelbergEC(cc.URI, cc.Device, cc.Comset, cc.Baudrate, modbus.ProtocolFromRTU(cc.RTU), cc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cc.Timeout > 0 {
+		err = wb.failsafe(cc.Timeout, cc.FailSafeCurrent)
+	}
+
+	return wb, err
 }
 
 // NewHeidelbergEC creates HeidelbergEC charger
-func NewHeidelbergEC(uri, device, comset string, baudrate int, proto modbus.Protocol, slaveID uint8) (api.Charger, error) {
+func NewHeidelbergEC(uri, device, comset string, baudrate int, proto modbus.Protocol, slaveID uint8) (*HeidelbergEC, error) {
 	conn, err := modbus.NewConnection(uri, device, comset, baudrate, proto, slaveID)
 	if err != nil {
 		return nil, err
@@ -91,9 +108,28 @@ func NewHeidelbergEC(uri, device, comset string, baudrate int, proto modbus.Prot
 	}
 
 	// disable standby to prevent comm loss
-	err = wb.set(hecRegStandbyConfig, hecStandbyDisabled)
+	if err := wb.set(hecRegStandbyConfig, hecStandbyDisabled); err != nil {
+		return nil, err
+	}
 
-	return wb, err
+	return wb, nil
+}
+
+// failsafe configures the communication watchdog timeout and the current applied after timeout
+func (wb *HeidelbergEC) failsafe(timeout time.Duration, current float64) error {
+	if current != 0 && (current < 6 || current > 16) {
+		return fmt.Errorf("invalid failsafe current %.1f", current)
+	}
+
+	if ms := timeout.Milliseconds(); ms > math.MaxUint16 {
+		return fmt.Errorf("invalid timeout %v", timeout)
+	}
+
+	if err := wb.set(hecRegTimeoutConfig, uint16(timeout.Milliseconds())); err != nil {
+		return err
+	}
+
+	return wb.set(hecRegFailSafeConfig, uint16(10*current))
 }
 
 func (wb *HeidelbergEC) set(reg, val uint16) error {
@@ -9,13 +9,15 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger/keba"
+	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/util"
 )
 
 // https://www.keba.com/file/downloads/e-mobility/KeContact_P20_P30_UDP_ProgrGuide_en.pdf
 
 const (
-	udpTimeout = time.Second
+	udpTimeout   = time.Second
+	report2Cache = time.Second // status, fault and enabled are read from the same report within one update cycle
 )
 
 // KebaUdp is an api.Charger implementation
@@ -25,7 +27,9 @@ type KebaUdp struct {
 	rfid    keba.RFID
 	timeout time.Duration
 	recv    chan keba.UDPMsg
+	notifyC chan struct{}
 	sender  *keba.Sender
+	report2 provider.Cacheable[keba.Report2]
 }
 
 func init() {
@@ -85,19 +89,58 @@ func NewKebaUdp(uri, serial string, rfid keba.RFID, timeout time.Duration) (*Keb
 		rfid:    rfid,
 		timeout: timeout,
 		recv:    make(chan keba.UDPMsg),
+		notifyC: make(chan struct{}, 1),
 		sender:  sender,
 	}
 
+	c.report2 = provider.ResettableCached(func() (keba.Report2, error) {
+		var kr keba.Report2
+		err := c.roundtrip("report", 2, &kr)
+		return kr, err
+	}, report2Cache)
+
 	// use serial to subscribe if defined for docker scenarios
 	if serial == "" {
 		serial = conn
 	}
 
-	instance.Subscribe(serial, c.recv)
+	msgC := make(chan keba.UDPMsg)
+	instance.Subscribe(serial, msgC)
+
+	go c.dispatch(msgC)
 
 	return c, err
 }
 
+// dispatch separates unsolicited status broadcasts from request responses
+func (c *KebaUdp) dispatch(msgC <-chan keba.UDPMsg) {
+	for msg := range msgC {
+		// broadcasts like {"State": 2} or {"Plug": 7} don't carry a report id
+		if msg.Report != nil && msg.Report.ID == 0 {
+			c.report2.Reset()
+
+			select {
+			case c.notifyC <- struct{}{}:
+			default:
+			}
+			continue
+		}
+
+		select {
+		case c.recv <- msg:
+		default:
+			c.log.TRACE.Println("recv: listener blocked")
+		}
+	}
+}
+
+var _ api.UpdateNotifier = (*KebaUdp)(nil)
+
+// UpdateNotify implements the api.UpdateNotifier interface
+func (c *KebaUdp) UpdateNotify() <-chan struct{} {
+	return c.notifyC
+}
+
 func (c *KebaUdp) receive(report int, resC chan<- keba.UDPMsg, errC chan<- error, closeC <-chan struct{}) {
 	t := time.NewTimer(c.timeout)
 	defer close(resC)
@@ -168,8 +211,7 @@ func (c *KebaUdp) roundtrip(msg string, report int, res interface{}) error {
 
 // Status implements the api.Charger interface
 func (c *KebaUdp) Status() (api.ChargeStatus, error) {
-	var kr keba.Report2
-	err := c.roundtrip("report", 2, &kr)
+	kr, err := c.report2.Get()
 	if err != nil {
 		return api.StatusA, err
 	}
@@ -187,10 +229,34 @@ func (c *KebaUdp) Status() (api.ChargeStatus, error) {
 	return api.StatusA, fmt.Errorf("unexpected status: %+v", kr)
 }
 
+var _ api.ChargerFault = (*KebaUdp)(nil)
+
+// Fault implements the api.ChargerFault interface
+func (c *KebaUdp) Fault() (int64, string, error) {
+	kr, err := c.report2.Get()
+	if err != nil {
+		return 0, "", err
+	}
+
+	if kr.State != 4 && kr.Error1 == 0 {
+		return 0, "", nil
+	}
+
+	// error state may be reported without error code
+	code := kr.Error1
+	if code == 0 {
+		code = kr.Error2
+	}
+	if code == 0 {
+		code = kr.State
+	}
+
+	return int64(code), fmt.Sprintf("error code %d/%d", kr.Error1, kr.Error2), nil
+}
+
 // Enabled implements the api.Charger interface
 func (c *KebaUdp) Enabled() (bool, error) {
-	var kr keba.Report2
-	err := c.roundtrip("report", 2, &kr)
+	kr, err := c.report2.Get()
 	if err != nil {
 		return false, err
 	}
@@ -201,8 +267,8 @@ func (c *KebaUdp) Enabled() (bool, error) {
 // enableRFID sends RFID credentials to enable charge
 func (c *KebaUdp) enableRFID() error {
 	// check if authorization required
-	var kr keba.Report2
-	if err := c.roundtrip("report", 2, &kr); err != nil {
+	kr, err := c.report2.Get()
+	if err != nil {
 		return err
 	}
 
@@ -223,6 +289,8 @@ func (c *KebaUdp) enableRFID() error {
 
 // Enable implements the api.Charger interface
 func (c *KebaUdp) Enable(enable bool) error {
+	defer c.report2.Reset()
+
 	if enable {
 		if err := c.enableRFID(); err != nil {
 			return err
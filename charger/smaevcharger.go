@@ -248,6 +248,14 @@ func (wb *Smaevcharger) ChargedEnergy() (float64, error) {
 	return res / 1e3, err
 }
 
+var _ api.MeterEnergy = (*Smaevcharger)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (wb *Smaevcharger) TotalEnergy() (float64, error) {
+	res, err := wb.getMeasurement("Measurement.Metering.GridMs.TotWhIn")
+	return res / 1e3, err
+}
+
 var _ api.PhaseCurrents = (*Smaevcharger)(nil)
 
 // Currents implements the api.PhaseCurrents interface
@@ -98,3 +98,17 @@ var _ api.MeterEnergy = (*TPLink)(nil)
 func (c *TPLink) TotalEnergy() (float64, error) {
 	return c.conn.TotalEnergy()
 }
+
+var _ api.PhaseCurrents = (*TPLink)(nil)
+
+// Currents implements the api.PhaseCurrents interface
+func (c *TPLink) Currents() (float64, float64, float64, error) {
+	return c.conn.Currents()
+}
+
+var _ api.PhaseVoltages = (*TPLink)(nil)
+
+// Voltages implements the api.PhaseVoltages interface
+func (c *TPLink) Voltages() (float64, float64, float64, error) {
+	return c.conn.Voltages()
+}
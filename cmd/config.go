@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"net"
@@ -15,10 +16,12 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/core/metrics"
 	"github.com/evcc-io/evcc/meter"
 	"github.com/evcc-io/evcc/provider/mqtt"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/server/oauth2redirect"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/modbus"
@@ -42,8 +45,10 @@ var conf = config{
 		Topic: "evcc",
 	},
 	Database: dbConfig{
-		Type: "sqlite",
-		Dsn:  "~/.evcc/evcc.db",
+		Type:      "sqlite",
+		Dsn:       "~/.evcc/evcc.db",
+		Flush:     15 * time.Minute,
+		Retention: metrics.DefaultRetention,
 	},
 }
 
@@ -58,12 +63,15 @@ type config struct {
 	Profile      bool
 	Levels       map[string]string
 	Interval     time.Duration
+	Timezone     string
 	Database     dbConfig
 	Mqtt         mqttConfig
+	Installer    installerConfig
 	ModbusProxy  []proxyConfig
 	Javascript   []javascriptConfig
 	Go           []goConfig
 	Influx       server.InfluxConfig
+	WLED         server.WLEDConfig
 	EEBus        map[string]interface{}
 	HEMS         typedConfig
 	Messaging    messagingConfig
@@ -75,9 +83,14 @@ type config struct {
 	Loadpoints   []map[string]interface{}
 }
 
+type installerConfig struct {
+	Pin string // protects installer settings like currents and phases
+}
+
 type mqttConfig struct {
 	mqtt.Config `mapstructure:",squash"`
 	Topic       string
+	Discovery   string // Home Assistant discovery prefix
 }
 
 type javascriptConfig struct {
@@ -97,8 +110,10 @@ type proxyConfig struct {
 }
 
 type dbConfig struct {
-	Type string
-	Dsn  string
+	Type      string
+	Dsn       string
+	Flush     time.Duration // batch writes
+	Retention metrics.Retention
 }
 
 type qualifiedConfig struct {
@@ -112,8 +127,10 @@ type typedConfig struct {
 }
 
 type messagingConfig struct {
+	Language string // message language, defaults to system language
 	Events   map[string]push.EventTemplateConfig
-	Services []typedConfig
+	Services []qualifiedConfig // services are named by type unless name is given
+	Summary  string            // daily or weekly session summary
 }
 
 type tariffConfig struct {
@@ -128,6 +145,12 @@ type networkConfig struct {
 	Schema string
 	Host   string
 	Port   int
+	TLS    tlsConfig
+}
+
+type tlsConfig struct {
+	Enabled   bool
+	Cert, Key string // certificate and private key files, self-signed certificate is created if empty
 }
 
 func (c networkConfig) HostPort() string {
@@ -146,6 +169,7 @@ type ConfigProvider struct {
 	meters   map[string]api.Meter
 	chargers map[string]api.Charger
 	vehicles map[string]api.Vehicle
+	ids      map[string]map[string]string // persistent device ids by class and name
 	visited  map[string]bool
 	auth     *util.AuthCollection
 }
@@ -186,7 +210,16 @@ func (cp *ConfigProvider) Vehicle(name string) (api.Vehicle, error) {
 	return nil, fmt.Errorf("vehicle does not exist: %s", name)
 }
 
+// DeviceID provides the persistent id of a configured device by class and name
+func (cp *ConfigProvider) DeviceID(class, name string) string {
+	return cp.ids[class][name]
+}
+
 func (cp *ConfigProvider) configure(conf config) error {
+	if err := lintDevices(conf); err != nil {
+		return err
+	}
+
 	err := cp.configureMeters(conf)
 	if err == nil {
 		err = cp.configureChargers(conf)
@@ -194,30 +227,79 @@ func (cp *ConfigProvider) configure(conf config) error {
 	if err == nil {
 		err = cp.configureVehicles(conf)
 	}
+	if err == nil {
+		err = cp.configureDeviceIDs(conf)
+	}
 	return err
 }
 
+// deviceFingerprint identifies a device configuration independent of device name and title
+func deviceFingerprint(cc qualifiedConfig) string {
+	other := maps.Clone(cc.Other)
+	delete(other, "title")
+
+	// hashed since configuration contains credentials
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s %v", strings.ToLower(cc.Type), other))))
+}
+
+// configureDeviceIDs assigns persistent ids to the configured devices
+func (cp *ConfigProvider) configureDeviceIDs(conf config) error {
+	classes := map[string][]qualifiedConfig{
+		"meter":   conf.Meters,
+		"charger": conf.Chargers,
+		"vehicle": conf.Vehicles,
+	}
+
+	cp.ids = make(map[string]map[string]string)
+
+	for class, devices := range classes {
+		fingerprints := make(map[string]string, len(devices))
+		for _, cc := range devices {
+			fingerprints[cc.Name] = deviceFingerprint(cc)
+		}
+
+		ids, err := settings.DeviceIDs(class, fingerprints)
+		if err != nil {
+			return fmt.Errorf("%s ids: %w", class, err)
+		}
+
+		cp.ids[class] = ids
+	}
+
+	return nil
+}
+
 func (cp *ConfigProvider) configureMeters(conf config) error {
+	var mu sync.Mutex
+	g, _ := errgroup.WithContext(context.Background())
+
 	cp.meters = make(map[string]api.Meter)
 	for id, cc := range conf.Meters {
 		if cc.Name == "" {
 			return fmt.Errorf("cannot create %s meter: missing name", humanize.Ordinal(id+1))
 		}
 
-		m, err := meter.NewFromConfig(cc.Type, cc.Other)
-		if err != nil {
-			err = fmt.Errorf("cannot create meter '%s': %w", cc.Name, err)
-			return err
-		}
+		cc := cc
 
-		if _, exists := cp.meters[cc.Name]; exists {
-			return fmt.Errorf("duplicate meter name: %s already defined and must be unique", cc.Name)
-		}
+		g.Go(func() error {
+			m, err := meter.NewFromConfig(cc.Type, cc.Other)
+			if err != nil {
+				return fmt.Errorf("cannot create meter '%s': %w", cc.Name, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if _, exists := cp.meters[cc.Name]; exists {
+				return fmt.Errorf("duplicate meter name: %s already defined and must be unique", cc.Name)
+			}
 
-		cp.meters[cc.Name] = m
+			cp.meters[cc.Name] = m
+			return nil
+		})
 	}
 
-	return nil
+	return g.Wait()
 }
 
 func (cp *ConfigProvider) configureChargers(conf config) error {
@@ -266,22 +348,33 @@ func (cp *ConfigProvider) configureVehicles(conf config) error {
 		cc := cc
 
 		g.Go(func() error {
-			v, err := vehicle.NewFromConfig(cc.Type, cc.Other)
+			create := func() (api.Vehicle, error) {
+				v, err := vehicle.NewFromConfig(cc.Type, cc.Other)
+				if err != nil {
+					return nil, err
+				}
+
+				// ensure vehicle config has title
+				if v.Title() == "" {
+					//lint:ignore SA1019 as Title is safe on ascii
+					v.SetTitle(strings.Title(cc.Name))
+				}
+
+				return v, nil
+			}
+
+			v, err := create()
 			if err != nil {
 				var ce *util.ConfigError
 				if errors.As(err, &ce) {
 					return fmt.Errorf("cannot create vehicle '%s': %w", cc.Name, err)
 				}
 
-				// wrap non-config vehicle errors to prevent fatals
+				// wrap non-config vehicle errors to prevent fatals and retry in background
 				log.ERROR.Printf("creating vehicle %s failed: %v", cc.Name, err)
-				v = wrapper.New(cc.Name, cc.Other, err)
-			}
-
-			// ensure vehicle config has title
-			if v.Title() == "" {
-				//lint:ignore SA1019 as Title is safe on ascii
-				v.SetTitle(strings.Title(cc.Name))
+				w := wrapper.New(cc.Name, cc.Other, err)
+				go w.Retry(log, create)
+				v = w
 			}
 
 			mu.Lock()
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// deviceBus is the modbus connection of a device configuration
+type deviceBus struct {
+	ref      string // device class and name
+	conn     string // serial device or network address
+	baudrate int
+	comset   string
+	rtu      *bool // rtu framing over tcp
+}
+
+// modbusBus extracts the modbus connection from a device configuration
+func modbusBus(ref string, other map[string]interface{}) (deviceBus, bool) {
+	var cc struct {
+		URI, Host, Device string
+		Port              int
+		ID                int
+		Baudrate          int
+		Comset            string
+		RTU               *bool
+		Modbus            string
+		Other             map[string]interface{} `mapstructure:",remain"`
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return deviceBus{}, false
+	}
+
+	// not a modbus device
+	if cc.ID == 0 && cc.Modbus == "" && cc.Device == "" && cc.RTU == nil {
+		return deviceBus{}, false
+	}
+
+	res := deviceBus{
+		ref:      ref,
+		baudrate: cc.Baudrate,
+		comset:   strings.ToUpper(cc.Comset),
+		rtu:      cc.RTU,
+	}
+
+	switch {
+	case cc.Device != "":
+		res.conn = cc.Device
+	case cc.URI != "":
+		res.conn = cc.URI
+	case cc.Host != "":
+		port := cc.Port
+		if port == 0 {
+			port = 502
+		}
+		res.conn = net.JoinHostPort(cc.Host, strconv.Itoa(port))
+	default:
+		return deviceBus{}, false
+	}
+
+	// template modbus selection
+	if cc.Modbus == "rs485tcpip" || cc.Modbus == "tcpip" {
+		rtu := cc.Modbus == "rs485tcpip"
+		res.rtu = &rtu
+	}
+
+	return res, true
+}
+
+// conflict returns the settings conflicting between two devices on the same connection.
+// Settings not defined by both devices are ignored.
+func (b deviceBus) conflict(o deviceBus) string {
+	switch {
+	case b.baudrate != 0 && o.baudrate != 0 && b.baudrate != o.baudrate:
+		return fmt.Sprintf("baudrate %d vs %d", b.baudrate, o.baudrate)
+	case b.comset != "" && o.comset != "" && b.comset != o.comset:
+		return fmt.Sprintf("comset %s vs %s", b.comset, o.comset)
+	case b.rtu != nil && o.rtu != nil && *b.rtu != *o.rtu:
+		return fmt.Sprintf("rtu %t vs %t", *b.rtu, *o.rtu)
+	}
+	return ""
+}
+
+// lintDevices checks meter and charger configurations for duplicate names, identical definitions
+// and devices sharing a modbus connection with conflicting settings
+func lintDevices(conf config) error {
+	classes := []struct {
+		class   string
+		devices []qualifiedConfig
+	}{
+		{"meter", conf.Meters},
+		{"charger", conf.Chargers},
+	}
+
+	var buses []deviceBus
+
+	for _, c := range classes {
+		names := make(map[string]bool)
+
+		for i, cc := range c.devices {
+			if cc.Name != "" && names[cc.Name] {
+				return fmt.Errorf("duplicate %s name: %s already defined and must be unique", c.class, cc.Name)
+			}
+			names[cc.Name] = true
+
+			for _, prev := range c.devices[:i] {
+				if strings.EqualFold(prev.Type, cc.Type) && reflect.DeepEqual(prev.Other, cc.Other) {
+					log.WARN.Printf("%s '%s' is identical to '%s'", c.class, cc.Name, prev.Name)
+				}
+			}
+
+			bus, ok := modbusBus(fmt.Sprintf("%s '%s'", c.class, cc.Name), cc.Other)
+			if !ok {
+				continue
+			}
+
+			for _, prev := range buses {
+				if prev.conn != bus.conn {
+					continue
+				}
+
+				if conflict := prev.conflict(bus); conflict != "" {
+					return fmt.Errorf("%s and %s share modbus connection %s with conflicting settings: %s", prev.ref, bus.ref, bus.conn, conflict)
+				}
+			}
+
+			buses = append(buses, bus)
+		}
+	}
+
+	return nil
+}
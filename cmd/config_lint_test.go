@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintDevices(t *testing.T) {
+	serial := func(name string, id int, baudrate int) qualifiedConfig {
+		return qualifiedConfig{Name: name, Type: "modbus", Other: map[string]interface{}{
+			"model": "sdm", "device": "/dev/ttyUSB0", "id": id, "baudrate": baudrate, "comset": "8N1",
+		}}
+	}
+
+	// shared rs485 bus
+	assert.NoError(t, lintDevices(config{
+		Meters: []qualifiedConfig{serial("grid", 1, 9600), serial("pv", 2, 9600)},
+	}))
+
+	// undefined settings are not conflicting
+	assert.NoError(t, lintDevices(config{
+		Meters: []qualifiedConfig{serial("grid", 1, 9600), serial("pv", 2, 0)},
+	}))
+
+	assert.ErrorContains(t, lintDevices(config{
+		Meters: []qualifiedConfig{serial("grid", 1, 9600), serial("pv", 2, 19200)},
+	}), "baudrate 9600 vs 19200")
+
+	// rtu over tcp vs tcp on same gateway
+	assert.ErrorContains(t, lintDevices(config{
+		Meters: []qualifiedConfig{
+			{Name: "grid", Type: "template", Other: map[string]interface{}{"template": "sdm", "modbus": "rs485tcpip", "host": "192.0.2.1", "id": 1}},
+		},
+		Chargers: []qualifiedConfig{
+			{Name: "wallbox", Type: "template", Other: map[string]interface{}{"template": "abl", "modbus": "tcpip", "host": "192.0.2.1", "port": 502, "id": 2}},
+		},
+	}), "rtu true vs false")
+
+	// duplicate names
+	assert.ErrorContains(t, lintDevices(config{
+		Meters: []qualifiedConfig{serial("grid", 1, 9600), serial("grid", 2, 9600)},
+	}), "duplicate meter name")
+
+	// non-modbus devices
+	assert.NoError(t, lintDevices(config{
+		Chargers: []qualifiedConfig{
+			{Name: "a", Type: "easee", Other: map[string]interface{}{"charger": "EH123", "user": "foo"}},
+			{Name: "b", Type: "easee", Other: map[string]interface{}{"charger": "EH456", "user": "foo"}},
+		},
+	}))
+}
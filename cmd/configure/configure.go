@@ -40,6 +40,7 @@ type config struct {
 		PVs       []string
 		Batteries []string
 	}
+	Region       region
 	Hems         string
 	EEBUS        string
 	MQTT         string
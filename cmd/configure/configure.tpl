@@ -63,6 +63,9 @@ loadpoints:
 
 site:
   title: {{ .Site.Title }}
+{{- if and .Region.Voltage (ne .Region.Voltage 230) }}
+  voltage: {{ .Region.Voltage }}
+{{- end }}
   meters:
 {{- if .Site.Grid }}
     grid: {{ .Site.Grid }}
@@ -79,6 +82,17 @@ site:
     - {{ . }}
     {{- end }}
 {{- end }}
+{{- if .Region.Currency }}
+
+tariffs:
+  currency: {{ .Region.Currency }}
+  grid:
+    type: fixed
+    price: {{ .Region.GridPrice }} # {{ .Region.Currency }}/kWh, adjust to your tariff
+  feedin:
+    type: fixed
+    price: {{ .Region.FeedInPrice }} # {{ .Region.Currency }}/kWh, adjust to your tariff
+{{- end }}
 {{- if .Hems }}
 
 hems:
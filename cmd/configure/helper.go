@@ -331,6 +331,20 @@ func (c *CmdConfigure) fetchElements(deviceCategory DeviceCategory) []templates.
 	}
 
 	sort.Slice(items[:], func(i, j int) bool {
+		// sort the region's shortlist to the top
+		pi, pj := c.region.preferredTemplate(items[i]), c.region.preferredTemplate(items[j])
+		if pi >= 0 || pj >= 0 {
+			if pi < 0 {
+				return false
+			}
+			if pj < 0 {
+				return true
+			}
+			if pi != pj {
+				return pi < pj
+			}
+		}
+
 		// sort generic templates to the bottom
 		if items[i].Group != "" && items[j].Group == "" {
 			return false
@@ -38,6 +38,8 @@ type CmdConfigure struct {
 	errItemNotPresent, errDeviceNotValid error
 
 	capabilitySMAHems bool
+
+	region region
 }
 
 // Run starts the interactive configuration
@@ -70,6 +72,9 @@ func (c *CmdConfigure) Run(log *util.Logger, flagLang string, advancedMode, expa
 
 	c.setDefaultTexts()
 
+	// use fallback region unless selected otherwise
+	c.region = regions[len(regions)-1]
+
 	fmt.Println()
 	fmt.Println(c.localizedString("Intro"))
 
@@ -159,6 +164,10 @@ func (c *CmdConfigure) flowSingleDevice(category DeviceCategory) {
 
 // configureNewConfigFile implements the flow for creating a new configuration file
 func (c *CmdConfigure) flowNewConfigFile() {
+	fmt.Println()
+	fmt.Println(c.localizedString("Region_Setup"))
+	c.configureRegion()
+
 	fmt.Println()
 	fmt.Println(c.localizedString("Flow_NewConfiguration_Setup"))
 	fmt.Println()
@@ -301,7 +310,7 @@ func (c *CmdConfigure) configureLoadpoints() {
 		})
 		loadpoint := loadpoint{
 			Title:      loadpointTitle,
-			Phases:     3,
+			Phases:     c.region.Phases,
 			MinCurrent: 6,
 		}
 
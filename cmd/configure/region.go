@@ -0,0 +1,78 @@
+package configure
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/util/templates"
+	"golang.org/x/exp/slices"
+)
+
+// region contains the country specific defaults
+type region struct {
+	Code        string
+	Title       string
+	Voltage     int
+	Phases      int
+	Currency    string
+	GridPrice   float64  // typical grid price per kWh
+	FeedInPrice float64  // typical feed-in compensation per kWh
+	templates   []string // templates commonly found in this region
+}
+
+// regions is the list of supported regions. The last entry is used as fallback.
+var regions = []region{
+	{
+		Code: "de", Title: "Deutschland", Voltage: 230, Phases: 3, Currency: "EUR", GridPrice: 0.40, FeedInPrice: 0.08,
+		templates: []string{"go-e", "go-e-v3", "keba", "keba-modbus", "openwb", "easee", "sma-home-manager", "sma-hybrid", "fronius-gen24", "kostal-plenticore"},
+	},
+	{
+		Code: "at", Title: "Österreich", Voltage: 230, Phases: 3, Currency: "EUR", GridPrice: 0.30, FeedInPrice: 0.10,
+		templates: []string{"go-e", "go-e-v3", "fronius-wattpilot", "keba", "easee", "fronius-gen24", "fronius-solarapi-v1", "huawei-dongle"},
+	},
+	{
+		Code: "ch", Title: "Schweiz", Voltage: 230, Phases: 3, Currency: "CHF", GridPrice: 0.30, FeedInPrice: 0.10,
+		templates: []string{"go-e", "go-e-v3", "zaptec", "keba", "easee", "fronius-gen24", "sma-home-manager"},
+	},
+	{
+		Code: "nl", Title: "Nederland", Voltage: 230, Phases: 3, Currency: "EUR", GridPrice: 0.40, FeedInPrice: 0.07,
+		templates: []string{"alfen", "easee", "zaptec", "go-e", "enphase", "solaredge-inverter", "huawei-dongle"},
+	},
+	{
+		Code: "no", Title: "Norge", Voltage: 230, Phases: 3, Currency: "NOK", GridPrice: 2.00, FeedInPrice: 0.50,
+		templates: []string{"easee", "zaptec", "go-e", "tesla-twc3"},
+	},
+	{
+		Code: "gb", Title: "United Kingdom", Voltage: 230, Phases: 1, Currency: "GBP", GridPrice: 0.30, FeedInPrice: 0.15,
+		templates: []string{"ocpp", "easee", "zaptec", "tesla-twc3", "tesla-powerwall", "solaredge-inverter", "enphase"},
+	},
+	{
+		Code: "us", Title: "United States", Voltage: 240, Phases: 1, Currency: "USD", GridPrice: 0.16, FeedInPrice: 0.08,
+		templates: []string{"tesla-twc3", "openevse", "tesla-powerwall", "enphase", "solaredge-inverter"},
+	},
+	{
+		Code: "", Voltage: 230, Phases: 3,
+	},
+}
+
+// configureRegion asks for the installation's country and applies the regional defaults
+func (c *CmdConfigure) configureRegion() {
+	choices := make([]string, 0, len(regions))
+	for _, r := range regions {
+		title := r.Title
+		if r.Code == "" {
+			title = c.localizedString("Region_Other")
+		}
+		choices = append(choices, title)
+	}
+
+	fmt.Println()
+	idx, _ := c.askChoice(c.localizedString("Region_Select"), choices)
+
+	c.region = regions[idx]
+	c.configuration.config.Region = c.region
+}
+
+// preferredTemplate returns the rank of a template within the region's shortlist or -1
+func (r region) preferredTemplate(tmpl templates.Template) int {
+	return slices.Index(r.templates, tmpl.Template)
+}
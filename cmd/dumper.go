@@ -174,6 +174,14 @@ func (d *dumper) Dump(name string, v interface{}) {
 		}
 	}
 
+	if v, ok := v.(api.VehicleClimateTemperature); ok {
+		if target, outside, err := v.ClimateTemperature(); err != nil {
+			fmt.Fprintf(w, "Climate temp:\t%v\n", err)
+		} else {
+			fmt.Fprintf(w, "Climate temp:\t%.1f°C target, %.1f°C outside\n", target, outside)
+		}
+	}
+
 	if v, ok := v.(api.VehiclePosition); ok {
 		if lat, lon, err := v.Position(); err != nil {
 			fmt.Fprintf(w, "Position:\t%v\n", err)
@@ -182,6 +190,22 @@ func (d *dumper) Dump(name string, v interface{}) {
 		}
 	}
 
+	if v, ok := v.(api.VehicleChargePort); ok {
+		if open, err := v.ChargePortOpen(); err != nil {
+			fmt.Fprintf(w, "Charge port:\t%v\n", err)
+		} else {
+			fmt.Fprintf(w, "Charge port open:\t%v\n", open)
+		}
+	}
+
+	if v, ok := v.(api.VehicleCableLock); ok {
+		if locked, err := v.CableLocked(); err != nil {
+			fmt.Fprintf(w, "Cable lock:\t%v\n", err)
+		} else {
+			fmt.Fprintf(w, "Cable locked:\t%v\n", locked)
+		}
+	}
+
 	if v, ok := v.(api.SocLimiter); ok {
 		if targetSoc, err := v.TargetSoc(); err != nil {
 			fmt.Fprintf(w, "Target Soc:\t%v\n", err)
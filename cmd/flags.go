@@ -33,6 +33,9 @@ const (
 	flagStop            = "stop"
 	flagStopDescription = "Stop charging"
 
+	flagSocLimit            = "soclimit"
+	flagSocLimitDescription = "Set vehicle charge limit (%)"
+
 	flagDigits = "digits"
 	flagDelay  = "delay"
 )
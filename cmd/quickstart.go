@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/evcc-io/evcc/detect"
+	"github.com/evcc-io/evcc/detect/tasks"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// quickstartConfig loads the configuration created by quickstart from the database if available
+func quickstartConfig(conf *config) (bool, error) {
+	conn, err := db.New(strings.ToLower(conf.Database.Type), conf.Database.Dsn)
+	if err != nil {
+		return false, err
+	}
+
+	if sqlDB, err := conn.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	var yaml string
+	if err := conn.Table("settings").Select("value").Where("key = ?", server.QuickstartConfigKey).Take(&yaml).Error; err != nil {
+		// settings table may not exist yet
+		if errors.Is(err, gorm.ErrRecordNotFound) || !conn.Migrator().HasTable("settings") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader(yaml)); err != nil {
+		return false, fmt.Errorf("failed decoding quickstart config: %w", err)
+	}
+
+	if err := viper.UnmarshalExact(&conf); err != nil {
+		return false, fmt.Errorf("failed loading quickstart config: %w", err)
+	}
+
+	// parse log levels after reading config
+	parseLogLevels()
+
+	return true, nil
+}
+
+// quickstartDetect scans the local network for devices
+func quickstartDetect() []tasks.Result {
+	hosts := []string{"127.0.0.1"}
+	if ips := util.LocalIPs(); len(ips) > 0 {
+		hosts = append(hosts, IPsFromSubnet(ips[0].String())...)
+	}
+
+	var res []tasks.Result
+	for _, hit := range detect.Work(log, 50, hosts) {
+		switch hit.ID {
+		case detect.TaskPing, detect.TaskHttp, detect.TaskModbus:
+			continue
+		default:
+			res = append(res, hit)
+		}
+	}
+
+	return res
+}
@@ -15,11 +15,13 @@ import (
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/server/modbus"
 	"github.com/evcc-io/evcc/server/updater"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/pipe"
 	"github.com/evcc-io/evcc/util/sponsor"
+	"github.com/evcc-io/evcc/util/supervisor"
 	"github.com/evcc-io/evcc/util/telemetry"
 	"github.com/fatih/structs"
 	"github.com/jeremywohl/flatten"
@@ -115,20 +117,38 @@ func Execute() {
 func runRoot(cmd *cobra.Command, args []string) {
 	// load config and re-configure logging after reading config file
 	var err error
+	var demoMode bool
 	if cfgErr := loadConfigFile(&conf); errors.As(cfgErr, &viper.ConfigFileNotFoundError{}) {
-		log.INFO.Println("missing config file - switching into demo mode")
-		if err := demoConfig(&conf); err != nil {
-			log.FATAL.Fatal(err)
+		if ok, qsErr := quickstartConfig(&conf); ok || qsErr != nil {
+			log.INFO.Println("missing config file - using quickstart configuration")
+			err = qsErr
+		} else {
+			log.INFO.Println("missing config file - switching into demo mode")
+			if err := demoConfig(&conf); err != nil {
+				log.FATAL.Fatal(err)
+			}
+			demoMode = true
 		}
 	} else {
 		err = cfgErr
 	}
 
+	// home assistant add-on
+	if err == nil && supervisor.Enabled() {
+		configureSupervisor(&conf)
+	}
+
 	// network config
 	if viper.GetString("uri") != "" {
 		log.WARN.Println("`uri` is deprecated and will be ignored. Use `network` instead.")
 	}
 
+	// https
+	var certFile, keyFile string
+	if err == nil && conf.Network.TLS.Enabled {
+		certFile, keyFile, err = configureTLS(&conf.Network)
+	}
+
 	log.INFO.Printf("starting ui and api at :%d", conf.Network.Port)
 
 	// start broadcasting values
@@ -141,6 +161,12 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// create web server
 	socketHub := server.NewSocketHub()
 	httpd := server.NewHTTPd(fmt.Sprintf(":%d", conf.Network.Port), socketHub)
+	httpd.SetInstallerPin(conf.Installer.Pin)
+
+	// discover devices and create configuration
+	if demoMode {
+		httpd.RegisterQuickstartHandlers(quickstartDetect)
+	}
 
 	// metrics
 	if viper.GetBool("metrics") {
@@ -191,14 +217,31 @@ func runRoot(cmd *cobra.Command, args []string) {
 		site, err = configureSiteAndLoadpoints(conf)
 	}
 
+	// setup local metrics
+	if err == nil && db.Instance != nil {
+		err = configureMetrics(conf.Database, pipe.NewDropper(append(ignoreErrors, ignoreEmpty)...).Pipe(tee.Attach()))
+	}
+
 	// setup database
 	if err == nil && conf.Influx.URL != "" {
-		configureInflux(conf.Influx, site, pipe.NewDropper(append(ignoreErrors, ignoreEmpty)...).Pipe(tee.Attach()))
+		err = configureInflux(conf.Influx, site, pipe.NewDropper(append(ignoreErrors, ignoreEmpty)...).Pipe(tee.Attach()))
+	}
+
+	// setup prometheus metrics
+	if err == nil && viper.GetBool("metrics") {
+		err = configurePrometheus(site, pipe.NewDropper(append(ignoreErrors, ignoreEmpty)...).Pipe(tee.Attach()))
+	}
+
+	// setup led output
+	if err == nil && conf.WLED.URI != "" {
+		err = configureWLED(conf.WLED, tee.Attach())
 	}
 
 	// setup mqtt publisher
 	if err == nil && conf.Mqtt.Broker != "" {
 		publisher := server.NewMQTT(strings.Trim(conf.Mqtt.Topic, "/"))
+		publisher.Protected = conf.Installer.Pin != ""
+		publisher.Discovery = strings.Trim(conf.Mqtt.Discovery, "/")
 		go publisher.Run(site, pipe.NewDropper(append(ignoreMqtt, ignoreEmpty)...).Pipe(tee.Attach()))
 	}
 
@@ -218,6 +261,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		pushChan, err = configureMessengers(conf.Messaging, valueChan, cache)
 	}
 
+	// setup session summary
+	if err == nil && conf.Messaging.Summary != "" {
+		err = core.RunSummary(conf.Messaging.Summary, pushChan)
+	}
+
 	// run shutdown functions on stop
 	var once sync.Once
 	stopC := make(chan struct{})
@@ -275,6 +323,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 			valueChan <- util.Param{Key: "sponsorTokenExpires", Val: validDuration}
 		}
 
+		// offer device discovery to UI
+		if demoMode {
+			valueChan <- util.Param{Key: "quickstart", Val: true}
+		}
+
 		// allow web access for vehicles
 		cp.webControl(conf.Network, httpd.Router(), valueChan)
 
@@ -304,5 +357,9 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// uds health check listener
 	go server.HealthListener(site)
 
-	log.FATAL.Println(wrapErrors(httpd.ListenAndServe()))
+	if certFile != "" {
+		log.FATAL.Println(wrapErrors(httpd.ListenAndServeTLS(certFile, keyFile)))
+	} else {
+		log.FATAL.Println(wrapErrors(httpd.ListenAndServe()))
+	}
 }
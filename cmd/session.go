@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evcc-io/evcc/core/db"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagFormat    = "format"
+	flagLoadpoint = "loadpoint"
+)
+
+// sessionCmd represents the session command
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Manage charging sessions",
+}
+
+// sessionImportCmd represents the session import command
+var sessionImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import charging sessions from wallbox vendor csv export",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSessionImport,
+}
+
+func init() {
+	rootCmd.AddCommand(sessionCmd)
+	sessionCmd.AddCommand(sessionImportCmd)
+	sessionImportCmd.Flags().StringP(flagFormat, "f", "", fmt.Sprintf("Export format (%s)", strings.Join(db.ImportFormats(), ", ")))
+	sessionImportCmd.Flags().StringP(flagLoadpoint, "l", "", "Loadpoint title to assign imported sessions to")
+}
+
+func runSessionImport(cmd *cobra.Command, args []string) {
+	// load config
+	if err := loadConfigFile(&conf); err != nil {
+		fatal(err)
+	}
+
+	// setup environment
+	if err := configureEnvironment(cmd, conf); err != nil {
+		fatal(err)
+	}
+
+	if conf.Database.Dsn == "" {
+		fatal(errors.New("database not configured"))
+	}
+
+	format := cmd.Flags().Lookup(flagFormat).Value.String()
+
+	title := cmd.Flags().Lookup(flagLoadpoint).Value.String()
+	if title == "" {
+		fatal(errors.New("missing loadpoint"))
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		fatal(err)
+	}
+	defer f.Close()
+
+	sessions, err := db.Import(format, f)
+	if err != nil {
+		fatal(err)
+	}
+
+	sessionDB, err := db.New(title)
+	if err != nil {
+		fatal(err)
+	}
+
+	count, err := sessionDB.Import(sessions)
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("imported %d of %d sessions\n", count, len(sessions))
+}
@@ -1,17 +1,22 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // embed timezone database for minimal systems
 
 	paho "github.com/eclipse/paho.mqtt.golang"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/charger/eebus"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
+	coredb "github.com/evcc-io/evcc/core/db"
+	"github.com/evcc-io/evcc/core/metrics"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/hems"
 	"github.com/evcc-io/evcc/provider/golang"
@@ -28,11 +33,12 @@ import (
 	"github.com/evcc-io/evcc/util/pipe"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/sponsor"
+	"github.com/evcc-io/evcc/util/supervisor"
 	"github.com/libp2p/zeroconf/v2"
+	"github.com/mitchellh/go-homedir"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"golang.org/x/exp/maps"
-	"golang.org/x/exp/slices"
 	"golang.org/x/text/currency"
 )
 
@@ -67,8 +73,13 @@ func configureEnvironment(cmd *cobra.Command, conf config) (err error) {
 		request.LogHeaders = true
 	}
 
+	// setup timezone
+	if conf.Timezone != "" {
+		err = configureTimezone(conf.Timezone)
+	}
+
 	// setup machine id
-	if conf.Plant != "" {
+	if err == nil && conf.Plant != "" {
 		err = machine.CustomID(conf.Plant)
 	}
 
@@ -110,8 +121,32 @@ func configureEnvironment(cmd *cobra.Command, conf config) (err error) {
 	return
 }
 
+// configureSupervisor applies Home Assistant add-on service discovery
+func configureSupervisor(conf *config) {
+	log.INFO.Println("running as home assistant add-on")
+
+	// mqtt broker from mqtt add-on if not explicitly configured
+	if conf.Mqtt.Broker == "" {
+		mqtt, err := supervisor.Mqtt()
+		if err != nil {
+			log.WARN.Printf("mqtt service discovery: %v", err)
+			return
+		}
+
+		log.INFO.Printf("using mqtt broker from service discovery: %s", mqtt.Broker())
+
+		conf.Mqtt.Broker = mqtt.Broker()
+		conf.Mqtt.User = mqtt.Username
+		conf.Mqtt.Password = mqtt.Password
+	}
+}
+
 // configureDatabase configures session database
 func configureDatabase(conf dbConfig) error {
+	if conf.Flush <= 0 {
+		return fmt.Errorf("invalid flush interval: %v", conf.Flush)
+	}
+
 	if err := db.NewInstance(conf.Type, conf.Dsn); err != nil {
 		return err
 	}
@@ -120,24 +155,71 @@ func configureDatabase(conf dbConfig) error {
 		return err
 	}
 
-	shutdown.Register(func() {
+	persist := func() {
 		if err := settings.Persist(); err != nil {
 			log.ERROR.Println("cannot save settings:", err)
 		}
-	})
+		coredb.Flush()
+	}
+
+	// batch settings and session writes
+	go func() {
+		for range time.Tick(conf.Flush) {
+			persist()
+		}
+	}()
+
+	shutdown.Register(persist)
+
+	return nil
+}
+
+// configureMetrics configures local metrics recording
+func configureMetrics(conf dbConfig, in <-chan util.Param) error {
+	recorder, err := metrics.NewRecorder(db.Instance, conf.Retention, conf.Flush)
+	if err != nil {
+		return fmt.Errorf("failed configuring metrics: %w", err)
+	}
+
+	go recorder.Run(in)
+	shutdown.Register(recorder.Persist)
+
+	return nil
+}
+
+// configurePrometheus publishes site values as prometheus metrics
+func configurePrometheus(site site.API, in <-chan util.Param) error {
+	prom, err := server.NewPrometheus(prometheus.DefaultRegisterer)
+	if err != nil {
+		return err
+	}
+
+	go prom.Run(site, in)
 
 	return nil
 }
 
 // configureInflux configures influx database
-func configureInflux(conf server.InfluxConfig, site site.API, in <-chan util.Param) {
+func configureInflux(conf server.InfluxConfig, site site.API, in <-chan util.Param) error {
+	if conf.Schema != 0 && conf.Schema != server.SchemaVersion {
+		return fmt.Errorf("influx: unsupported schema version: %d", conf.Schema)
+	}
+
+	database := conf.Database
+	if conf.Bucket != "" {
+		database = conf.Bucket
+	}
+
 	influx := server.NewInfluxClient(
 		conf.URL,
 		conf.Token,
 		conf.Org,
 		conf.User,
 		conf.Password,
-		conf.Database,
+		database,
+		conf.Tags,
+		conf.SiteTag,
+		conf.Schema,
 	)
 
 	// eliminate duplicate values
@@ -145,6 +227,33 @@ func configureInflux(conf server.InfluxConfig, site site.API, in <-chan util.Par
 	in = dedupe.Pipe(in)
 
 	go influx.Run(site, in)
+
+	return nil
+}
+
+// configureWLED configures WLED led output
+func configureWLED(conf server.WLEDConfig, in <-chan util.Param) error {
+	wled, err := server.NewWLED(conf)
+	if err != nil {
+		return fmt.Errorf("failed configuring wled: %w", err)
+	}
+
+	go wled.Run(in)
+
+	return nil
+}
+
+// setup timezone
+func configureTimezone(tz string) error {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return fmt.Errorf("failed configuring timezone: %w", err)
+	}
+
+	time.Local = loc
+	log.INFO.Println("using timezone", loc)
+
+	return nil
 }
 
 // setup mqtt
@@ -194,11 +303,44 @@ func configureHEMS(conf typedConfig, site *core.Site, httpd *server.HTTPd) error
 	return nil
 }
 
+// setup TLS, returns certificate and private key files
+func configureTLS(conf *networkConfig) (string, string, error) {
+	cc := conf.TLS
+
+	if (cc.Cert == "") != (cc.Key == "") {
+		return "", "", errors.New("tls: cert and key must both be configured")
+	}
+
+	// self-signed
+	if cc.Cert == "" {
+		dir, err := homedir.Expand("~/.evcc/tls")
+		if err != nil {
+			return "", "", err
+		}
+
+		cc.Cert = filepath.Join(dir, "cert.pem")
+		cc.Key = filepath.Join(dir, "key.pem")
+
+		if err := server.SelfSignedCertificate(cc.Cert, cc.Key, []string{conf.Host, "localhost", "127.0.0.1"}); err != nil {
+			return "", "", fmt.Errorf("tls: %w", err)
+		}
+	}
+
+	// validate
+	if _, err := tls.LoadX509KeyPair(cc.Cert, cc.Key); err != nil {
+		return "", "", fmt.Errorf("tls: %w", err)
+	}
+
+	conf.Schema = "https"
+
+	return cc.Cert, cc.Key, nil
+}
+
 // setup MDNS
 func configureMDNS(conf networkConfig) error {
 	host := strings.TrimSuffix(conf.Host, ".local")
 
-	zc, err := zeroconf.RegisterProxy("EV Charge Controller", "_http._tcp", "local.", conf.Port, host, nil, []string{}, nil)
+	zc, err := zeroconf.RegisterProxy("EV Charge Controller", "_"+conf.Schema+"._tcp", "local.", conf.Port, host, nil, []string{}, nil)
 	if err != nil {
 		return fmt.Errorf("mDNS announcement: %w", err)
 	}
@@ -225,7 +367,7 @@ func configureEEBus(conf map[string]interface{}) error {
 func configureMessengers(conf messagingConfig, valueChan chan util.Param, cache *util.Cache) (chan push.Event, error) {
 	messageChan := make(chan push.Event, 1)
 
-	messageHub, err := push.NewHub(conf.Events, cache)
+	messageHub, err := push.NewHub(conf.Events, conf.Language, cache)
 	if err != nil {
 		return messageChan, fmt.Errorf("failed configuring push services: %w", err)
 	}
@@ -235,7 +377,16 @@ func configureMessengers(conf messagingConfig, valueChan chan util.Param, cache
 		if err != nil {
 			return messageChan, fmt.Errorf("failed configuring push service %s: %w", service.Type, err)
 		}
-		messageHub.Add(impl)
+
+		name := service.Name
+		if name == "" {
+			name = service.Type
+		}
+		messageHub.Add(name, impl)
+	}
+
+	if err := messageHub.Validate(); err != nil {
+		return messageChan, fmt.Errorf("failed configuring push services: %w", err)
 	}
 
 	go messageHub.Run(messageChan, valueChan)
@@ -306,19 +457,10 @@ func configureSiteAndLoadpoints(conf config) (*core.Site, error) {
 		return nil, err
 	}
 
-	// list of vehicles ordered by name
-	keys := maps.Keys(cp.vehicles)
-	slices.Sort(keys)
-
-	vehicles := make([]api.Vehicle, 0, len(cp.vehicles))
-	for _, k := range keys {
-		vehicles = append(vehicles, cp.vehicles[k])
-	}
-
-	return configureSite(conf.Site, cp, loadpoints, vehicles, tariffs)
+	return configureSite(conf.Site, cp, loadpoints, cp.vehicles, tariffs)
 }
 
-func configureSite(conf map[string]interface{}, cp *ConfigProvider, loadpoints []*core.Loadpoint, vehicles []api.Vehicle, tariffs tariff.Tariffs) (*core.Site, error) {
+func configureSite(conf map[string]interface{}, cp *ConfigProvider, loadpoints []*core.Loadpoint, vehicles map[string]api.Vehicle, tariffs tariff.Tariffs) (*core.Site, error) {
 	site, err := core.NewSiteFromConfig(log, cp, conf, loadpoints, vehicles, tariffs)
 	if err != nil {
 		return nil, fmt.Errorf("failed configuring site: %w", err)
@@ -35,3 +35,9 @@ func TestYamlOff(t *testing.T) {
 		t.Errorf("expected `off`, got %s", lp.Mode)
 	}
 }
+
+func TestDatabaseFlush(t *testing.T) {
+	if err := configureDatabase(dbConfig{Type: "sqlite", Dsn: ":memory:"}); err == nil {
+		t.Error("expected invalid flush interval error")
+	}
+}
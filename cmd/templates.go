@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/spf13/cobra"
+)
+
+// templatesCmd represents the templates command
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Device templates",
+}
+
+// templatesExportCmd represents the templates export command
+var templatesExportCmd = &cobra.Command{
+	Use:   "export [class]",
+	Short: "Export device templates as json",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runTemplatesExport,
+}
+
+const (
+	flagLanguage = "lang"
+	flagOutput   = "output"
+)
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesExportCmd)
+
+	templatesExportCmd.Flags().StringP(flagLanguage, "", "en", "Language of descriptions (de, en)")
+	templatesExportCmd.Flags().StringP(flagOutput, "o", "", "Output file (default stdout)")
+}
+
+// templateExport adds the requirements which are omitted from the ui api
+type templateExport struct {
+	templates.TemplateDefinition
+	Requirements templates.Requirements
+}
+
+func runTemplatesExport(cmd *cobra.Command, args []string) {
+	classes := templates.ClassValues()
+
+	if len(args) > 0 {
+		class, err := templates.ClassString(args[0])
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+		classes = []templates.Class{class}
+	}
+
+	lang, _ := cmd.Flags().GetString(flagLanguage)
+	templates.EncoderLanguage(strings.ToLower(lang))
+
+	res := make(map[string][]templateExport)
+	for _, class := range classes {
+		key := strings.ToLower(class.String())
+		for _, tmpl := range templates.ByClass(class) {
+			res[key] = append(res[key], templateExport{
+				TemplateDefinition: tmpl.TemplateDefinition,
+				Requirements:       tmpl.Requirements,
+			})
+		}
+	}
+
+	out := os.Stdout
+	if file, _ := cmd.Flags().GetString(flagOutput); file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(res); err != nil {
+		log.FATAL.Fatal(err)
+	}
+}
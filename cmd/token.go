@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util/oauth"
+	"github.com/evcc-io/evcc/vehicle"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/slices"
@@ -50,7 +54,11 @@ func runToken(cmd *cobra.Command, args []string) {
 	var token *oauth2.Token
 	var err error
 
-	switch strings.ToLower(vehicleConf.Type) {
+	typ := strings.ToLower(vehicleConf.Type)
+
+	switch typ {
+	case "mercedes":
+		token, err = mercedesToken(vehicleConf)
 	case "tesla":
 		token, err = teslaToken()
 	case "tronity":
@@ -63,6 +71,21 @@ func runToken(cmd *cobra.Command, args []string) {
 		log.FATAL.Fatal(err)
 	}
 
+	// vehicles restoring tokens from the database
+	if typ == "mercedes" || typ == "tesla" {
+		vin, _ := vehicleConf.Other["vin"].(string)
+		if err := saveToken(conf.Database, vehicle.TokenStore(typ, vin), token); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		fmt.Println()
+		fmt.Println("Tokens have been saved to the database")
+
+		if typ == "mercedes" {
+			return
+		}
+	}
+
 	fmt.Println()
 	fmt.Println("Add the following tokens to the vehicle config:")
 	fmt.Println()
@@ -70,3 +93,20 @@ func runToken(cmd *cobra.Command, args []string) {
 	fmt.Println("    access:", token.AccessToken)
 	fmt.Println("    refresh:", token.RefreshToken)
 }
+
+// saveToken persists the token in the database
+func saveToken(conf dbConfig, store *oauth.TokenStore, token *oauth2.Token) error {
+	if err := db.NewInstance(conf.Type, conf.Dsn); err != nil {
+		return err
+	}
+
+	if err := settings.Init(); err != nil {
+		return err
+	}
+
+	if err := store.Save(token); err != nil {
+		return err
+	}
+
+	return settings.Persist()
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/vehicle/mercedes"
+	"github.com/skratchdot/open-golang/open"
+	"golang.org/x/oauth2"
+)
+
+// mercedesToken performs the device code login, see https://www.rfc-editor.org/rfc/rfc8628
+func mercedesToken(vehicleConf qualifiedConfig) (*oauth2.Token, error) {
+	var cc struct {
+		ClientID, ClientSecret string
+		Other                  map[string]interface{} `mapstructure:",remain"`
+	}
+
+	if err := util.DecodeOther(vehicleConf.Other, &cc); err != nil {
+		return nil, err
+	}
+
+	identity, err := mercedes.NewIdentity(log, cc.ClientID, cc.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	flow, err := identity.DeviceCodeFlow()
+	if err != nil {
+		return nil, err
+	}
+
+	da, err := flow.DeviceAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	uri := da.VerificationURIComplete
+	if uri == "" {
+		uri = da.VerificationURI
+	}
+
+	fmt.Println("Open the following page and enter code", da.UserCode, "to login:")
+	fmt.Println()
+	fmt.Println("  " + uri)
+	fmt.Println()
+
+	_ = open.Start(uri)
+
+	return flow.Token(context.Background(), da)
+}
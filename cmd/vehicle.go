@@ -21,6 +21,7 @@ func init() {
 	vehicleCmd.Flags().BoolP(flagStart, "a", false, flagStartDescription)
 	vehicleCmd.Flags().BoolP(flagStop, "o", false, flagStopDescription)
 	vehicleCmd.Flags().BoolP(flagWakeup, "w", false, flagWakeupDescription)
+	vehicleCmd.Flags().Int(flagSocLimit, 0, flagSocLimitDescription)
 	//lint:ignore SA1019 as Title is safe on ascii
 	vehicleCmd.Flags().Bool(flagDiagnose, false, strings.Title(flagDiagnose))
 }
@@ -81,6 +82,19 @@ func runVehicle(cmd *cobra.Command, args []string) {
 			}
 		}
 
+		if cmd.Flags().Lookup(flagSocLimit).Changed {
+			flagUsed = true
+
+			if vv, ok := v.(api.SocLimitController); ok {
+				soc, _ := cmd.Flags().GetInt(flagSocLimit)
+				if err := vv.SetSocLimit(soc); err != nil {
+					log.ERROR.Println("set soc limit:", err)
+				}
+			} else {
+				log.ERROR.Println("set soc limit: not implemented")
+			}
+		}
+
 		if cmd.Flags().Lookup(flagStop).Changed {
 			flagUsed = true
 
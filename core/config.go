@@ -7,4 +7,5 @@ type configProvider interface {
 	Meter(string) (api.Meter, error)
 	Charger(string) (api.Charger, error)
 	Vehicle(string) (api.Vehicle, error)
+	DeviceID(class, name string) string // persistent id of a configured device, empty if unknown
 }
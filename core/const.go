@@ -9,7 +9,9 @@ const (
 
 	chargerIcon = "chargerIcon" // charger icon for ui
 
+	vehicleCableLocked     = "vehicleCableLocked"     // vehicle charge cable locked
 	vehicleCapacity        = "vehicleCapacity"        // vehicle battery capacity
+	vehicleChargePortOpen  = "vehicleChargePortOpen"  // vehicle charge port open
 	vehicleDetectionActive = "vehicleDetectionActive" // vehicle detection active
 	vehicleIcon            = "vehicleIcon"            // vehicle icon for ui
 	vehicleOdometer        = "vehicleOdometer"        // vehicle odometer
@@ -31,7 +31,19 @@ func (a *adapter) Release(v api.Vehicle) {
 	a.c.release(v)
 }
 
+func (a *adapter) IdentifyVehicle(id string) api.Vehicle {
+	return a.c.identifyVehicle(id)
+}
+
 func (a *adapter) IdentifyVehicleByStatus() api.Vehicle {
 	available := a.c.availableDetectibleVehicles(a.lp)
 	return a.c.identifyVehicleByStatus(available)
 }
+
+func (a *adapter) ReportHealth(v api.Vehicle, err error) bool {
+	return a.c.reportHealth(v, err)
+}
+
+func (a *adapter) DeviceID(v api.Vehicle) string {
+	return a.c.deviceIDs[v]
+}
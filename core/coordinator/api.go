@@ -7,5 +7,8 @@ type API interface {
 	GetVehicles() []api.Vehicle
 	Acquire(api.Vehicle)
 	Release(api.Vehicle)
+	IdentifyVehicle(id string) api.Vehicle
 	IdentifyVehicleByStatus() api.Vehicle
+	ReportHealth(api.Vehicle, error) bool
+	DeviceID(api.Vehicle) string
 }
@@ -1,6 +1,10 @@
 package coordinator
 
 import (
+	"regexp"
+	"strings"
+	"sync"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
@@ -8,9 +12,14 @@ import (
 
 // Coordinator coordinates vehicle access between loadpoints
 type Coordinator struct {
-	log      *util.Logger
-	vehicles []api.Vehicle
-	tracked  map[api.Vehicle]loadpoint.API
+	log         *util.Logger
+	vehicles    []api.Vehicle
+	tracked     map[api.Vehicle]loadpoint.API
+	identifiers map[string]api.Vehicle
+	deviceIDs   map[api.Vehicle]string
+
+	mu     sync.Mutex
+	health map[api.Vehicle]*Health
 }
 
 // New creates a coordinator for a set of vehicles
@@ -22,10 +31,65 @@ func New(log *util.Logger, vehicles []api.Vehicle) *Coordinator {
 	}
 }
 
+// AddIdentifier registers a charger-reported identifier (e.g. RFID UID, EVCC-ID or MAC) for the given vehicle
+func (c *Coordinator) AddIdentifier(id string, vehicle api.Vehicle) {
+	if c.identifiers == nil {
+		c.identifiers = make(map[string]api.Vehicle)
+	}
+	c.identifiers[strings.ToLower(id)] = vehicle
+}
+
+// SetDeviceID registers the persistent id of the vehicle
+func (c *Coordinator) SetDeviceID(vehicle api.Vehicle, id string) {
+	if c.deviceIDs == nil {
+		c.deviceIDs = make(map[api.Vehicle]string)
+	}
+	c.deviceIDs[vehicle] = id
+}
+
 func (c *Coordinator) GetVehicles() []api.Vehicle {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.vehicles
 }
 
+// Replace swaps the vehicle for its replacement, e.g. a vehicle recovered after failed startup
+func (c *Coordinator) Replace(from, to api.Vehicle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// copy on write as the vehicle list may be in use
+	vehicles := make([]api.Vehicle, 0, len(c.vehicles))
+	for _, v := range c.vehicles {
+		if v == from {
+			v = to
+		}
+		vehicles = append(vehicles, v)
+	}
+	c.vehicles = vehicles
+
+	for id, v := range c.identifiers {
+		if v == from {
+			c.identifiers[id] = to
+		}
+	}
+
+	if o, ok := c.tracked[from]; ok {
+		delete(c.tracked, from)
+		c.tracked[to] = o
+	}
+
+	if id, ok := c.deviceIDs[from]; ok {
+		delete(c.deviceIDs, from)
+		c.deviceIDs[to] = id
+	}
+
+	if h, ok := c.health[from]; ok {
+		delete(c.health, from)
+		c.health[to] = h
+	}
+}
+
 func (c *Coordinator) acquire(owner loadpoint.API, vehicle api.Vehicle) {
 	if o, ok := c.tracked[vehicle]; ok && o != owner {
 		o.SetVehicle(nil)
@@ -37,6 +101,41 @@ func (c *Coordinator) release(vehicle api.Vehicle) {
 	delete(c.tracked, vehicle)
 }
 
+// identifyVehicle finds the vehicle matching the charger-reported identifier
+func (c *Coordinator) identifyVehicle(id string) api.Vehicle {
+	// site-level registry
+	if vehicle, ok := c.identifiers[strings.ToLower(id)]; ok {
+		return vehicle
+	}
+
+	// find exact match
+	for _, vehicle := range c.vehicles {
+		for _, vid := range vehicle.Identifiers() {
+			if strings.EqualFold(id, vid) {
+				return vehicle
+			}
+		}
+	}
+
+	// find placeholder match
+	for _, vehicle := range c.vehicles {
+		for _, vid := range vehicle.Identifiers() {
+			// case insensitive match
+			re, err := regexp.Compile("(?i)" + strings.ReplaceAll(vid, "*", ".*?"))
+			if err != nil {
+				c.log.ERROR.Printf("vehicle id: %v", err)
+				continue
+			}
+
+			if re.MatchString(id) {
+				return vehicle
+			}
+		}
+	}
+
+	return nil
+}
+
 // availableDetectibleVehicles is the list of vehicles that are currently not
 // associated to another loadpoint and have a status api that allows for detection
 func (c *Coordinator) availableDetectibleVehicles(owner loadpoint.API) []api.Vehicle {
@@ -65,3 +65,30 @@ func TestVehicleDetectByStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestVehicleIdentify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v1 := mock.NewMockVehicle(ctrl)
+	v2 := mock.NewMockVehicle(ctrl)
+
+	v1.EXPECT().Identifiers().Return([]string{"1234"}).AnyTimes()
+	v2.EXPECT().Identifiers().Return([]string{"abc*"}).AnyTimes()
+
+	c := New(util.NewLogger("foo"), []api.Vehicle{v1, v2})
+	c.AddIdentifier("AA:BB:CC", v2)
+
+	for _, tc := range []struct {
+		id  string
+		res api.Vehicle
+	}{
+		{"1234", v1},
+		{"aa:bb:cc", v2},
+		{"ABCdef", v2},
+		{"5678", nil},
+	} {
+		if res := c.identifyVehicle(tc.id); res != tc.res {
+			t.Errorf("%s: expected %v, got %v", tc.id, tc.res, res)
+		}
+	}
+}
@@ -19,6 +19,18 @@ func (a *dummy) Acquire(v api.Vehicle) {}
 
 func (a *dummy) Release(v api.Vehicle) {}
 
+func (a *dummy) IdentifyVehicle(id string) api.Vehicle {
+	return nil
+}
+
 func (a *dummy) IdentifyVehicleByStatus() api.Vehicle {
 	return nil
 }
+
+func (a *dummy) ReportHealth(v api.Vehicle, err error) bool {
+	return false
+}
+
+func (a *dummy) DeviceID(v api.Vehicle) string {
+	return ""
+}
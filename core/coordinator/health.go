@@ -0,0 +1,92 @@
+package coordinator
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// Health is the vehicle api health status
+type Health struct {
+	Success     int       `json:"success"`         // successful api requests
+	Failure     int       `json:"failure"`         // failed api requests
+	SuccessRate float64   `json:"successRate"`     // share of successful api requests in percent
+	Updated     time.Time `json:"updated"`         // last successful api request
+	Error       string    `json:"error,omitempty"` // last api error
+	Reauth      bool      `json:"reauth"`          // authentication rejected, user login required
+	Limited     bool      `json:"limited"`         // rate-limited or locked out by the api
+}
+
+// update records the api request result and returns true if re-authentication has become required
+func (h *Health) update(err error, now time.Time) bool {
+	// not an api failure
+	if errors.Is(err, api.ErrAsleep) || errors.Is(err, api.ErrNotAvailable) {
+		return false
+	}
+
+	defer func() {
+		h.SuccessRate = 100 * float64(h.Success) / float64(h.Success+h.Failure)
+	}()
+
+	if err == nil {
+		h.Success++
+		h.Updated = now
+		h.Error = ""
+		h.Reauth = false
+		h.Limited = false
+		return false
+	}
+
+	h.Failure++
+	h.Error = err.Error()
+
+	var se request.StatusError
+	hasStatus := func(codes ...int) bool {
+		return errors.As(err, &se) && se.HasStatus(codes...)
+	}
+
+	h.Limited = errors.Is(err, api.ErrMustRetry) || hasStatus(http.StatusTooManyRequests)
+
+	reauth := h.Reauth
+	h.Reauth = errors.Is(err, api.ErrMissingCredentials) || hasStatus(http.StatusUnauthorized, http.StatusForbidden)
+
+	return h.Reauth && !reauth
+}
+
+// reportHealth records the api request result for the vehicle and returns true if re-authentication has become required
+func (c *Coordinator) reportHealth(vehicle api.Vehicle, err error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.health == nil {
+		c.health = make(map[api.Vehicle]*Health)
+	}
+
+	h, ok := c.health[vehicle]
+	if !ok {
+		h = new(Health)
+		c.health[vehicle] = h
+	}
+
+	return h.update(err, time.Now())
+}
+
+// Health returns the api health status of all vehicles by vehicle title
+func (c *Coordinator) Health() map[string]Health {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make(map[string]Health, len(c.vehicles))
+	for _, v := range c.vehicles {
+		var h Health
+		if vh, ok := c.health[v]; ok {
+			h = *vh
+		}
+		res[v.Title()] = h
+	}
+
+	return res
+}
@@ -0,0 +1,43 @@
+package coordinator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealth(t *testing.T) {
+	var h Health
+	now := time.Now()
+
+	assert.False(t, h.update(nil, now))
+	assert.Equal(t, now, h.Updated)
+
+	assert.False(t, h.update(api.ErrAsleep, now), "asleep is not a failure")
+	assert.Equal(t, 0, h.Failure)
+
+	assert.False(t, h.update(api.ErrMustRetry, now))
+	assert.True(t, h.Limited)
+	assert.Equal(t, 50.0, h.SuccessRate)
+
+	unauthorized := request.NewStatusError(&http.Response{StatusCode: http.StatusUnauthorized})
+	assert.True(t, h.update(fmt.Errorf("login: %w", unauthorized), now), "reauth required")
+	assert.True(t, h.Reauth)
+	assert.False(t, h.Limited)
+	assert.False(t, h.update(unauthorized, now), "reauth already notified")
+
+	assert.False(t, h.update(errors.New("foo"), now))
+	assert.False(t, h.Reauth)
+	assert.Equal(t, "foo", h.Error)
+
+	assert.False(t, h.update(nil, now))
+	assert.Empty(t, h.Error)
+	assert.Equal(t, 2, h.Success)
+	assert.Equal(t, 4, h.Failure)
+}
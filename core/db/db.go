@@ -1,8 +1,12 @@
 package db
 
 import (
+	"sync"
+	"time"
+
 	serverdb "github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util"
+	"golang.org/x/exp/slices"
 	"gorm.io/gorm"
 )
 
@@ -11,11 +15,22 @@ type DB struct {
 	log  *util.Logger
 	db   *gorm.DB
 	name string
+	id   string
+
+	mu      sync.Mutex
+	pending []interface{} // sessions queued for writing
 }
 
+var (
+	mu        sync.Mutex
+	instances []*DB
+)
+
 type Database interface {
 	Session(startEnergy float64) *Session
 	Persist(session interface{})
+	Queue(session interface{})
+	ChargedEnergy(from time.Time, exclude uint) (float64, error)
 }
 
 // New creates a database storage driver
@@ -37,13 +52,18 @@ func New(name string) (*DB, error) {
 		name: name,
 	}
 
+	mu.Lock()
+	instances = append(instances, sessiondb)
+	mu.Unlock()
+
 	return sessiondb, err
 }
 
 // Session creates a charging session
 func (s *DB) Session(meter float64) *Session {
 	t := Session{
-		Loadpoint: s.name,
+		Loadpoint:   s.name,
+		LoadpointID: s.id,
 	}
 
 	if meter > 0 {
@@ -55,14 +75,100 @@ func (s *DB) Session(meter float64) *Session {
 
 // Persist creates or updates a transaction in the database
 func (s *DB) Persist(session interface{}) {
+	s.mu.Lock()
+	if idx := slices.Index(s.pending, session); idx >= 0 {
+		s.pending = slices.Delete(s.pending, idx, idx+1)
+	}
+	s.mu.Unlock()
+
 	if err := s.db.Save(session).Error; err != nil {
 		s.log.ERROR.Printf("persist: %v", err)
 	}
 }
 
+// Queue defers creating or updating a transaction in the database until the next Flush.
+// This reduces storage wear for frequent session updates.
+func (s *DB) Queue(session interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !slices.Contains(s.pending, session) {
+		s.pending = append(s.pending, session)
+	}
+}
+
+// flush writes the queued transactions
+func (s *DB) flush() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	for _, session := range pending {
+		if err := s.db.Save(session).Error; err != nil {
+			s.log.ERROR.Printf("persist: %v", err)
+		}
+	}
+}
+
+// Flush writes the queued transactions of all session databases
+func Flush() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, s := range instances {
+		s.flush()
+	}
+}
+
+// SetID assigns the persistent loadpoint id to new sessions and to sessions recorded by loadpoint title.
+// Sessions recorded under a previous title of the loadpoint are renamed.
+func (s *DB) SetID(id string) error {
+	s.id = id
+	return adopt(s.db, "loadpoint", id, s.name)
+}
+
+// AdoptVehicle assigns the persistent vehicle id to sessions recorded by vehicle title.
+// Sessions recorded under a previous title of the vehicle are renamed.
+func AdoptVehicle(id, title string) error {
+	return adopt(serverdb.Instance, "vehicle", id, title)
+}
+
+// adopt links the sessions of a device title to the device id and updates the title of sessions of the device id
+func adopt(db *gorm.DB, column, id, title string) error {
+	err := db.Model(new(Session)).
+		Where(column+" = ? AND ("+column+"_id = '' OR "+column+"_id IS NULL)", title).
+		Update(column+"_id", id).Error
+
+	if err == nil {
+		err = db.Model(new(Session)).
+			Where(column+"_id = ? AND "+column+" <> ?", id, title).
+			Update(column, title).Error
+	}
+
+	return err
+}
+
+// ChargedEnergy returns the energy charged by the loadpoint's sessions created since the given time, excluding the given session
+func (s *DB) ChargedEnergy(from time.Time, exclude uint) (float64, error) {
+	column, val := "loadpoint", s.name
+	if s.id != "" {
+		column, val = "loadpoint_id", s.id
+	}
+
+	var res float64
+	tx := s.db.Model(new(Session)).
+		Where(column+" = ? AND created >= ? AND id <> ?", val, from, exclude).
+		Select("COALESCE(SUM(charged_kwh), 0)").
+		Scan(&res)
+	return res, tx.Error
+}
+
 // Return sessions
 // TODO make this part of server/db
 func (s *DB) Sessions() (Sessions, error) {
+	s.flush()
+
 	var res Sessions
 	tx := s.db.Find(&res)
 	return res, tx.Error
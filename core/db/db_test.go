@@ -0,0 +1,83 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceIDs(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	// sessions recorded before ids were introduced
+	old, err := New("garage")
+	require.NoError(t, err)
+
+	old.Persist(&Session{Loadpoint: "garage", Vehicle: "Red", Created: time.Now(), ChargedEnergy: 5})
+	old.Persist(&Session{Loadpoint: "carport", Vehicle: "Blue", Created: time.Now(), ChargedEnergy: 7})
+
+	// loadpoint and vehicle renamed
+	s, err := New("home")
+	require.NoError(t, err)
+	require.NoError(t, old.SetID("lp1"))
+	require.NoError(t, AdoptVehicle("v1", "Red"))
+	require.NoError(t, s.SetID("lp1"))
+	require.NoError(t, AdoptVehicle("v1", "Ruby"))
+
+	assert.Equal(t, "lp1", s.Session(0).LoadpointID)
+
+	res, err := s.Sessions()
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, "home", res[0].Loadpoint)
+	assert.Equal(t, "lp1", res[0].LoadpointID)
+	assert.Equal(t, "Ruby", res[0].Vehicle)
+	assert.Equal(t, "v1", res[0].VehicleID)
+
+	// other devices unchanged
+	assert.Equal(t, "carport", res[1].Loadpoint)
+	assert.Equal(t, "", res[1].LoadpointID)
+	assert.Equal(t, "Blue", res[1].Vehicle)
+
+	energy, err := s.ChargedEnergy(time.Now().Add(-time.Hour), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, energy)
+}
+
+func TestQueue(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	s, err := New("garage")
+	require.NoError(t, err)
+
+	session := &Session{Loadpoint: "garage", Created: time.Now(), ChargedEnergy: 5}
+	s.Queue(session)
+	s.Queue(session)
+
+	var count int64
+	require.NoError(t, serverdb.Instance.Model(new(Session)).Count(&count).Error)
+	assert.Equal(t, int64(0), count, "queued")
+
+	Flush()
+	require.NoError(t, serverdb.Instance.Model(new(Session)).Count(&count).Error)
+	assert.Equal(t, int64(1), count, "flushed")
+
+	// persist writes immediately and removes the session from the queue
+	session.ChargedEnergy = 7
+	s.Queue(session)
+	s.Persist(session)
+	assert.Empty(t, s.pending)
+
+	res, err := s.Sessions()
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 7.0, res[0].ChargedEnergy)
+}
@@ -0,0 +1,203 @@
+package db
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// importColumns maps session fields to the (lower case) column captions of a vendor export
+type importColumns struct {
+	Created, Finished, Energy, Identifier, MeterStart, MeterStop []string
+}
+
+// importFormats are the supported vendor export formats
+var importFormats = map[string]importColumns{
+	"easee": {
+		Created:    []string{"start", "started", "start time", "startzeit"},
+		Finished:   []string{"end", "stopped", "end time", "endzeit"},
+		Energy:     []string{"kwh", "energy (kwh)", "energy", "energie (kwh)"},
+		Identifier: []string{"auth token", "authtoken", "rfid", "user"},
+	},
+	"go-e": {
+		Created:    []string{"start", "session start", "startzeit", "start time"},
+		Finished:   []string{"end", "session end", "endzeit", "end time"},
+		Energy:     []string{"energy (kwh)", "energy [kwh]", "kwh", "energie (kwh)", "energie [kwh]"},
+		Identifier: []string{"rfid", "rfid card", "card", "karte"},
+		MeterStart: []string{"meter start (kwh)", "meter start", "zählerstand start"},
+		MeterStop:  []string{"meter end (kwh)", "meter end", "zählerstand ende"},
+	},
+	"keba": {
+		Created:    []string{"started", "start time", "start", "startzeit"},
+		Finished:   []string{"ended", "end time", "end", "endzeit"},
+		Energy:     []string{"energy [kwh]", "e pres [kwh]", "energy (kwh)", "energie [kwh]"},
+		Identifier: []string{"rfid tag", "rfid", "rfid-karte"},
+		MeterStart: []string{"e start [kwh]", "meter start [kwh]"},
+		MeterStop:  []string{"e end [kwh]", "meter end [kwh]"},
+	},
+}
+
+// importTimeFormats are the accepted date/time formats of vendor exports
+var importTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+}
+
+// ImportFormats returns the supported import formats
+func ImportFormats() []string {
+	res := maps.Keys(importFormats)
+	slices.Sort(res)
+	return res
+}
+
+// Import parses sessions from a vendor csv export
+func Import(format string, r io.Reader) (Sessions, error) {
+	cols, ok := importFormats[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("invalid format: %s", format)
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// strip BOM
+	s := strings.TrimPrefix(string(b), "\ufeff")
+
+	cr := csv.NewReader(strings.NewReader(s))
+	cr.FieldsPerRecord = -1
+
+	// european exports use semicolon separator and decimal comma
+	header, _, _ := strings.Cut(s, "\n")
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		cr.Comma = ';'
+	}
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("empty file")
+	}
+
+	index := make(map[string]int)
+	for i, caption := range records[0] {
+		index[strings.ToLower(strings.TrimSpace(caption))] = i
+	}
+
+	column := func(captions []string) int {
+		for _, caption := range captions {
+			if i, ok := index[caption]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	created, finished, energy := column(cols.Created), column(cols.Finished), column(cols.Energy)
+	if created < 0 || energy < 0 {
+		return nil, errors.New("missing start time or energy column")
+	}
+
+	identifier, meterStart, meterStop := column(cols.Identifier), column(cols.MeterStart), column(cols.MeterStop)
+
+	value := func(rec []string, i int) string {
+		if i < 0 || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	float := func(s string) (float64, error) {
+		if cr.Comma == ';' && strings.Contains(s, ",") {
+			s = strings.ReplaceAll(strings.ReplaceAll(s, ".", ""), ",", ".")
+		}
+		return strconv.ParseFloat(s, 64)
+	}
+
+	var res Sessions
+	for line, rec := range records[1:] {
+		var session Session
+
+		if session.Created, err = importTime(value(rec, created)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line+2, err)
+		}
+
+		if s := value(rec, finished); s != "" {
+			if session.Finished, err = importTime(s); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line+2, err)
+			}
+		}
+
+		if session.ChargedEnergy, err = float(value(rec, energy)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line+2, err)
+		}
+
+		session.Identifier = value(rec, identifier)
+
+		if f, err := float(value(rec, meterStart)); err == nil {
+			session.MeterStart = &f
+		}
+		if f, err := float(value(rec, meterStop)); err == nil {
+			session.MeterStop = &f
+		}
+
+		res = append(res, session)
+	}
+
+	return res, nil
+}
+
+func importTime(s string) (time.Time, error) {
+	for _, layout := range importTimeFormats {
+		if ts, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return ts, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time: %s", s)
+}
+
+// Import stores sessions for this loadpoint, skipping sessions that already exist.
+// It returns the number of imported sessions.
+func (s *DB) Import(sessions Sessions) (int, error) {
+	var count int
+
+	for _, session := range sessions {
+		session.ID = 0
+		session.Loadpoint = s.name
+
+		var existing int64
+		if err := s.db.Model(new(Session)).Where("loadpoint = ? AND created = ?", session.Loadpoint, session.Created).Count(&existing).Error; err != nil {
+			return count, err
+		}
+
+		if existing > 0 {
+			continue
+		}
+
+		if err := s.db.Create(&session).Error; err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	for _, tc := range []struct {
+		format, csv string
+		energy      float64
+		meterStop   *float64
+	}{
+		{"easee", "Start,End,kWh,Auth Token\n2023-03-01 18:00:00,2023-03-01 21:30:00,12.5,abc\n", 12.5, nil},
+		{"go-e", "Startzeit;Endzeit;Energie (kWh);Karte;Zählerstand Ende\n01.03.2023 18:00;01.03.2023 21:30;1.012,5;abc;2.000,25\n", 1012.5, func(f float64) *float64 { return &f }(2000.25)},
+		{"keba", "Started;Ended;Energy [kWh];RFID Tag\n2023-03-01 18:00:00;2023-03-01 21:30:00;12.5;abc\n", 12.5, nil},
+	} {
+		t.Logf("%+v", tc)
+
+		res, err := Import(tc.format, strings.NewReader(tc.csv))
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+
+		s := res[0]
+		assert.Equal(t, time.Date(2023, 3, 1, 18, 0, 0, 0, time.Local), s.Created)
+		assert.Equal(t, time.Date(2023, 3, 1, 21, 30, 0, 0, time.Local), s.Finished)
+		assert.Equal(t, tc.energy, s.ChargedEnergy)
+		assert.Equal(t, "abc", s.Identifier)
+		assert.Equal(t, tc.meterStop, s.MeterStop)
+	}
+
+	_, err := Import("foo", strings.NewReader(""))
+	assert.Error(t, err)
+
+	_, err = Import("easee", strings.NewReader("Foo,Bar\n"))
+	assert.Error(t, err)
+}
+
+func TestImportDuplicates(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	db, err := New("foo")
+	require.NoError(t, err)
+
+	sessions := Sessions{{Created: time.Unix(0, 0), ChargedEnergy: 10}}
+
+	count, err := db.Import(sessions)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = db.Import(sessions)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	res, err := db.Sessions()
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "foo", res[0].Loadpoint)
+}
@@ -20,20 +20,30 @@ import (
 
 // Session is a single charging session
 type Session struct {
-	ID              uint      `json:"id" csv:"-" gorm:"primarykey"`
-	Created         time.Time `json:"created"`
-	Finished        time.Time `json:"finished"`
-	Loadpoint       string    `json:"loadpoint"`
-	Identifier      string    `json:"identifier"`
-	Vehicle         string    `json:"vehicle"`
-	Odometer        *float64  `json:"odometer" format:"int"`
-	MeterStart      *float64  `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
-	MeterStop       *float64  `json:"meterStop" csv:"Meter Stop (kWh)" gorm:"column:meter_end_kwh"`
-	ChargedEnergy   float64   `json:"chargedEnergy" csv:"Charged Energy (kWh)" gorm:"column:charged_kwh"`
-	SolarPercentage *float64  `json:"solarPercentage" csv:"Solar (%)" gorm:"column:solar_percentage"`
-	Price           *float64  `json:"price" csv:"Price" gorm:"column:price"`
-	PricePerKWh     *float64  `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
-	Co2PerKWh       *float64  `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	ID                uint           `json:"id" csv:"-" gorm:"primarykey"`
+	Created           time.Time      `json:"created"`
+	Finished          time.Time      `json:"finished"`
+	Loadpoint         string         `json:"loadpoint"`
+	LoadpointID       string         `json:"loadpointId" csv:"-"`
+	Identifier        string         `json:"identifier"`
+	Vehicle           string         `json:"vehicle"`
+	VehicleID         string         `json:"vehicleId" csv:"-"`
+	Odometer          *float64       `json:"odometer" format:"int"`
+	MeterStart        *float64       `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
+	MeterStop         *float64       `json:"meterStop" csv:"Meter Stop (kWh)" gorm:"column:meter_end_kwh"`
+	ChargedEnergy     float64        `json:"chargedEnergy" csv:"Charged Energy (kWh)" gorm:"column:charged_kwh"`
+	ChargeDuration    *time.Duration `json:"chargeDuration" csv:"Charge Duration" gorm:"column:charge_duration"`
+	SocStart          *float64       `json:"socStart" csv:"Soc Start (%)" format:"int" gorm:"column:soc_start"`
+	SocEnd            *float64       `json:"socEnd" csv:"Soc End (%)" format:"int" gorm:"column:soc_end"`
+	SolarPercentage   *float64       `json:"solarPercentage" csv:"Solar (%)" gorm:"column:solar_percentage"`
+	BatteryPercentage *float64       `json:"batteryPercentage" csv:"Battery (%)" gorm:"column:battery_percentage"`
+	Price             *float64       `json:"price" csv:"Price" gorm:"column:price"`
+	PricePerKWh       *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
+	Co2PerKWh         *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	Savings           *float64       `json:"savings" csv:"Savings" gorm:"column:savings"`
+	OffSite           bool           `json:"offSite" csv:"Off-site" gorm:"column:off_site"`
+	Tags              string         `json:"tags"`
+	Notes             string         `json:"notes"`
 }
 
 // Sessions is a list of sessions
@@ -41,14 +51,14 @@ type Sessions []Session
 
 var _ api.CsvWriter = (*Sessions)(nil)
 
-func (t *Sessions) writeHeader(ctx context.Context, ww *csv.Writer) error {
+func writeCsvHeader(ctx context.Context, ww *csv.Writer, v any) error {
 	localizer := locale.Localizer
 	if val := ctx.Value(locale.Locale).(string); val != "" {
 		localizer = i18n.NewLocalizer(locale.Bundle, val, locale.Language)
 	}
 
 	var row []string
-	for _, f := range structs.Fields(Session{}) {
+	for _, f := range structs.Fields(v) {
 		csv := f.Tag("csv")
 		if csv == "-" {
 			continue
@@ -72,7 +82,7 @@ func (t *Sessions) writeHeader(ctx context.Context, ww *csv.Writer) error {
 	return ww.Write(row)
 }
 
-func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) error {
+func writeCsvRow(ww *csv.Writer, mp *message.Printer, r any) error {
 	var row []string
 	for _, f := range structs.Fields(r) {
 		if f.Tag("csv") == "-" {
@@ -92,6 +102,10 @@ func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) erro
 			if !v.IsZero() {
 				val = v.Local().Format("2006-01-02 15:04:05")
 			}
+		case *time.Duration:
+			if v != nil {
+				val = v.Round(time.Second).String()
+			}
 		default:
 			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
 				if pv := reflect.Indirect(rv); pv.CanFloat() && !rv.IsNil() {
@@ -109,8 +123,8 @@ func (t *Sessions) writeRow(ww *csv.Writer, mp *message.Printer, r Session) erro
 	return ww.Write(row)
 }
 
-// WriteCsv implements the api.CsvWriter interface
-func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
+// writeCsv writes header and rows of the given struct type as localized csv
+func writeCsv[T any](ctx context.Context, w io.Writer, rows []T) error {
 	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
 		return err
 	}
@@ -133,13 +147,14 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 		ww.Comma = ';'
 	}
 
-	if err := t.writeHeader(ctx, ww); err != nil {
+	var header T
+	if err := writeCsvHeader(ctx, ww, header); err != nil {
 		return err
 	}
 
 	mp := message.NewPrinter(tag)
-	for _, r := range *t {
-		if err := t.writeRow(ww, mp, r); err != nil {
+	for _, r := range rows {
+		if err := writeCsvRow(ww, mp, r); err != nil {
 			return err
 		}
 	}
@@ -148,3 +163,8 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 
 	return ww.Error()
 }
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, *t)
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"golang.org/x/exp/slices"
+)
+
+// Summary is the aggregation of charging sessions per month
+type Summary struct {
+	Month             string   `json:"month"`
+	Sessions          int      `json:"sessions"`
+	ChargedEnergy     float64  `json:"chargedEnergy" csv:"Charged Energy (kWh)"`
+	SolarPercentage   *float64 `json:"solarPercentage" csv:"Solar (%)"`
+	BatteryPercentage *float64 `json:"batteryPercentage" csv:"Battery (%)"`
+	Price             *float64 `json:"price" csv:"Price"`
+	PricePerKWh       *float64 `json:"pricePerKWh" csv:"Price/kWh"`
+}
+
+// Summaries is a list of monthly summaries
+type Summaries []Summary
+
+var _ api.CsvWriter = (*Summaries)(nil)
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *Summaries) WriteCsv(ctx context.Context, w io.Writer) error {
+	return writeCsv(ctx, w, *t)
+}
+
+// Monthly aggregates sessions by month of their creation in chronological order
+func (t Sessions) Monthly() Summaries {
+	var (
+		res     Summaries
+		solar   = make(map[string]float64)
+		battery = make(map[string]float64)
+	)
+
+	for _, s := range t {
+		month := s.Created.Local().Format("2006-01")
+
+		idx := slices.IndexFunc(res, func(s Summary) bool {
+			return s.Month == month
+		})
+
+		if idx < 0 {
+			res = append(res, Summary{Month: month})
+			idx = len(res) - 1
+		}
+
+		sum := &res[idx]
+		sum.Sessions++
+		sum.ChargedEnergy += s.ChargedEnergy
+
+		if s.SolarPercentage != nil {
+			solar[month] += s.ChargedEnergy * *s.SolarPercentage / 100
+		}
+
+		if s.BatteryPercentage != nil {
+			battery[month] += s.ChargedEnergy * *s.BatteryPercentage / 100
+		}
+
+		if s.Price != nil {
+			price := *s.Price
+			if sum.Price != nil {
+				price += *sum.Price
+			}
+			sum.Price = &price
+		}
+	}
+
+	for i := range res {
+		sum := &res[i]
+
+		if sum.ChargedEnergy == 0 {
+			continue
+		}
+
+		if f, ok := solar[sum.Month]; ok {
+			perc := 100 * f / sum.ChargedEnergy
+			sum.SolarPercentage = &perc
+		}
+
+		if f, ok := battery[sum.Month]; ok {
+			perc := 100 * f / sum.ChargedEnergy
+			sum.BatteryPercentage = &perc
+		}
+
+		if sum.Price != nil {
+			pricePerKWh := *sum.Price / sum.ChargedEnergy
+			sum.PricePerKWh = &pricePerKWh
+		}
+	}
+
+	slices.SortFunc(res, func(a, b Summary) bool {
+		return a.Month < b.Month
+	})
+
+	return res
+}
+
+// Report is the aggregation of charging sessions of a period
+type Report struct {
+	Summary
+	From, To      time.Time
+	Cheapest      *Session // session with lowest price per kWh
+	MostExpensive *Session // session with highest price per kWh
+}
+
+// Report aggregates sessions created within the given period
+func (t Sessions) Report(from, to time.Time) Report {
+	res := Report{From: from, To: to}
+
+	var solar, battery float64
+	for i, s := range t {
+		if s.Created.Before(from) || !s.Created.Before(to) {
+			continue
+		}
+
+		res.Sessions++
+		res.ChargedEnergy += s.ChargedEnergy
+
+		if s.SolarPercentage != nil {
+			solar += s.ChargedEnergy * *s.SolarPercentage / 100
+		}
+
+		if s.BatteryPercentage != nil {
+			battery += s.ChargedEnergy * *s.BatteryPercentage / 100
+		}
+
+		if s.Price != nil {
+			price := *s.Price
+			if res.Price != nil {
+				price += *res.Price
+			}
+			res.Price = &price
+		}
+
+		if s.PricePerKWh != nil {
+			if res.Cheapest == nil || *s.PricePerKWh < *res.Cheapest.PricePerKWh {
+				res.Cheapest = &t[i]
+			}
+			if res.MostExpensive == nil || *s.PricePerKWh > *res.MostExpensive.PricePerKWh {
+				res.MostExpensive = &t[i]
+			}
+		}
+	}
+
+	if res.ChargedEnergy > 0 {
+		solarPercentage := 100 * solar / res.ChargedEnergy
+		res.SolarPercentage = &solarPercentage
+
+		batteryPercentage := 100 * battery / res.ChargedEnergy
+		res.BatteryPercentage = &batteryPercentage
+
+		if res.Price != nil {
+			pricePerKWh := *res.Price / res.ChargedEnergy
+			res.PricePerKWh = &pricePerKWh
+		}
+	}
+
+	return res
+}
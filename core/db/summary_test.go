@@ -0,0 +1,53 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthly(t *testing.T) {
+	ptr := func(f float64) *float64 { return &f }
+
+	res := Sessions{
+		{Created: time.Date(2023, 2, 10, 0, 0, 0, 0, time.Local), ChargedEnergy: 10, SolarPercentage: ptr(100), BatteryPercentage: ptr(40), Price: ptr(1)},
+		{Created: time.Date(2023, 1, 10, 0, 0, 0, 0, time.Local), ChargedEnergy: 5},
+		{Created: time.Date(2023, 2, 20, 0, 0, 0, 0, time.Local), ChargedEnergy: 10, SolarPercentage: ptr(0), Price: ptr(3)},
+	}.Monthly()
+
+	require.Len(t, res, 2)
+
+	assert.Equal(t, Summary{Month: "2023-01", Sessions: 1, ChargedEnergy: 5}, res[0])
+
+	assert.Equal(t, "2023-02", res[1].Month)
+	assert.Equal(t, 2, res[1].Sessions)
+	assert.Equal(t, 20.0, res[1].ChargedEnergy)
+	assert.Equal(t, 50.0, *res[1].SolarPercentage)
+	assert.Equal(t, 20.0, *res[1].BatteryPercentage)
+	assert.Equal(t, 4.0, *res[1].Price)
+	assert.Equal(t, 0.2, *res[1].PricePerKWh)
+}
+
+func TestReport(t *testing.T) {
+	ptr := func(f float64) *float64 { return &f }
+
+	from := time.Date(2023, 2, 10, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 1)
+
+	res := Sessions{
+		{Created: from.Add(-time.Hour), ChargedEnergy: 5},
+		{Created: from.Add(time.Hour), ChargedEnergy: 10, SolarPercentage: ptr(100), Price: ptr(1), PricePerKWh: ptr(0.1)},
+		{Created: from.Add(2 * time.Hour), ChargedEnergy: 10, SolarPercentage: ptr(0), Price: ptr(3), PricePerKWh: ptr(0.3)},
+		{Created: to, ChargedEnergy: 5},
+	}.Report(from, to)
+
+	assert.Equal(t, 2, res.Sessions)
+	assert.Equal(t, 20.0, res.ChargedEnergy)
+	assert.Equal(t, 50.0, *res.SolarPercentage)
+	assert.Equal(t, 4.0, *res.Price)
+	assert.Equal(t, 0.2, *res.PricePerKWh)
+	assert.Equal(t, 0.1, *res.Cheapest.PricePerKWh)
+	assert.Equal(t, 0.3, *res.MostExpensive.PricePerKWh)
+}
@@ -4,11 +4,15 @@ package core
 type EnergyMetrics struct {
 	totalKWh          float64  // Total amount of energy used (kWh)
 	solarKWh          float64  // Self-produced energy energy (kWh)
+	batteryKWh        float64  // Self-produced energy from home battery (kWh)
 	price             *float64 // Total cost (Currency)
 	co2               *float64 // Amount of emitted CO2 (gCO2eq)
+	gridCost          *float64 // Total cost if charged from grid only (Currency)
 	currentGreenShare float64  // Current share of solar energy of site (0-1)
+	currentBattShare  float64  // Current share of home battery energy of site (0-1)
 	currentPrice      *float64 // Current price per kWh
 	currentCo2        *float64 // Current co2 emissions
+	currentGridPrice  *float64 // Current grid price per kWh
 }
 
 func NewEnergyMetrics() *EnergyMetrics {
@@ -25,6 +29,17 @@ func (em *EnergyMetrics) SetEnvironment(greenShare float64, effPrice, effCo2 *fl
 	em.currentCo2 = effCo2
 }
 
+// SetGridPrice updates the grid price used as reference for calculating savings
+func (em *EnergyMetrics) SetGridPrice(gridPrice *float64) {
+	em.currentGridPrice = gridPrice
+}
+
+// SetBatteryShare updates the share of home battery discharge in the site's energy sources.
+// The battery share is part of the green share.
+func (em *EnergyMetrics) SetBatteryShare(batteryShare float64) {
+	em.currentBattShare = batteryShare
+}
+
 // Update sets the a new value for the total amount of charged energy and updated metrics based on enviroment values
 func (em *EnergyMetrics) Update(chargedKWh float64) {
 	added := chargedKWh - em.totalKWh
@@ -34,6 +49,7 @@ func (em *EnergyMetrics) Update(chargedKWh float64) {
 	}
 	em.totalKWh = chargedKWh
 	em.solarKWh += added * em.currentGreenShare
+	em.batteryKWh += added * em.currentBattShare
 	// optional values
 	if em.currentPrice != nil {
 		addedPrice := *em.currentPrice * added
@@ -43,6 +59,13 @@ func (em *EnergyMetrics) Update(chargedKWh float64) {
 		}
 		em.price = &newPrice
 	}
+	if em.currentGridPrice != nil {
+		newCost := *em.currentGridPrice * added
+		if em.gridCost != nil {
+			newCost += *em.gridCost
+		}
+		em.gridCost = &newCost
+	}
 	if em.currentCo2 != nil {
 		addedCo2 := *em.currentCo2 * added
 		newCo2 := addedCo2
@@ -57,8 +80,10 @@ func (em *EnergyMetrics) Update(chargedKWh float64) {
 func (em *EnergyMetrics) Reset() {
 	em.totalKWh = 0
 	em.solarKWh = 0
+	em.batteryKWh = 0
 	em.price = nil
 	em.co2 = nil
+	em.gridCost = nil
 }
 
 // TotalWh returns the total energy in Wh
@@ -74,6 +99,15 @@ func (em *EnergyMetrics) SolarPercentage() float64 {
 	return 100 / em.totalKWh * em.solarKWh
 }
 
+// BatteryPercentage returns the share of energy from home battery in percent.
+// The battery percentage is part of the solar percentage.
+func (em *EnergyMetrics) BatteryPercentage() float64 {
+	if em.totalKWh == 0 {
+		return 0
+	}
+	return 100 / em.totalKWh * em.batteryKWh
+}
+
 // Price returns the total energy price in Currency
 func (em *EnergyMetrics) Price() *float64 {
 	if em.totalKWh == 0 || em.price == nil {
@@ -91,6 +125,15 @@ func (em *EnergyMetrics) PricePerKWh() *float64 {
 	return &price
 }
 
+// Savings returns the total savings compared to charging from grid only in Currency
+func (em *EnergyMetrics) Savings() *float64 {
+	if em.totalKWh == 0 || em.price == nil || em.gridCost == nil {
+		return nil
+	}
+	savings := *em.gridCost - *em.price
+	return &savings
+}
+
 // Co2PerKWh returns the average co2 emissions per kWh
 func (em *EnergyMetrics) Co2PerKWh() *float64 {
 	if em.totalKWh == 0 || em.co2 == nil {
@@ -104,7 +147,9 @@ func (em *EnergyMetrics) Co2PerKWh() *float64 {
 func (em *EnergyMetrics) Publish(prefix string, p publisher) {
 	p.publish(prefix+"Energy", em.TotalWh())
 	p.publish(prefix+"SolarPercentage", em.SolarPercentage())
+	p.publish(prefix+"BatteryPercentage", em.BatteryPercentage())
 	p.publish(prefix+"PricePerKWh", em.PricePerKWh())
 	p.publish(prefix+"Price", em.Price())
+	p.publish(prefix+"Savings", em.Savings())
 	p.publish(prefix+"Co2PerKWh", em.Co2PerKWh())
 }
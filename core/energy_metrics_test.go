@@ -1,6 +1,7 @@
 package core
 
 import (
+	"math"
 	"testing"
 )
 
@@ -144,3 +145,54 @@ func TestEnergyMetrics(t *testing.T) {
 		t.Errorf("Metrics not properly reset %+v", s)
 	}
 }
+
+func TestEnergyMetricsBattery(t *testing.T) {
+	s := NewEnergyMetrics()
+
+	s.SetEnvironment(1, nil, nil)
+	s.SetBatteryShare(0.5)
+	s.Update(1)
+
+	s.SetEnvironment(0, nil, nil)
+	s.SetBatteryShare(0)
+	s.Update(2)
+
+	if s.SolarPercentage() != 50 {
+		t.Errorf("SolarPercentage was incorrect, got: %.3f, want: 50", s.SolarPercentage())
+	}
+	if s.BatteryPercentage() != 25 {
+		t.Errorf("BatteryPercentage was incorrect, got: %.3f, want: 25", s.BatteryPercentage())
+	}
+
+	s.Reset()
+	if s.BatteryPercentage() != 0 {
+		t.Errorf("BatteryPercentage not properly reset %+v", s)
+	}
+}
+
+func TestEnergyMetricsSavings(t *testing.T) {
+	f := func(f float64) *float64 { return &f }
+
+	s := NewEnergyMetrics()
+	if s.Savings() != nil {
+		t.Error("expected no savings")
+	}
+
+	// grid only
+	s.SetEnvironment(0, f(0.3), nil)
+	s.SetGridPrice(f(0.3))
+	s.Update(1)
+
+	// solar only, valued at feed-in
+	s.SetEnvironment(1, f(0.1), nil)
+	s.Update(2)
+
+	if savings := s.Savings(); savings == nil || math.Abs(*savings-0.2) > 1e-6 {
+		t.Errorf("Savings was incorrect, got: %v, want: 0.2", savings)
+	}
+
+	s.Reset()
+	if s.Savings() != nil {
+		t.Error("expected no savings after reset")
+	}
+}
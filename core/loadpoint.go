@@ -35,6 +35,11 @@ const (
 	evVehicleDisconnect   = "disconnect" // vehicle disconnected
 	evVehicleSoc          = "soc"        // vehicle soc progress
 	evVehicleUnidentified = "guest"      // vehicle unidentified
+	evVehicleIdentified   = "identified" // vehicle identified
+	evVehicleTargetSoc    = "targetsoc"  // vehicle reached target soc
+	evVehicleCalibrated   = "calibrated" // vehicle calibration charge completed
+	evVehicleReauth       = "reauth"     // vehicle api requires re-authentication
+	evChargerFault        = "fault"      // charger reports fault
 
 	pvTimer   = "pv"
 	pvEnable  = "enable"
@@ -49,8 +54,9 @@ const (
 
 	timerInactive = "inactive"
 
-	minActiveCurrent = 1.0 // minimum current at which a phase is treated as active
-	minActiveVoltage = 208 // minimum voltage at which a phase is treated as active
+	minActiveCurrent = 1.0  // minimum current at which a phase is treated as active
+	minActiveVoltage = 208  // minimum voltage at which a phase is treated as active
+	weakVoltageRatio = 0.95 // voltage below this share of nominal voltage indicates a weak phase while charging
 
 	guardGracePeriod = 60 * time.Second // allow out of sync during this timespan
 )
@@ -109,30 +115,48 @@ type Loadpoint struct {
 
 	Title_            string   `mapstructure:"title"`    // UI title
 	Priority_         int      `mapstructure:"priority"` // Priority
+	Group_            string   `mapstructure:"group"`    // Group for aggregated statistics
+	Circuit_          string   `mapstructure:"circuit"`  // Circuit for shared current limit
 	ConfiguredPhases  int      `mapstructure:"phases"`   // Charger configured phase mode 0/1/3
 	ChargerRef        string   `mapstructure:"charger"`  // Charger reference
 	VehicleRef        string   `mapstructure:"vehicle"`  // Vehicle reference
 	VehiclesRef_      []string `mapstructure:"vehicles"` // TODO deprecated
 	MeterRef          string   `mapstructure:"meter"`    // Charge meter reference
+	Virtual           bool     `mapstructure:"virtual"`  // Off-site loadpoint fed by the vehicle api
 	Soc               SocConfig
+	Geofence          GeofenceConfig
 	Enable, Disable   ThresholdConfig
-	ResetOnDisconnect bool `mapstructure:"resetOnDisconnect"`
+	PhaseSwitch       PhaseSwitchConfig
+	Schedules         []loadpoint.Schedule
+	Hooks             map[string]string // commands executed on loadpoint events
+	ResetOnDisconnect bool              `mapstructure:"resetOnDisconnect"`
 	onDisconnect      api.ActionConfig
 	targetEnergy      float64 // Target charge energy for dumb vehicles in kWh
 
-	MinCurrent    float64       // PV mode: start current	Min+PV mode: min current
-	MaxCurrent    float64       // Max allowed current. Physically ensured by the charger
-	GuardDuration time.Duration // charger enable/disable minimum holding time
-
-	enabled             bool      // Charger enabled state
-	phases              int       // Charger enabled phases, guarded by mutex
-	measuredPhases      int       // Charger physically measured phases
-	chargeCurrent       float64   // Charger current limit
-	guardUpdated        time.Time // Charger enabled/disabled timestamp
-	socUpdated          time.Time // Soc updated timestamp (poll: connected)
-	vehicleDetect       time.Time // Vehicle connected timestamp
-	vehicleDetectTicker *clock.Ticker
-	vehicleIdentifier   string
+	MinCurrent     float64       // PV mode: start current	Min+PV mode: min current
+	MaxCurrent     float64       // Max allowed current. Physically ensured by the charger
+	CircuitCurrent float64       // Hard current limit of the wiring, not changeable at runtime
+	GuardDuration  time.Duration // charger enable/disable minimum holding time
+	StartupGrace   time.Duration // ignore measured current for pv decisions after enabling
+	RampRate       float64       // max charge current change per second (A/s)
+
+	enabled                bool      // Charger enabled state
+	phases                 int       // Charger enabled phases, guarded by mutex
+	measuredPhases         int       // Charger physically measured phases
+	chargeCurrent          float64   // Charger current limit
+	dryRun                 bool      // Log charger commands instead of executing them
+	capacity               *capacity // Source capacity limit imposed by site
+	circuitShare           *float64  // Circuit current share imposed by site
+	rampUpdated            time.Time // Ramp limit last applied timestamp
+	guardUpdated           time.Time // Charger enabled/disabled timestamp
+	socUpdated             time.Time // Soc updated timestamp (poll: connected)
+	vehicleDetect          time.Time // Vehicle connected timestamp
+	vehicleDetectTicker    *clock.Ticker
+	vehicleIdentifier      string
+	vehicleAway            bool      // Vehicle positioned outside geofence
+	vehiclePositionUpdated time.Time // Vehicle position last updated
+	preconditioned         time.Time // Target time vehicle climatisation was started for
+	calibrationTargetSoc   int       // Target soc to restore after calibration charge
 
 	charger          api.Charger
 	chargeTimer      api.ChargeTimer
@@ -152,17 +176,26 @@ type Loadpoint struct {
 	planActive  bool      // plan is active
 
 	// cached state
-	status         api.ChargeStatus       // Charger status
-	remoteDemand   loadpoint.RemoteDemand // External status demand
-	chargePower    float64                // Charging power
-	chargeCurrents []float64              // Phase currents
-	connectedTime  time.Time              // Time when vehicle was connected
-	pvTimer        time.Time              // PV enabled/disable timer
-	phaseTimer     time.Time              // 1p3p switch timer
-	wakeUpTimer    *Timer                 // Vehicle wake-up timeout
+	status         api.ChargeStatus                  // Charger status
+	faultCode      int64                             // Charger fault code
+	remoteDemand   loadpoint.RemoteDemand            // External status demand
+	remoteDemands  map[string]loadpoint.RemoteDemand // External status demand by source
+	profile        *api.ActionConfig                 // Active site mode profile
+	chargePower    float64                           // Charging power
+	chargeCurrents []float64                         // Phase currents
+	weakPhases     [3]bool                           // Phases with voltage drop while charging
+	connectedTime  time.Time                         // Time when vehicle was connected
+	pvTimer        time.Time                         // PV enabled/disable timer
+	phaseTimer     time.Time                         // 1p3p switch timer
+	wakeUpTimer    *Timer                            // Vehicle wake-up timeout
 
 	// charge progress
 	vehicleSoc              float64        // Vehicle Soc
+	vehicleSocEstimated     bool           // Vehicle Soc was estimated or restored
+	vehicleSocLimit         float64        // Vehicle Soc limit reported by vehicle api
+	vehicleCableLocked      bool           // Vehicle charge cable locked reported by vehicle api
+	plugFault               bool           // Vehicle reports locked cable while charger is disconnected
+	targetSocNotified       bool           // Target soc reached event has been sent
 	chargeDuration          time.Duration  // Charge duration
 	sessionEnergy           *EnergyMetrics // Stats for charged energy by session
 	chargeRemainingDuration time.Duration  // Remaining charge duration
@@ -170,10 +203,19 @@ type Loadpoint struct {
 	progress                *Progress      // Step-wise progress indicator
 
 	// session log
+	id      string // Persistent id of the charger, keeps sessions when loadpoint or charger are renamed
 	db      db.Database
 	session *db.Session
 
+	sessionTags, sessionNotes string // tags and notes for the next session
+
+	schedules []schedule // charging schedule windows, guarded by mutex
+	budget    *budget    // monthly energy budget, guarded by mutex
+
 	tasks *util.Queue[Task] // tasks to be executed
+
+	batchMux sync.Mutex        // guard batch
+	batch    *api.ActionConfig // settings pending for the next update cycle
 }
 
 // NewLoadpointFromConfig creates a new loadpoint
@@ -203,6 +245,18 @@ func NewLoadpointFromConfig(log *util.Logger, cp configProvider, other map[strin
 		lp.log.WARN.Println("maxCurrent must be larger than minCurrent")
 	}
 
+	if lp.CircuitCurrent > 0 {
+		if lp.MaxCurrent > lp.CircuitCurrent {
+			lp.log.WARN.Printf("maxCurrent exceeds circuitCurrent, limiting to %.3gA", lp.CircuitCurrent)
+			lp.MaxCurrent = lp.CircuitCurrent
+		}
+
+		if lp.MinCurrent > lp.CircuitCurrent {
+			lp.log.WARN.Printf("minCurrent exceeds circuitCurrent, limiting to %.3gA", lp.CircuitCurrent)
+			lp.MinCurrent = lp.CircuitCurrent
+		}
+	}
+
 	if lp.Soc.Min_ != 0 {
 		lp.log.WARN.Println("Configuring soc.min at loadpoint is deprecated and must be applied per vehicle")
 	}
@@ -211,6 +265,20 @@ func NewLoadpointFromConfig(log *util.Logger, cp configProvider, other map[strin
 		lp.log.WARN.Println("Configuring soc.target at loadpoint is deprecated and must be applied per vehicle")
 	}
 
+	// loadpoints are identified by the persistent charger id, settings recorded by loadpoint title are linked to the id
+	lp.id = cp.DeviceID("charger", lp.ChargerRef)
+	if err := migrateDeviceSettings("loadpoint", lp.id, lp.Title()); err != nil {
+		return nil, err
+	}
+
+	if err := lp.configureSchedules(); err != nil {
+		return nil, err
+	}
+
+	if err := lp.configureHooks(); err != nil {
+		return nil, err
+	}
+
 	// store defaults
 	lp.collectDefaults()
 
@@ -234,12 +302,18 @@ func NewLoadpointFromConfig(log *util.Logger, cp configProvider, other map[strin
 		lp.log.WARN.Println("vehicles option is deprecated")
 	}
 
-	if lp.ChargerRef == "" {
-		return nil, errors.New("missing charger")
-	}
-	var err error
-	if lp.charger, err = cp.Charger(lp.ChargerRef); err != nil {
-		return nil, err
+	if lp.Virtual {
+		if err := lp.configureVirtual(); err != nil {
+			return nil, err
+		}
+	} else {
+		if lp.ChargerRef == "" {
+			return nil, errors.New("missing charger")
+		}
+		var err error
+		if lp.charger, err = cp.Charger(lp.ChargerRef); err != nil {
+			return nil, err
+		}
 	}
 	lp.configureChargerType(lp.charger)
 
@@ -310,6 +384,7 @@ func (lp *Loadpoint) collectDefaults() {
 		*actionCfg.Mode = lp.GetMode()
 		*actionCfg.MinCurrent = lp.GetMinCurrent()
 		*actionCfg.MaxCurrent = lp.GetMaxCurrent()
+		*actionCfg.Phases = lp.ConfiguredPhases
 		*actionCfg.MinSoc = lp.GetMinSoc()
 		*actionCfg.TargetSoc = lp.GetTargetSoc()
 		*actionCfg.Priority = lp.Priority()
@@ -326,6 +401,14 @@ func (lp *Loadpoint) requestUpdate() {
 	}
 }
 
+// updateNotifier requests loadpoint updates for device notifications
+func (lp *Loadpoint) updateNotifier(notifyC <-chan struct{}) {
+	for range notifyC {
+		lp.log.TRACE.Println("update requested by device")
+		lp.requestUpdate()
+	}
+}
+
 // configureChargerType ensures that chargeMeter, Rate and Timer can use charger capabilities
 func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 	var integrated bool
@@ -378,8 +461,9 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 	lp.wakeUpTimer = NewTimer()
 }
 
-// pushEvent sends push messages to clients
+// pushEvent sends push messages to clients and runs the event's hook command
 func (lp *Loadpoint) pushEvent(event string) {
+	lp.runHook(event)
 	lp.pushChan <- push.Event{Event: event}
 }
 
@@ -404,6 +488,11 @@ func (lp *Loadpoint) evChargeStartHandler() {
 	lp.updateSession(func(session *db.Session) {
 		if session.Created.IsZero() {
 			session.Created = lp.clock.Now()
+
+			if lp.vehicleSoc > 0 {
+				socStart := lp.vehicleSoc
+				session.SocStart = &socStart
+			}
 		}
 	})
 }
@@ -442,13 +531,7 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 	lp.connectedTime = lp.clock.Now()
 	lp.publish("connectedDuration", time.Duration(0))
 
-	// soc update reset
-	lp.socUpdated = time.Time{}
-
-	// soc update reset on car change
-	if lp.socEstimator != nil {
-		lp.socEstimator.Reset()
-	}
+	// soc is reset on vehicle change or disconnect, keep soc restored at startup
 
 	// set default or start detection
 	if !lp.chargerHasFeature(api.IntegratedDevice) {
@@ -484,6 +567,14 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	lp.setVehicleIdentifier("")
 	lp.stopVehicleDetection()
 
+	// revert calibration target soc
+	lp.Lock()
+	lp.stopCalibration()
+	lp.Unlock()
+
+	// cable state of the connected vehicle is outdated
+	lp.resetVehicleCable()
+
 	// set default vehicle (may be nil)
 	lp.setActiveVehicle(lp.defaultVehicle)
 
@@ -497,8 +588,14 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 		lp.applyAction(lp.defaultVehicle.OnIdentified())
 	}
 
-	// soc update reset
+	// site mode profile takes precedence
+	lp.applyProfile()
+
+	// soc update reset, vehicle may be driven before reconnecting
 	lp.socUpdated = time.Time{}
+	if lp.socEstimator != nil {
+		lp.socEstimator.Reset()
+	}
 
 	// reset plan once charge goal is met
 	lp.setTargetTime(time.Time{})
@@ -548,12 +645,20 @@ func (lp *Loadpoint) applyAction(actionCfg api.ActionConfig) {
 	if max := actionCfg.MaxCurrent; max != nil && *max <= *lp.onDisconnect.MaxCurrent {
 		lp.SetMaxCurrent(*max)
 	}
+	if _, ok := lp.charger.(api.PhaseSwitcher); ok && actionCfg.Phases != nil {
+		if err := lp.SetPhases(*actionCfg.Phases); err != nil {
+			lp.log.ERROR.Println(err)
+		}
+	}
 	if actionCfg.MinSoc != nil {
-		lp.SetMinSoc(*actionCfg.MinSoc)
+		lp.applyMinSoc(*actionCfg.MinSoc)
 	}
 	if actionCfg.TargetSoc != nil {
 		lp.SetTargetSoc(*actionCfg.TargetSoc)
 	}
+	if actionCfg.Priority != nil {
+		lp.SetPriority(*actionCfg.Priority)
+	}
 }
 
 // Prepare loadpoint configuration by adding missing helper elements
@@ -570,10 +675,24 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 	_ = lp.bus.Subscribe(evChargeCurrent, lp.evChargeCurrentHandler)
 	_ = lp.bus.Subscribe(evVehicleSoc, lp.evVehicleSocProgressHandler)
 
+	// push-capable devices trigger immediate updates
+	if n, ok := lp.charger.(api.UpdateNotifier); ok {
+		go lp.updateNotifier(n.UpdateNotify())
+	}
+	if n, ok := lp.chargeMeter.(api.UpdateNotifier); ok && any(lp.chargeMeter) != any(lp.charger) {
+		go lp.updateNotifier(n.UpdateNotify())
+	}
+
 	// publish initial values
 	lp.publish(title, lp.Title())
+	lp.publish("priority", lp.Priority())
+	lp.publish("virtual", lp.Virtual)
+	lp.publishSchedules()
 	lp.publish(minCurrent, lp.MinCurrent)
 	lp.publish(maxCurrent, lp.MaxCurrent)
+	if lp.CircuitCurrent > 0 {
+		lp.publish("circuitCurrent", lp.CircuitCurrent)
+	}
 
 	lp.setConfiguredPhases(lp.ConfiguredPhases)
 	lp.publish(phasesEnabled, lp.phases)
@@ -623,8 +742,22 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 	}
 }
 
+// syncChargerEnable re-applies the expected charger state. Vehicle charge control is not
+// used for synchronization to avoid excessive api calls.
+func (lp *Loadpoint) syncChargerEnable(enable bool) error {
+	if err := lp.charger.Enable(enable); !errors.Is(err, api.ErrNotAvailable) {
+		return err
+	}
+	return nil
+}
+
 // syncCharger updates charger status and synchronizes it with expectations
 func (lp *Loadpoint) syncCharger() error {
+	// charger state is not expected to follow in dry run mode
+	if lp.dryRun {
+		return nil
+	}
+
 	enabled, err := lp.charger.Enabled()
 	if err != nil {
 		return err
@@ -635,14 +768,14 @@ func (lp *Loadpoint) syncCharger() error {
 		if lp.guardGracePeriodElapsed() && (!lp.enabled || lp.connected()) {
 			lp.log.WARN.Printf("charger out of sync: expected %vd, got %vd", status[lp.enabled], status[enabled])
 		}
-		return lp.charger.Enable(lp.enabled)
+		return lp.syncChargerEnable(lp.enabled)
 	}
 
 	if !enabled && lp.charging() {
 		if lp.guardGracePeriodElapsed() {
 			lp.log.WARN.Println("charger logic error: disabled but charging")
 		}
-		return lp.charger.Enable(false)
+		return lp.syncChargerEnable(false)
 	}
 
 	return nil
@@ -650,27 +783,51 @@ func (lp *Loadpoint) syncCharger() error {
 
 // setLimit applies charger current limits and enables/disables accordingly
 func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
+	// limit current change rate
+	if !force {
+		chargeCurrent = lp.rampLimit(chargeCurrent)
+	}
+
+	// hard limits are applied after ramping to take effect immediately
+	requested := chargeCurrent
+
+	// limit by source capacity
+	chargeCurrent = lp.capacityLimit(chargeCurrent)
+
+	// limit by shared circuit
+	chargeCurrent = lp.circuitLimit(chargeCurrent)
+
+	// never exceed the circuit rating
+	if lp.CircuitCurrent > 0 && chargeCurrent > lp.CircuitCurrent {
+		chargeCurrent = lp.CircuitCurrent
+	}
+
+	// disabling due to a hard limit must not be delayed by the guard
+	limited := chargeCurrent < requested && chargeCurrent < lp.GetMinCurrent()
+
 	// full amps only?
-	if _, ok := lp.charger.(api.ChargerEx); !ok || lp.vehicleHasFeature(api.CoarseCurrent) {
+	_, powerLimiter := lp.charger.(api.PowerLimiter)
+	if _, ok := lp.charger.(api.ChargerEx); !ok && !powerLimiter || lp.vehicleHasFeature(api.CoarseCurrent) {
 		chargeCurrent = math.Trunc(chargeCurrent)
 	}
 
+	// re-apply current if charger deviates from last setpoint
+	if lp.enabled && !lp.dryRun {
+		lp.reconcileCurrent()
+	}
+
 	// set current
 	if chargeCurrent != lp.chargeCurrent && chargeCurrent >= lp.GetMinCurrent() {
-		var err error
-		if charger, ok := lp.charger.(api.ChargerEx); ok {
-			err = charger.MaxCurrentMillis(chargeCurrent)
-		} else {
-			err = lp.charger.MaxCurrent(int64(chargeCurrent))
-		}
-
-		if err != nil {
+		if err := lp.chargerMaxCurrent(chargeCurrent); err != nil {
 			v := lp.GetVehicle()
 			if vv, ok := v.(api.Resurrector); ok && errors.Is(err, api.ErrAsleep) {
 				// https://github.com/evcc-io/evcc/issues/8254
 				// wakeup vehicle
 				lp.log.DEBUG.Printf("max charge current: waking up vehicle")
-				return vv.WakeUp()
+				if err := vv.WakeUp(); err != nil && !errors.Is(err, api.ErrMustRetry) {
+					return err
+				}
+				return nil
 			}
 
 			return fmt.Errorf("max charge current %.3gA: %w", chargeCurrent, err)
@@ -683,13 +840,13 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
 
 	// set enabled/disabled
 	if enabled := chargeCurrent >= lp.GetMinCurrent(); enabled != lp.enabled {
-		if remaining := (lp.GuardDuration - lp.clock.Since(lp.guardUpdated)).Truncate(time.Second); remaining > 0 && !force {
+		if remaining := (lp.GuardDuration - lp.clock.Since(lp.guardUpdated)).Truncate(time.Second); remaining > 0 && !force && !limited {
 			lp.publishTimer(guardTimer, lp.GuardDuration, guardEnable)
 			return nil
 		}
 		lp.elapseGuard()
 
-		if err := lp.charger.Enable(enabled); err != nil {
+		if err := lp.chargerEnable(enabled); err != nil {
 			return fmt.Errorf("charger %s: %w", status[enabled], err)
 		}
 
@@ -710,6 +867,26 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
 	return nil
 }
 
+// reconcileCurrent reads back the charger's active current limit and updates the
+// last known setpoint if the charger has clamped or otherwise modified it
+func (lp *Loadpoint) reconcileCurrent() {
+	charger, ok := lp.charger.(api.CurrentGetter)
+	if !ok {
+		return
+	}
+
+	current, err := charger.GetMaxCurrent()
+	if err != nil {
+		lp.log.ERROR.Printf("max charge current: %v", err)
+		return
+	}
+
+	if math.Abs(current-lp.chargeCurrent) > 0.1 {
+		lp.log.WARN.Printf("max charge current: charger reports %.3gA, expected %.3gA", current, lp.chargeCurrent)
+		lp.chargeCurrent = current
+	}
+}
+
 // connected returns the EVs connection state
 func (lp *Loadpoint) connected() bool {
 	status := lp.GetStatus()
@@ -749,6 +926,15 @@ func (lp *Loadpoint) targetSocReached() bool {
 		lp.vehicleSoc >= float64(lp.Soc.target)
 }
 
+// notifyTargetSocReached sends the target soc event once the connected vehicle reaches its target soc
+func (lp *Loadpoint) notifyTargetSocReached() {
+	reached := lp.connected() && lp.targetSocReached()
+	if reached && !lp.targetSocNotified {
+		lp.pushEvent(evVehicleTargetSoc)
+	}
+	lp.targetSocNotified = reached
+}
+
 // minSocNotReached checks if minimum is configured and not reached.
 // If vehicle is not configured this will always return false
 func (lp *Loadpoint) minSocNotReached() bool {
@@ -853,7 +1039,7 @@ func (lp *Loadpoint) effectiveCurrent() float64 {
 	}
 
 	// adjust actual current for vehicles like Zoe where it remains below target
-	if lp.chargeCurrents != nil {
+	if lp.chargeCurrents != nil && !lp.startupGraceActive() {
 		cur := max(lp.chargeCurrents)
 		return math.Min(cur+2.0, lp.chargeCurrent)
 	}
@@ -861,6 +1047,11 @@ func (lp *Loadpoint) effectiveCurrent() float64 {
 	return lp.chargeCurrent
 }
 
+// startupGraceActive checks if the charger has been enabled recently and the vehicle may still be ramping up
+func (lp *Loadpoint) startupGraceActive() bool {
+	return lp.enabled && lp.StartupGrace > 0 && lp.clock.Since(lp.guardUpdated) < lp.StartupGrace
+}
+
 // elapsePVTimer puts the pv enable/disable timer into elapsed state
 func (lp *Loadpoint) elapsePVTimer() {
 	if lp.pvTimer.Equal(elapsed) {
@@ -935,8 +1126,10 @@ func (lp *Loadpoint) scalePhases(phases int) error {
 		}
 
 		// switch phases
-		if err := cp.Phases1p3p(phases); err != nil {
-			return fmt.Errorf("switch phases: %w", err)
+		if !lp.dryRunCommand("switch phases %dp", phases) {
+			if err := cp.Phases1p3p(phases); err != nil {
+				return fmt.Errorf("switch phases: %w", err)
+			}
 		}
 
 		// update setting and reset timer
@@ -986,9 +1179,10 @@ func (lp *Loadpoint) pvScalePhases(availablePower, minCurrent, maxCurrent float6
 			lp.phaseTimer = lp.clock.Now()
 		}
 
-		lp.publishTimer(phaseTimer, lp.Disable.Delay, phaseScale1p)
+		delay := lp.phaseSwitchDelay(lp.Disable)
+		lp.publishTimer(phaseTimer, delay, phaseScale1p)
 
-		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= lp.Disable.Delay {
+		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= delay {
 			lp.log.DEBUG.Printf("phase %s timer elapsed", phaseScale1p)
 			if err := lp.scalePhases(1); err == nil {
 				lp.log.DEBUG.Printf("switched phases: 1p @ %.0fW", availablePower)
@@ -1006,17 +1200,18 @@ func (lp *Loadpoint) pvScalePhases(availablePower, minCurrent, maxCurrent float6
 	scalable := maxPhases > 1 && phases < maxPhases && target1pCurrent > maxCurrent
 
 	// scale up phases
-	if targetCurrent := powerToCurrent(availablePower, maxPhases); targetCurrent >= minCurrent && scalable {
-		lp.log.DEBUG.Printf("available power %.0fW > %.0fW min %dp threshold", availablePower, 3*Voltage*minCurrent, maxPhases)
+	if targetCurrent := powerToCurrent(availablePower-lp.PhaseSwitch.Hysteresis, maxPhases); targetCurrent >= minCurrent && scalable {
+		lp.log.DEBUG.Printf("available power %.0fW > %.0fW min %dp threshold", availablePower, 3*Voltage*minCurrent+lp.PhaseSwitch.Hysteresis, maxPhases)
 
 		if lp.phaseTimer.IsZero() {
 			lp.log.DEBUG.Printf("start phase %s timer", phaseScale3p)
 			lp.phaseTimer = lp.clock.Now()
 		}
 
-		lp.publishTimer(phaseTimer, lp.Enable.Delay, phaseScale3p)
+		delay := lp.phaseSwitchDelay(lp.Enable)
+		lp.publishTimer(phaseTimer, delay, phaseScale3p)
 
-		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= lp.Enable.Delay {
+		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= delay {
 			lp.log.DEBUG.Printf("phase %s timer elapsed", phaseScale3p)
 			if err := lp.scalePhases(3); err == nil {
 				lp.log.DEBUG.Printf("switched phases: 3p @ %.0fW", availablePower)
@@ -1092,6 +1287,12 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 	}
 
 	if mode == api.ModePV && lp.enabled && targetCurrent < minCurrent {
+		// don't judge vehicle consumption while it is still starting up
+		if lp.startupGraceActive() {
+			lp.log.DEBUG.Printf("pv disable timer: startup grace remaining: %v", (lp.StartupGrace - lp.clock.Since(lp.guardUpdated)).Round(time.Second))
+			return minCurrent
+		}
+
 		// kick off disable sequence
 		if sitePower >= lp.Disable.Threshold && lp.phaseTimer.IsZero() {
 			lp.log.DEBUG.Printf("site power %.0fW >= %.0fW disable threshold", sitePower, lp.Disable.Threshold)
@@ -1229,12 +1430,8 @@ func (lp *Loadpoint) updateChargeCurrents() {
 	}
 }
 
-// updateChargeVoltages uses PhaseVoltages interface to count phases with nominal grid voltage
+// updateChargeVoltages uses PhaseVoltages interface to publish voltages and count phases with nominal grid voltage
 func (lp *Loadpoint) updateChargeVoltages() {
-	if _, ok := lp.charger.(api.PhaseSwitcher); ok {
-		return // we don't need the voltages
-	}
-
 	phaseMeter, ok := lp.chargeMeter.(api.PhaseVoltages)
 	if !ok {
 		return // don't guess
@@ -1250,6 +1447,27 @@ func (lp *Loadpoint) updateChargeVoltages() {
 	lp.log.DEBUG.Printf("charge voltages: %.3gV", chargeVoltages)
 	lp.publish("chargeVoltages", chargeVoltages)
 
+	// weak phases show voltage drop under load, log on change only
+	charging := lp.charging()
+	for i, u := range chargeVoltages {
+		weak := charging && u > minActiveVoltage && u < weakVoltageRatio*Voltage
+		if weak == lp.weakPhases[i] {
+			continue
+		}
+
+		lp.weakPhases[i] = weak
+
+		if weak {
+			lp.log.WARN.Printf("weak phase L%d: %.0fV", i+1, u)
+		} else if charging {
+			lp.log.INFO.Printf("phase L%d recovered: %.0fV", i+1, u)
+		}
+	}
+
+	if _, ok := lp.charger.(api.PhaseSwitcher); ok {
+		return // we don't need the voltages for phase detection
+	}
+
 	// Quine-McCluskey for (¬L1∧L2∧¬L3) ∨ (L1∧L2∧¬L3) ∨ (¬L1∧¬L2∧L3) ∨ (L1∧¬L2∧L3) ∨ (¬L1∧L2∧L3) -> ¬L1 ∧ L3 ∨ L2 ∧ ¬L3 ∨ ¬L2 ∧ L3
 	if !(u1 > minActiveVoltage) && (u3 > minActiveVoltage) || (u2 > minActiveVoltage) && !(u3 > minActiveVoltage) || !(u2 > minActiveVoltage) && (u3 > minActiveVoltage) {
 		lp.log.WARN.Printf("invalid phase wiring between charge meter and charger")
@@ -1316,6 +1534,10 @@ func (lp *Loadpoint) publishSocAndRange() {
 		lp.socUpdated = lp.clock.Now()
 
 		f, err := lp.socEstimator.Soc(lp.getChargedEnergy())
+		if queried, err := lp.socEstimator.VehicleResult(); queried {
+			lp.reportVehicleHealth(err)
+		}
+
 		if err != nil {
 			if errors.Is(err, api.ErrMustRetry) {
 				lp.socUpdated = time.Time{}
@@ -1327,14 +1549,17 @@ func (lp *Loadpoint) publishSocAndRange() {
 		}
 
 		lp.vehicleSoc = f
+		lp.vehicleSocEstimated = lp.socEstimator.Estimated()
 		lp.log.DEBUG.Printf("vehicle soc: %.0f%%", lp.vehicleSoc)
 		lp.publish(vehicleSoc, lp.vehicleSoc)
+		lp.publish("vehicleSocEstimated", lp.vehicleSocEstimated)
 
 		// vehicle target soc
 		targetSoc := 100
 		if vs, ok := lp.GetVehicle().(api.SocLimiter); ok {
 			if limit, err := vs.TargetSoc(); err == nil {
 				targetSoc = int(math.Trunc(limit))
+				lp.vehicleSocLimit = limit
 				lp.log.DEBUG.Printf("vehicle soc limit: %.0f%%", limit)
 				lp.publish(vehicleTargetSoc, limit)
 			} else {
@@ -1356,16 +1581,27 @@ func (lp *Loadpoint) publishSocAndRange() {
 
 		lp.SetRemainingEnergy(1e3 * lp.socEstimator.RemainingChargeEnergy(socLimit))
 
+		state := vehicleSocState{Soc: f, Updated: lp.socUpdated}
+
 		// range
 		if vs, ok := lp.GetVehicle().(api.VehicleRange); ok {
 			if rng, err := vs.Range(); err == nil {
 				lp.log.DEBUG.Printf("vehicle range: %dkm", rng)
 				lp.publish(vehicleRange, rng)
+				state.Range = rng
 			} else {
 				lp.log.ERROR.Printf("vehicle range: %v", err)
 			}
 		}
 
+		// charge port and cable
+		lp.updateVehicleChargePort()
+
+		// persist for restart
+		if v := lp.GetVehicle(); v != nil {
+			lp.persistVehicleSoc(v, state)
+		}
+
 		// trigger message after variables are updated
 		lp.bus.Publish(evVehicleSoc, f)
 	}
@@ -1423,6 +1659,9 @@ func (lp *Loadpoint) guardGracePeriodElapsed() bool {
 func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batteryStart bool, greenShare float64, effPrice, effCo2 *float64) {
 	lp.processTasks()
 
+	// apply pending settings before evaluating them
+	lp.applyBatch()
+
 	mode := lp.GetMode()
 	lp.publish("mode", mode)
 
@@ -1445,6 +1684,10 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		return
 	}
 
+	// read and publish charger and plug fault
+	lp.updateChargerFault()
+	lp.updatePlugFault()
+
 	lp.publish("connected", lp.connected())
 	lp.publish("charging", lp.charging())
 	lp.publish("enabled", lp.enabled)
@@ -1463,6 +1706,23 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	// publish soc after updating charger status to make sure
 	// initial update of connected state matches charger status
 	lp.publishSocAndRange()
+	lp.vehicleCalibration()
+	lp.notifyTargetSocReached()
+
+	// off-site charging can't be controlled
+	if lp.Virtual {
+		return
+	}
+
+	// monthly energy budget
+	lp.updateBudget()
+	budgetAction := lp.budgetAction()
+
+	// restrict to pv if budget exceeded
+	if budgetAction == api.ModePV {
+		mode = api.ModePV
+		autoCharge = false
+	}
 
 	// sync settings with charger
 	if err := lp.syncCharger(); err != nil {
@@ -1483,6 +1743,14 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		// https://github.com/evcc-io/evcc/issues/105
 		err = lp.setLimit(0, false)
 
+	case lp.chargerFaulted():
+		lp.log.DEBUG.Printf("charging blocked by charger fault %d", lp.faultCode)
+		err = lp.setLimit(0, true)
+
+	case lp.vehicleOutsideGeofence():
+		lp.log.DEBUG.Println("vehicle outside geofence")
+		err = lp.setLimit(0, true)
+
 	case lp.scalePhasesRequired():
 		if err = lp.scalePhases(lp.ConfiguredPhases); err == nil {
 			lp.log.DEBUG.Printf("switched phases: %dp", lp.ConfiguredPhases)
@@ -1496,6 +1764,14 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		lp.log.DEBUG.Printf("targetSoc reached: %.1f%% > %d%%", lp.vehicleSoc, lp.Soc.target)
 		err = lp.disableUnlessClimater()
 
+	case lp.scheduleBlocked(lp.clock.Now()):
+		lp.log.DEBUG.Println("charging blocked by schedule")
+		err = lp.setLimit(0, true)
+
+	case budgetAction == api.ModeOff:
+		lp.log.DEBUG.Println("charging blocked by exceeded budget")
+		err = lp.setLimit(0, true)
+
 	case lp.remoteControlled(loadpoint.RemoteHardDisable):
 		remoteDisabled = loadpoint.RemoteHardDisable
 		fallthrough
@@ -1505,10 +1781,12 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 
 	// immediate charging
 	case mode == api.ModeNow:
+		lp.vehiclePrecondition(true)
 		err = lp.fastCharging()
 
 	// minimum or target charging
-	case lp.minSocNotReached() || lp.plannerActive():
+	case budgetAction == "" && (lp.minSocNotReached() || lp.plannerActive()):
+		lp.vehiclePrecondition(true)
 		err = lp.fastCharging()
 		lp.resetPhaseTimer()
 		lp.elapsePVTimer() // let PV mode disable immediately afterwards
@@ -1523,6 +1801,7 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		}
 
 		targetCurrent := lp.pvMaxCurrent(mode, sitePower, batteryBuffered, batteryStart)
+		lp.vehiclePrecondition(targetCurrent > 0)
 
 		var required bool // false
 		if targetCurrent == 0 && lp.vehicleClimateActive() {
@@ -17,7 +17,14 @@ type Controller interface {
 type API interface {
 	// Title returns the defined loadpoint title
 	Title() string
+	// Group returns the loadpoint group
+	Group() string
+	// Priority returns the loadpoint priority
 	Priority() int
+	// SetPriority sets the loadpoint priority
+	SetPriority(int)
+	// EffectivePriority returns the loadpoint priority including vehicle priority boost
+	EffectivePriority() int
 
 	//
 	// status
@@ -25,6 +32,8 @@ type API interface {
 
 	// GetStatus returns the charging status
 	GetStatus() api.ChargeStatus
+	// GetVehicleSoc returns the vehicle soc and if it was estimated
+	GetVehicleSoc() (float64, bool)
 
 	//
 	// settings
@@ -34,6 +43,8 @@ type API interface {
 	GetMode() api.ChargeMode
 	// SetMode sets the charge mode
 	SetMode(api.ChargeMode)
+	// SetBatch applies multiple settings together at the beginning of the next update cycle
+	SetBatch(api.ActionConfig) error
 	// GetMinSoc returns the charge minimum soc
 	GetMinSoc() int
 	// SetMinSoc sets the charge minimum soc
@@ -66,6 +77,18 @@ type API interface {
 	// SetDisableThreshold sets loadpoint disable threshold
 	SetDisableThreshold(threshold float64)
 
+	// GetSchedules returns the charging schedules
+	GetSchedules() []Schedule
+	// SetSchedules replaces the charging schedules
+	SetSchedules([]Schedule) error
+
+	// GetBudget returns the monthly energy budget or nil if not configured
+	GetBudget() *Budget
+	// GetBudgetEnergy returns the monthly energy budget in kWh
+	GetBudgetEnergy() float64
+	// SetBudgetEnergy sets the monthly energy budget in kWh
+	SetBudgetEnergy(float64) error
+
 	// RemoteControl sets remote status demand
 	RemoteControl(string, RemoteDemand)
 
@@ -101,6 +124,13 @@ type API interface {
 	// GetRemainingEnergy is the remaining charge energy in Wh
 	GetRemainingEnergy() float64
 
+	//
+	// sessions
+	//
+
+	// SetSessionNotes sets tags and notes of the current or next charging session
+	SetSessionNotes(tags, notes string)
+
 	//
 	// vehicles
 	//
@@ -0,0 +1,11 @@
+package loadpoint
+
+import "github.com/evcc-io/evcc/api"
+
+// Budget is the monthly charging energy budget of a loadpoint
+type Budget struct {
+	Energy   float64        `json:"energy"`   // monthly energy budget (kWh)
+	Used     float64        `json:"used"`     // energy charged in the current month (kWh)
+	Action   api.ChargeMode `json:"action"`   // off or pv when exceeded
+	Exceeded bool           `json:"exceeded"` // budget exceeded in the current month
+}
@@ -35,6 +35,48 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// EffectivePriority mocks base method.
+func (m *MockAPI) EffectivePriority() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EffectivePriority")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// EffectivePriority indicates an expected call of EffectivePriority.
+func (mr *MockAPIMockRecorder) EffectivePriority() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectivePriority", reflect.TypeOf((*MockAPI)(nil).EffectivePriority))
+}
+
+// GetBudget mocks base method.
+func (m *MockAPI) GetBudget() *Budget {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudget")
+	ret0, _ := ret[0].(*Budget)
+	return ret0
+}
+
+// GetBudget indicates an expected call of GetBudget.
+func (mr *MockAPIMockRecorder) GetBudget() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudget", reflect.TypeOf((*MockAPI)(nil).GetBudget))
+}
+
+// GetBudgetEnergy mocks base method.
+func (m *MockAPI) GetBudgetEnergy() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetEnergy")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetBudgetEnergy indicates an expected call of GetBudgetEnergy.
+func (mr *MockAPIMockRecorder) GetBudgetEnergy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetEnergy", reflect.TypeOf((*MockAPI)(nil).GetBudgetEnergy))
+}
+
 // GetChargePower mocks base method.
 func (m *MockAPI) GetChargePower() float64 {
 	m.ctrl.T.Helper()
@@ -233,6 +275,20 @@ func (mr *MockAPIMockRecorder) GetRemainingEnergy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemainingEnergy", reflect.TypeOf((*MockAPI)(nil).GetRemainingEnergy))
 }
 
+// GetSchedules mocks base method.
+func (m *MockAPI) GetSchedules() []Schedule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules")
+	ret0, _ := ret[0].([]Schedule)
+	return ret0
+}
+
+// GetSchedules indicates an expected call of GetSchedules.
+func (mr *MockAPIMockRecorder) GetSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*MockAPI)(nil).GetSchedules))
+}
+
 // GetStatus mocks base method.
 func (m *MockAPI) GetStatus() api.ChargeStatus {
 	m.ctrl.T.Helper()
@@ -303,6 +359,35 @@ func (mr *MockAPIMockRecorder) GetVehicle() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicle", reflect.TypeOf((*MockAPI)(nil).GetVehicle))
 }
 
+// GetVehicleSoc mocks base method.
+func (m *MockAPI) GetVehicleSoc() (float64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleSoc")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetVehicleSoc indicates an expected call of GetVehicleSoc.
+func (mr *MockAPIMockRecorder) GetVehicleSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleSoc", reflect.TypeOf((*MockAPI)(nil).GetVehicleSoc))
+}
+
+// Group mocks base method.
+func (m *MockAPI) Group() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Group")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Group indicates an expected call of Group.
+func (mr *MockAPIMockRecorder) Group() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Group", reflect.TypeOf((*MockAPI)(nil).Group))
+}
+
 // HasChargeMeter mocks base method.
 func (m *MockAPI) HasChargeMeter() bool {
 	m.ctrl.T.Helper()
@@ -343,6 +428,34 @@ func (mr *MockAPIMockRecorder) RemoteControl(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteControl", reflect.TypeOf((*MockAPI)(nil).RemoteControl), arg0, arg1)
 }
 
+// SetBatch mocks base method.
+func (m *MockAPI) SetBatch(arg0 api.ActionConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBatch indicates an expected call of SetBatch.
+func (mr *MockAPIMockRecorder) SetBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatch", reflect.TypeOf((*MockAPI)(nil).SetBatch), arg0)
+}
+
+// SetBudgetEnergy mocks base method.
+func (m *MockAPI) SetBudgetEnergy(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBudgetEnergy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBudgetEnergy indicates an expected call of SetBudgetEnergy.
+func (mr *MockAPIMockRecorder) SetBudgetEnergy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBudgetEnergy", reflect.TypeOf((*MockAPI)(nil).SetBudgetEnergy), arg0)
+}
+
 // SetDisableThreshold mocks base method.
 func (m *MockAPI) SetDisableThreshold(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -429,6 +542,44 @@ func (mr *MockAPIMockRecorder) SetPhases(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPhases", reflect.TypeOf((*MockAPI)(nil).SetPhases), arg0)
 }
 
+// SetPriority mocks base method.
+func (m *MockAPI) SetPriority(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPriority", arg0)
+}
+
+// SetPriority indicates an expected call of SetPriority.
+func (mr *MockAPIMockRecorder) SetPriority(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriority", reflect.TypeOf((*MockAPI)(nil).SetPriority), arg0)
+}
+
+// SetSchedules mocks base method.
+func (m *MockAPI) SetSchedules(arg0 []Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedules", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSchedules indicates an expected call of SetSchedules.
+func (mr *MockAPIMockRecorder) SetSchedules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedules", reflect.TypeOf((*MockAPI)(nil).SetSchedules), arg0)
+}
+
+// SetSessionNotes mocks base method.
+func (m *MockAPI) SetSessionNotes(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSessionNotes", arg0, arg1)
+}
+
+// SetSessionNotes indicates an expected call of SetSessionNotes.
+func (mr *MockAPIMockRecorder) SetSessionNotes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSessionNotes", reflect.TypeOf((*MockAPI)(nil).SetSessionNotes), arg0, arg1)
+}
+
 // SetTargetEnergy mocks base method.
 func (m *MockAPI) SetTargetEnergy(arg0 float64) {
 	m.ctrl.T.Helper()
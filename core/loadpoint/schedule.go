@@ -0,0 +1,18 @@
+package loadpoint
+
+// ScheduleAction defines if charging is blocked or allowed during a schedule window
+type ScheduleAction string
+
+// schedule action definition
+const (
+	ScheduleBlock ScheduleAction = "block"
+	ScheduleAllow ScheduleAction = "allow"
+)
+
+// Schedule is a recurring time window during which charging is blocked or allowed.
+// If any allow window is defined, charging is only allowed inside allow windows.
+type Schedule struct {
+	Days   string         `json:"days"`   // week days, e.g. Mo-Fr, empty for all days
+	Hours  string         `json:"hours"`  // time ranges, e.g. 17-20 or 22:00-0,0-6
+	Action ScheduleAction `json:"action"` // block (default) or allow
+}
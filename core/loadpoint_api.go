@@ -9,6 +9,9 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/wrapper"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 var _ loadpoint.API = (*Loadpoint)(nil)
@@ -18,11 +21,46 @@ func (lp *Loadpoint) Title() string {
 	return lp.Title_
 }
 
+// Group returns the loadpoint group
+func (lp *Loadpoint) Group() string {
+	return lp.Group_
+}
+
 // Priority returns the loadpoint priority
 func (lp *Loadpoint) Priority() int {
+	lp.Lock()
+	defer lp.Unlock()
 	return lp.Priority_
 }
 
+// SetPriority sets the loadpoint priority
+func (lp *Loadpoint) SetPriority(prio int) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set priority:", prio)
+
+	if lp.Priority_ != prio {
+		lp.Priority_ = prio
+		lp.publish("priority", prio)
+		lp.requestUpdate()
+	}
+}
+
+// EffectivePriority returns the loadpoint priority including vehicle priority boost
+func (lp *Loadpoint) EffectivePriority() int {
+	prio := lp.Priority()
+
+	if v, ok := lp.GetVehicle().(api.VehiclePriorityBoost); ok {
+		boost := v.PriorityBoost()
+		if soc, _ := lp.GetVehicleSoc(); boost.Priority != 0 && soc > 0 && soc < float64(boost.Soc) {
+			prio += boost.Priority
+		}
+	}
+
+	return prio
+}
+
 // GetStatus returns the charging status
 func (lp *Loadpoint) GetStatus() api.ChargeStatus {
 	lp.Lock()
@@ -129,6 +167,13 @@ func (lp *Loadpoint) SetTargetSoc(soc int) {
 	}
 }
 
+// GetVehicleSoc returns the vehicle soc and if it was estimated
+func (lp *Loadpoint) GetVehicleSoc() (float64, bool) {
+	lp.Lock()
+	defer lp.Unlock()
+	return lp.vehicleSoc, lp.vehicleSocEstimated
+}
+
 // GetMinSoc returns loadpoint charge minimum soc
 func (lp *Loadpoint) GetMinSoc() int {
 	lp.Lock()
@@ -142,8 +187,17 @@ func (lp *Loadpoint) setMinSoc(soc int) {
 	lp.publish(minSoc, soc)
 }
 
-// SetMinSoc sets loadpoint charge minimum soc
+// SetMinSoc sets loadpoint charge minimum soc and remembers it for the active vehicle
 func (lp *Loadpoint) SetMinSoc(soc int) {
+	if vehicle := lp.GetVehicle(); vehicle != nil {
+		settings.SetInt(lp.vehicleSettingsKey(vehicle, "minSoc"), int64(soc))
+	}
+
+	lp.applyMinSoc(soc)
+}
+
+// applyMinSoc sets loadpoint charge minimum soc
+func (lp *Loadpoint) applyMinSoc(soc int) {
 	lp.Lock()
 	defer lp.Unlock()
 
@@ -165,13 +219,8 @@ func (lp *Loadpoint) GetPhases() int {
 
 // SetPhases sets loadpoint enabled phases
 func (lp *Loadpoint) SetPhases(phases int) error {
-	// limit auto mode (phases=0) to scalable charger
-	if _, ok := lp.charger.(api.PhaseSwitcher); !ok && phases == 0 {
-		return fmt.Errorf("invalid number of phases: %d", phases)
-	}
-
-	if phases != 0 && phases != 1 && phases != 3 {
-		return fmt.Errorf("invalid number of phases: %d", phases)
+	if err := lp.validatePhases(phases); err != nil {
+		return err
 	}
 
 	// set new default
@@ -188,6 +237,20 @@ func (lp *Loadpoint) SetPhases(phases int) error {
 	return nil
 }
 
+// validatePhases checks if the number of phases is supported by the charger
+func (lp *Loadpoint) validatePhases(phases int) error {
+	// limit auto mode (phases=0) to scalable charger
+	if _, ok := lp.charger.(api.PhaseSwitcher); !ok && phases == 0 {
+		return fmt.Errorf("invalid number of phases: %d", phases)
+	}
+
+	if phases != 0 && phases != 1 && phases != 3 {
+		return fmt.Errorf("invalid number of phases: %d", phases)
+	}
+
+	return nil
+}
+
 // GetTargetTime returns the target time
 func (lp *Loadpoint) GetTargetTime() time.Time {
 	lp.Lock()
@@ -253,12 +316,25 @@ func (lp *Loadpoint) SetDisableThreshold(threshold float64) {
 	}
 }
 
-// RemoteControl sets remote status demand
+// RemoteControl sets remote status demand of the given source.
+// Demands are tracked per source, the most restrictive demand of all sources applies.
 func (lp *Loadpoint) RemoteControl(source string, demand loadpoint.RemoteDemand) {
 	lp.Lock()
 	defer lp.Unlock()
 
-	lp.log.DEBUG.Println("remote demand:", demand)
+	lp.log.DEBUG.Printf("remote demand: %s (%s)", demand, source)
+
+	if lp.remoteDemands == nil {
+		lp.remoteDemands = make(map[string]loadpoint.RemoteDemand)
+	}
+
+	if demand == loadpoint.RemoteEnable {
+		delete(lp.remoteDemands, source)
+	} else {
+		lp.remoteDemands[source] = demand
+	}
+
+	demand, source = lp.effectiveRemoteDemand(source)
 
 	// apply immediately
 	if lp.remoteDemand != demand {
@@ -271,6 +347,22 @@ func (lp *Loadpoint) RemoteControl(source string, demand loadpoint.RemoteDemand)
 	}
 }
 
+// effectiveRemoteDemand returns the most restrictive remote demand and its source
+func (lp *Loadpoint) effectiveRemoteDemand(source string) (loadpoint.RemoteDemand, string) {
+	sources := maps.Keys(lp.remoteDemands)
+	slices.Sort(sources)
+
+	for _, demand := range []loadpoint.RemoteDemand{loadpoint.RemoteHardDisable, loadpoint.RemoteSoftDisable} {
+		for _, s := range sources {
+			if lp.remoteDemands[s] == demand {
+				return demand, s
+			}
+		}
+	}
+
+	return loadpoint.RemoteEnable, source
+}
+
 // HasChargeMeter determines if a physical charge meter is attached
 func (lp *Loadpoint) HasChargeMeter() bool {
 	_, isWrapped := lp.chargeMeter.(*wrapper.ChargeMeter)
@@ -314,6 +406,11 @@ func (lp *Loadpoint) SetMinCurrent(current float64) {
 
 	lp.log.DEBUG.Println("set min current:", current)
 
+	if lp.CircuitCurrent > 0 && current > lp.CircuitCurrent {
+		lp.log.WARN.Printf("min current exceeds circuit limit of %.3gA", lp.CircuitCurrent)
+		current = lp.CircuitCurrent
+	}
+
 	if current != lp.MinCurrent {
 		lp.MinCurrent = current
 		lp.publish(minCurrent, lp.MinCurrent)
@@ -334,6 +431,11 @@ func (lp *Loadpoint) SetMaxCurrent(current float64) {
 
 	lp.log.DEBUG.Println("set max current:", current)
 
+	if lp.CircuitCurrent > 0 && current > lp.CircuitCurrent {
+		lp.log.WARN.Printf("max current exceeds circuit limit of %.3gA", lp.CircuitCurrent)
+		current = lp.CircuitCurrent
+	}
+
 	if current != lp.MaxCurrent {
 		lp.MaxCurrent = current
 		lp.publish(maxCurrent, lp.MaxCurrent)
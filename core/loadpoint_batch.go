@@ -0,0 +1,106 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// SetBatch validates the settings and queues them to be applied together at the beginning of the next update cycle.
+// This avoids evaluating intermediate states when changing multiple settings. Batches queued within the same cycle are merged.
+func (lp *Loadpoint) SetBatch(batch api.ActionConfig) error {
+	if err := lp.validateBatch(batch); err != nil {
+		return err
+	}
+
+	lp.log.DEBUG.Printf("set batch: %v", batch)
+
+	lp.batchMux.Lock()
+	if lp.batch != nil {
+		batch = lp.batch.Merge(batch)
+	}
+	lp.batch = &batch
+	lp.batchMux.Unlock()
+
+	lp.requestUpdate()
+
+	return nil
+}
+
+// validateBatch checks all settings upfront so that the batch is either applied completely or not at all
+func (lp *Loadpoint) validateBatch(batch api.ActionConfig) error {
+	if batch.Mode != nil && *batch.Mode == api.ModeEmpty {
+		return errors.New("invalid charge mode")
+	}
+
+	for name, soc := range map[string]*int{"min soc": batch.MinSoc, "target soc": batch.TargetSoc} {
+		if soc != nil && (*soc < 0 || *soc > 100) {
+			return fmt.Errorf("invalid %s: %d", name, *soc)
+		}
+	}
+
+	if batch.Priority != nil && *batch.Priority < 0 {
+		return fmt.Errorf("invalid priority: %d", *batch.Priority)
+	}
+
+	if batch.Phases != nil {
+		if err := lp.validatePhases(*batch.Phases); err != nil {
+			return err
+		}
+	}
+
+	if batch.MinCurrent == nil && batch.MaxCurrent == nil {
+		return nil
+	}
+
+	minCurrent, maxCurrent := lp.GetMinCurrent(), lp.GetMaxCurrent()
+	if batch.MinCurrent != nil {
+		minCurrent = *batch.MinCurrent
+	}
+	if batch.MaxCurrent != nil {
+		maxCurrent = *batch.MaxCurrent
+	}
+
+	if minCurrent <= 0 || maxCurrent < minCurrent {
+		return fmt.Errorf("invalid current range: %.3gA..%.3gA", minCurrent, maxCurrent)
+	}
+
+	return nil
+}
+
+// applyBatch applies the pending settings
+func (lp *Loadpoint) applyBatch() {
+	lp.batchMux.Lock()
+	batch := lp.batch
+	lp.batch = nil
+	lp.batchMux.Unlock()
+
+	if batch == nil {
+		return
+	}
+
+	if batch.Mode != nil {
+		lp.SetMode(*batch.Mode)
+	}
+	if batch.MinCurrent != nil {
+		lp.SetMinCurrent(*batch.MinCurrent)
+	}
+	if batch.MaxCurrent != nil {
+		lp.SetMaxCurrent(*batch.MaxCurrent)
+	}
+	if batch.Phases != nil {
+		if err := lp.SetPhases(*batch.Phases); err != nil {
+			lp.log.ERROR.Println(err)
+		}
+	}
+	if batch.MinSoc != nil {
+		lp.SetMinSoc(*batch.MinSoc)
+	}
+	if batch.TargetSoc != nil {
+		lp.SetTargetSoc(*batch.TargetSoc)
+	}
+	if batch.Priority != nil {
+		lp.SetPriority(*batch.Priority)
+	}
+}
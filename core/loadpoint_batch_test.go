@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.Mode = api.ModeOff
+	lp.MinCurrent = 6
+	lp.MaxCurrent = 16
+
+	floatPtr := func(v float64) *float64 { return &v }
+	intPtr := func(v int) *int { return &v }
+	mode := func(v api.ChargeMode) *api.ChargeMode { return &v }
+
+	for _, batch := range []api.ActionConfig{
+		{Mode: mode(api.ModeEmpty)},
+		{TargetSoc: intPtr(101)},
+		{MinSoc: intPtr(-1)},
+		{Priority: intPtr(-1)},
+		{Phases: intPtr(2)},
+		{MinCurrent: floatPtr(20)},
+		{MinCurrent: floatPtr(10), MaxCurrent: floatPtr(8)},
+	} {
+		assert.Error(t, lp.SetBatch(batch), batch)
+	}
+
+	require.NoError(t, lp.SetBatch(api.ActionConfig{Mode: mode(api.ModeNow), MaxCurrent: floatPtr(10)}))
+	require.NoError(t, lp.SetBatch(api.ActionConfig{TargetSoc: intPtr(80), MaxCurrent: floatPtr(12)}))
+
+	// not applied before update cycle
+	assert.Equal(t, api.ModeOff, lp.GetMode())
+	assert.Equal(t, 16.0, lp.GetMaxCurrent())
+
+	lp.applyBatch()
+
+	assert.Equal(t, api.ModeNow, lp.GetMode())
+	assert.Equal(t, 12.0, lp.GetMaxCurrent())
+	assert.Equal(t, 80, lp.GetTargetSoc())
+	assert.Equal(t, 6.0, lp.GetMinCurrent())
+	assert.Nil(t, lp.batch)
+}
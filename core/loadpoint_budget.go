@@ -0,0 +1,174 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/db"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+const evBudgetExceeded = "budget" // monthly energy budget exceeded
+
+// budget tracks the monthly charging energy budget
+type budget struct {
+	loadpoint.Budget
+	configured float64     // configured budget, lower limit for runtime changes (kWh)
+	month      time.Time   // start of the current month
+	session    *db.Session // current session, not included in base
+	base       float64     // energy of previous sessions in the current month (kWh)
+}
+
+// budgetOverride is the persisted budget raised at runtime
+type budgetOverride struct {
+	Configured float64 `json:"configured"` // configured budget the override applies to (kWh)
+	Energy     float64 `json:"energy"`     // raised budget (kWh)
+}
+
+// configureBudget enables the monthly energy budget.
+// A budget raised at runtime takes precedence until the configured budget changes.
+func (lp *Loadpoint) configureBudget(energy float64, action api.ChargeMode) {
+	b := &budget{
+		Budget:     loadpoint.Budget{Energy: energy, Action: action},
+		configured: energy,
+	}
+
+	var o budgetOverride
+	if err := settings.Json(lp.settingsKey("budget"), &o); err == nil {
+		if o.Configured == energy && o.Energy > energy {
+			b.Energy = o.Energy
+		} else if err := settings.Delete(lp.settingsKey("budget")); err != nil {
+			lp.log.ERROR.Printf("budget: %v", err)
+		}
+	}
+
+	lp.budget = b
+}
+
+// GetBudget returns the monthly energy budget or nil if not configured
+func (lp *Loadpoint) GetBudget() *loadpoint.Budget {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.budget == nil {
+		return nil
+	}
+
+	res := lp.budget.Budget
+	return &res
+}
+
+// GetBudgetEnergy returns the monthly energy budget in kWh
+func (lp *Loadpoint) GetBudgetEnergy() float64 {
+	if b := lp.GetBudget(); b != nil {
+		return b.Energy
+	}
+	return 0
+}
+
+// SetBudgetEnergy sets the monthly energy budget in kWh
+func (lp *Loadpoint) SetBudgetEnergy(energy float64) error {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.budget == nil {
+		return errors.New("budget not configured")
+	}
+
+	// the configured budget is the contractual minimum
+	if energy < lp.budget.configured {
+		return fmt.Errorf("budget must not be lower than configured %.1fkWh", lp.budget.configured)
+	}
+
+	lp.log.DEBUG.Printf("set budget: %.1fkWh", energy)
+
+	if lp.budget.Energy != energy {
+		lp.budget.Energy = energy
+		lp.budget.Exceeded = lp.budget.Used >= energy
+		lp.publish("budget", lp.budget.Budget)
+
+		if err := settings.SetJson(lp.settingsKey("budget"), budgetOverride{
+			Configured: lp.budget.configured,
+			Energy:     energy,
+		}); err != nil {
+			return err
+		}
+
+		lp.requestUpdate()
+	}
+
+	return nil
+}
+
+// updateBudget updates the energy charged in the current month and notifies when the budget is exceeded
+func (lp *Loadpoint) updateBudget() {
+	if lp.updateBudgetUsed() {
+		lp.pushEvent(evBudgetExceeded)
+	}
+}
+
+// updateBudgetUsed updates the energy charged in the current month and returns true if the budget became exceeded
+func (lp *Loadpoint) updateBudgetUsed() bool {
+	lp.Lock()
+	defer lp.Unlock()
+
+	b := lp.budget
+	if b == nil {
+		return false
+	}
+
+	now := lp.clock.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	// previous sessions only change when the month or session changes
+	if !month.Equal(b.month) || lp.session != b.session {
+		var base float64
+
+		if lp.db != nil {
+			var exclude uint
+			if lp.session != nil {
+				exclude = lp.session.ID
+			}
+
+			var err error
+			if base, err = lp.db.ChargedEnergy(month, exclude); err != nil {
+				lp.log.ERROR.Printf("budget: %v", err)
+				return false
+			}
+		}
+
+		b.month, b.session, b.base = month, lp.session, base
+	}
+
+	b.Used = b.base
+	if lp.session == nil || !lp.session.Created.Before(month) {
+		b.Used += lp.sessionEnergy.TotalWh() / 1e3
+	}
+
+	exceeded := b.Used >= b.Energy
+	notify := exceeded && !b.Exceeded
+
+	if notify {
+		lp.log.WARN.Printf("budget: %.1fkWh of %.1fkWh monthly energy used, charging restricted to %s", b.Used, b.Energy, b.Action)
+	}
+
+	b.Exceeded = exceeded
+	lp.publish("budget", b.Budget)
+
+	return notify
+}
+
+// budgetAction returns the restriction when the monthly energy budget is exceeded
+func (lp *Loadpoint) budgetAction() api.ChargeMode {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.budget == nil || !lp.budget.Exceeded {
+		return ""
+	}
+
+	return lp.budget.Action
+}
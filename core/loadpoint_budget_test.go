@@ -0,0 +1,94 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/db"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type budgetDatabase struct {
+	db.Database
+	from    time.Time
+	charged float64
+}
+
+func (d *budgetDatabase) ChargedEnergy(from time.Time, exclude uint) (float64, error) {
+	d.from = from
+	return d.charged, nil
+}
+
+func TestBudget(t *testing.T) {
+	clck := clock.NewMock()
+	clck.Set(time.Date(2023, 7, 15, 12, 0, 0, 0, time.Local))
+
+	database := &budgetDatabase{charged: 250}
+	pushChan := make(chan push.Event, 10)
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.clock = clck
+	lp.db = database
+	lp.pushChan = pushChan
+	lp.configureBudget(300, api.ModePV)
+
+	lp.sessionEnergy.Update(40)
+	lp.updateBudget()
+	assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.Local), database.from)
+	assert.Equal(t, 290.0, lp.GetBudget().Used)
+	assert.Equal(t, api.ChargeMode(""), lp.budgetAction())
+
+	lp.sessionEnergy.Update(60)
+	lp.updateBudget()
+	assert.True(t, lp.GetBudget().Exceeded)
+	assert.Equal(t, api.ModePV, lp.budgetAction())
+	require.Len(t, pushChan, 1)
+	assert.Equal(t, evBudgetExceeded, (<-pushChan).Event)
+
+	// no repeated notification
+	lp.updateBudget()
+	assert.Len(t, pushChan, 0)
+
+	// raise budget
+	require.NoError(t, lp.SetBudgetEnergy(400))
+	assert.False(t, lp.GetBudget().Exceeded)
+	assert.Error(t, lp.SetBudgetEnergy(0))
+	assert.Error(t, lp.SetBudgetEnergy(250), "below configured budget")
+
+	// raised budget is restored
+	lp.configureBudget(300, api.ModePV)
+	assert.Equal(t, 400.0, lp.GetBudgetEnergy())
+
+	// changed configuration resets raised budget
+	lp.configureBudget(200, api.ModePV)
+	assert.Equal(t, 200.0, lp.GetBudgetEnergy())
+	lp.configureBudget(300, api.ModePV)
+	assert.Equal(t, 300.0, lp.GetBudgetEnergy())
+
+	// new month
+	database.charged = 0
+	lp.sessionEnergy.Reset()
+	clck.Add(20 * 24 * time.Hour)
+	lp.updateBudget()
+	assert.Equal(t, time.Date(2023, 8, 1, 0, 0, 0, 0, time.Local), database.from)
+	assert.Equal(t, 0.0, lp.GetBudget().Used)
+}
+
+func TestBudgetRequiresDatabase(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.Title_ = "Garage"
+
+	site := &Site{
+		loadpoints: []*Loadpoint{lp},
+		Budgets:    []BudgetConfig{{Loadpoint: "Garage", Energy: 300}},
+	}
+	assert.Error(t, site.configureBudgets())
+
+	lp.db = &budgetDatabase{}
+	assert.NoError(t, site.configureBudgets())
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+// calibrationDue checks if the vehicle's recurring calibration charge is due
+func (lp *Loadpoint) calibrationDue(vehicle api.Vehicle) bool {
+	v, ok := vehicle.(api.VehicleCalibration)
+	if !ok || v.CalibrationInterval() <= 0 {
+		return false
+	}
+
+	// time of the last completed calibration charge
+	last, err := settings.Time(lp.vehicleSettingsKey(vehicle, "calibrated"))
+	if err != nil {
+		// start counting from first use instead of calibrating immediately
+		settings.SetTime(lp.vehicleSettingsKey(vehicle, "calibrated"), lp.clock.Now())
+		return false
+	}
+
+	return lp.clock.Since(last) >= v.CalibrationInterval()
+}
+
+// startCalibration overrides the target soc to 100% for the current session if calibration is due
+func (lp *Loadpoint) startCalibration(vehicle api.Vehicle) {
+	if !lp.calibrationDue(vehicle) {
+		return
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.calibrationTargetSoc != 0 {
+		return
+	}
+
+	lp.log.INFO.Printf("vehicle calibration due, charging to 100%%")
+
+	lp.calibrationTargetSoc = lp.Soc.target
+	lp.setTargetSoc(100)
+	lp.publish("vehicleCalibration", true)
+}
+
+// stopCalibration reverts the calibration target soc override (no mutex)
+func (lp *Loadpoint) stopCalibration() {
+	if lp.calibrationTargetSoc == 0 {
+		return
+	}
+
+	lp.setTargetSoc(lp.calibrationTargetSoc)
+	lp.calibrationTargetSoc = 0
+	lp.publish("vehicleCalibration", false)
+}
+
+// vehicleCalibration completes an active calibration charge once the vehicle is full
+func (lp *Loadpoint) vehicleCalibration() {
+	if lp.calibrationTargetSoc == 0 || lp.vehicle == nil || lp.vehicleSoc < 100 {
+		return
+	}
+
+	lp.log.INFO.Println("vehicle calibration completed")
+
+	settings.SetTime(lp.vehicleSettingsKey(lp.vehicle, "calibrated"), lp.clock.Now())
+
+	lp.Lock()
+	lp.stopCalibration()
+	lp.Unlock()
+
+	lp.pushEvent(evVehicleCalibrated)
+}
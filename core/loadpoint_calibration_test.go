@@ -0,0 +1,92 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type calibrationVehicle struct {
+	*mock.MockVehicle
+	interval time.Duration
+}
+
+func (v *calibrationVehicle) CalibrationInterval() time.Duration {
+	return v.interval
+}
+
+func TestVehicleCalibration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	vehicle := &calibrationVehicle{mock.NewMockVehicle(ctrl), 30 * 24 * time.Hour}
+	vehicle.MockVehicle.EXPECT().Title().Return("calibration").AnyTimes()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		Soc: SocConfig{
+			target: 80,
+		},
+		vehicle: vehicle,
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// first use starts the interval
+	lp.startCalibration(vehicle)
+	assert.Equal(t, 80, lp.Soc.target)
+
+	// due after interval
+	clck.Add(vehicle.interval)
+	lp.startCalibration(vehicle)
+	assert.Equal(t, 100, lp.Soc.target)
+
+	// not yet full
+	lp.vehicleSoc = 95
+	lp.vehicleCalibration()
+	assert.Equal(t, 100, lp.Soc.target)
+
+	// completed and reverted
+	lp.vehicleSoc = 100
+	lp.vehicleCalibration()
+	assert.Equal(t, 80, lp.Soc.target)
+	assert.False(t, lp.calibrationDue(vehicle))
+}
+
+func TestVehicleCalibrationRearm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	vehicle := &calibrationVehicle{mock.NewMockVehicle(ctrl), 30 * 24 * time.Hour}
+	vehicle.MockVehicle.EXPECT().Title().Return("rearm").AnyTimes()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		Soc: SocConfig{
+			target: 80,
+		},
+		vehicle:        vehicle,
+		defaultVehicle: vehicle,
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// first use starts the interval
+	lp.startCalibration(vehicle)
+	clck.Add(vehicle.interval)
+
+	// default vehicle remains active after disconnect, connect must re-arm calibration
+	lp.vehicleDefaultOrDetect()
+	assert.Equal(t, 100, lp.Soc.target)
+}
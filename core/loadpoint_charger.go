@@ -1,6 +1,8 @@
 package core
 
 import (
+	"errors"
+
 	"github.com/evcc-io/evcc/api"
 	"golang.org/x/exp/slices"
 )
@@ -30,3 +32,57 @@ func (lp *Loadpoint) chargerSoc() (float64, error) {
 	}
 	return 0, api.ErrNotAvailable
 }
+
+// dryRunCommand logs the command in dry run mode and returns true if it must not be executed
+func (lp *Loadpoint) dryRunCommand(format string, v ...any) bool {
+	if lp.dryRun {
+		lp.log.INFO.Printf("dry run: "+format, v...)
+	}
+	return lp.dryRun
+}
+
+// chargerMaxCurrent applies the charge current limit using the charger's most precise interface
+func (lp *Loadpoint) chargerMaxCurrent(chargeCurrent float64) error {
+	if lp.dryRunCommand("max charge current %.3gA", chargeCurrent) {
+		return nil
+	}
+
+	if charger, ok := lp.charger.(api.PowerLimiter); ok {
+		// power-controlled chargers, e.g. DC
+		power := chargeCurrent * Voltage * float64(lp.activePhases())
+		lp.log.DEBUG.Printf("max charge power: %.0fW", power)
+		return charger.MaxPower(power)
+	}
+
+	if charger, ok := lp.charger.(api.ChargerEx); ok {
+		return charger.MaxCurrentMillis(chargeCurrent)
+	}
+
+	return lp.charger.MaxCurrent(int64(chargeCurrent))
+}
+
+// chargerEnable enables or disables the charger. If the charger does not support
+// switching, the vehicle charge controller is used as fallback if available.
+func (lp *Loadpoint) chargerEnable(enable bool) error {
+	if lp.dryRunCommand("charger %s", status[enable]) {
+		return nil
+	}
+
+	err := lp.charger.Enable(enable)
+	if !errors.Is(err, api.ErrNotAvailable) {
+		return err
+	}
+
+	vc, ok := lp.GetVehicle().(api.VehicleChargeController)
+	if !ok {
+		return err
+	}
+
+	if enable {
+		lp.log.DEBUG.Println("vehicle charge start")
+		return vc.StartCharge()
+	}
+
+	lp.log.DEBUG.Println("vehicle charge stop")
+	return vc.StopCharge()
+}
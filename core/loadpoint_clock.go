@@ -0,0 +1,24 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/db"
+)
+
+// clockJumped corrects the session start time taken before the system clock jumped by offset and re-evaluates the charging plan.
+// Durations measured in-process use the monotonic clock and are not affected.
+func (lp *Loadpoint) clockJumped(offset time.Duration) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	// session timestamps are persisted as wall clock time
+	lp.updateSession(func(session *db.Session) {
+		if !session.Created.IsZero() {
+			session.Created = session.Created.Round(0).Add(offset)
+		}
+	})
+
+	// plan slots refer to the previous time
+	lp.setPlanActive(false)
+}
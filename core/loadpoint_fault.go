@@ -0,0 +1,66 @@
+package core
+
+import "github.com/evcc-io/evcc/api"
+
+// updateChargerFault reads the charger fault state and notifies when a new fault occurs
+func (lp *Loadpoint) updateChargerFault() {
+	cf, ok := lp.charger.(api.ChargerFault)
+	if !ok {
+		return
+	}
+
+	code, msg, err := cf.Fault()
+	if err != nil {
+		lp.log.ERROR.Printf("charger fault: %v", err)
+		return
+	}
+
+	if code == lp.faultCode {
+		return
+	}
+
+	lp.faultCode = code
+	lp.publish("chargerFaultCode", code)
+	lp.publish("chargerFault", msg)
+
+	if code == 0 {
+		lp.log.INFO.Println("charger fault cleared")
+		return
+	}
+
+	lp.log.ERROR.Printf("charger fault %d: %s, disabling charging", code, msg)
+	lp.pushEvent(evChargerFault)
+}
+
+// chargerFaulted returns true while the charger reports a fault.
+// The loadpoint stays disabled until the fault is cleared.
+func (lp *Loadpoint) chargerFaulted() bool {
+	return lp.faultCode != 0
+}
+
+// resetVehicleCable forgets the vehicle's cable state on disconnect.
+// Plug faults are only detected from vehicle readings taken after the disconnect.
+func (lp *Loadpoint) resetVehicleCable() {
+	if lp.vehicleCableLocked {
+		lp.vehicleCableLocked = false
+		lp.publish(vehicleCableLocked, false)
+	}
+}
+
+// updatePlugFault detects a vehicle reporting a locked charge cable while the charger is disconnected,
+// e.g. a faulty cable or charge port, as opposed to a vehicle that is not plugged in
+func (lp *Loadpoint) updatePlugFault() {
+	fault := lp.vehicleCableLocked && !lp.connected()
+	if fault == lp.plugFault {
+		return
+	}
+
+	lp.plugFault = fault
+	lp.publish("plugFault", fault)
+
+	if fault {
+		lp.log.WARN.Println("vehicle reports locked cable but charger is disconnected, check cable and charge port")
+	} else {
+		lp.log.INFO.Println("plug fault cleared")
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"testing"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type faultCharger struct {
+	api.Charger
+	code int64
+	msg  string
+}
+
+func (c *faultCharger) Fault() (int64, string, error) {
+	return c.code, c.msg, nil
+}
+
+func TestChargerFault(t *testing.T) {
+	charger := &faultCharger{code: 13, msg: "overtemperature"}
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.charger = charger
+
+	uiChan := make(chan util.Param, 10)
+	pushChan := make(chan push.Event, 10)
+	lp.uiChan = uiChan
+	lp.pushChan = pushChan
+
+	lp.updateChargerFault()
+	assert.Equal(t, int64(13), lp.faultCode)
+	require.Len(t, pushChan, 1)
+	assert.Equal(t, evChargerFault, (<-pushChan).Event)
+
+	// no repeated notification
+	lp.updateChargerFault()
+	assert.Len(t, pushChan, 0)
+
+	charger.code, charger.msg = 0, ""
+	lp.updateChargerFault()
+	assert.Equal(t, int64(0), lp.faultCode)
+	assert.Len(t, pushChan, 0)
+}
+
+func TestChargerFaultDisables(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clock.NewMock(),
+		charger:       &faultCharger{Charger: charger, code: 13, msg: "overtemperature"},
+		chargeMeter:   &Null{}, // silence nil panics
+		chargeRater:   &Null{}, // silence nil panics
+		chargeTimer:   &Null{}, // silence nil panics
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		MinCurrent:    minA,
+		MaxCurrent:    maxA,
+		Mode:          api.ModeNow,
+		status:        api.StatusC,
+		enabled:       true,
+		chargeCurrent: maxA,
+	}
+
+	charger.EXPECT().Enabled().Return(true, nil).AnyTimes()
+	charger.EXPECT().MaxCurrent(int64(minA)).Return(nil)
+	attachListeners(t, lp)
+
+	charger.EXPECT().Status().Return(api.StatusC, nil)
+	charger.EXPECT().Enable(false).Return(nil)
+
+	lp.Update(0, false, false, false, 0, nil, nil)
+
+	assert.True(t, lp.chargerFaulted())
+	assert.False(t, lp.enabled)
+}
+
+type cableVehicle struct {
+	api.Vehicle
+	locked bool
+}
+
+func (v *cableVehicle) CableLocked() (bool, error) {
+	return v.locked, nil
+}
+
+func TestPlugFault(t *testing.T) {
+	vehicle := &cableVehicle{locked: true}
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.vehicle = vehicle
+	lp.status = api.StatusB
+
+	lp.updateVehicleChargePort()
+	lp.updatePlugFault()
+	assert.False(t, lp.plugFault)
+
+	// disconnected, cable state read while connected is outdated
+	lp.status = api.StatusA
+	lp.resetVehicleCable()
+	lp.updatePlugFault()
+	assert.False(t, lp.plugFault)
+
+	// cable locked but charger disconnected
+	lp.updateVehicleChargePort()
+	lp.updatePlugFault()
+	assert.True(t, lp.plugFault)
+
+	// not plugged
+	vehicle.locked = false
+	lp.updateVehicleChargePort()
+	lp.updatePlugFault()
+	assert.False(t, lp.plugFault)
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	earthRadius        = 6371e3          // m
+	vehiclePositionAge = 5 * time.Minute // maximum age of the vehicle position for blocking charging
+)
+
+// GeofenceConfig restricts charging to vehicles positioned within radius of the loadpoint
+type GeofenceConfig struct {
+	Latitude, Longitude float64
+	Radius              float64 // m
+}
+
+func (c GeofenceConfig) enabled() bool {
+	return c.Radius > 0
+}
+
+// distance returns the great-circle distance in m between two positions
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := rad(lat2 - lat1)
+	dLon := rad(lon2 - lon1)
+
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Pow(math.Sin(dLon/2), 2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// setVehicleAway sets and publishes the vehicle geofence status
+func (lp *Loadpoint) setVehicleAway(away bool) {
+	if lp.vehicleAway != away {
+		lp.vehicleAway = away
+		lp.publish("vehicleAway", away)
+	}
+}
+
+// vehiclePosition updates the vehicle geofence status
+func (lp *Loadpoint) vehiclePosition() {
+	vp, ok := lp.GetVehicle().(api.VehiclePosition)
+	if !ok {
+		return
+	}
+
+	lat, lon, err := vp.Position()
+	if err != nil {
+		if !errors.Is(err, api.ErrNotAvailable) {
+			lp.log.ERROR.Printf("vehicle position: %v", err)
+		}
+		return
+	}
+
+	d := distance(lp.Geofence.Latitude, lp.Geofence.Longitude, lat, lon)
+	lp.log.DEBUG.Printf("vehicle position: %.0fm from loadpoint", d)
+
+	lp.vehiclePositionUpdated = lp.clock.Now()
+	lp.setVehicleAway(d > lp.Geofence.Radius)
+}
+
+// vehicleOutsideGeofence returns true if the vehicle is positioned outside the geofence.
+// An outdated position is refreshed before blocking charging and expires if it can't be refreshed.
+func (lp *Loadpoint) vehicleOutsideGeofence() bool {
+	if !lp.vehicleAway {
+		return false
+	}
+
+	if lp.clock.Since(lp.vehiclePositionUpdated) > vehiclePositionAge {
+		lp.vehiclePosition()
+	}
+
+	if lp.clock.Since(lp.vehiclePositionUpdated) > vehiclePositionAge {
+		lp.log.DEBUG.Println("vehicle position: expired")
+		lp.setVehicleAway(false)
+	}
+
+	return lp.vehicleAway
+}
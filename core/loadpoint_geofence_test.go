@@ -0,0 +1,62 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeofenceDistance(t *testing.T) {
+	assert.Equal(t, 0.0, distance(52.52, 13.405, 52.52, 13.405))
+
+	// Berlin - Munich
+	assert.InDelta(t, 504e3, distance(52.52, 13.405, 48.137, 11.575), 2e3)
+
+	// 1/1000 degree latitude
+	assert.InDelta(t, 111.2, distance(52.52, 13.405, 52.521, 13.405), 0.5)
+}
+
+type positionVehicle struct {
+	api.Vehicle
+	lat, lon float64
+	err      error
+}
+
+func (v *positionVehicle) Position() (float64, float64, error) {
+	return v.lat, v.lon, v.err
+}
+
+func TestGeofenceExpiry(t *testing.T) {
+	clck := clock.NewMock()
+	vehicle := &positionVehicle{lat: 48.137, lon: 11.575}
+
+	lp := &Loadpoint{
+		log:      util.NewLogger("foo"),
+		clock:    clck,
+		vehicle:  vehicle,
+		Geofence: GeofenceConfig{Latitude: 52.52, Longitude: 13.405, Radius: 500},
+	}
+
+	lp.vehiclePosition()
+	assert.True(t, lp.vehicleOutsideGeofence(), "away")
+
+	// vehicle returned, position refreshed once outdated
+	vehicle.lat, vehicle.lon = 52.52, 13.405
+	assert.True(t, lp.vehicleOutsideGeofence(), "position still current")
+
+	clck.Add(vehiclePositionAge + time.Second)
+	assert.False(t, lp.vehicleOutsideGeofence(), "position refreshed")
+
+	// outdated position which can't be refreshed expires
+	vehicle.lat, vehicle.lon = 48.137, 11.575
+	lp.vehiclePosition()
+
+	vehicle.err = errors.New("offline")
+	clck.Add(vehiclePositionAge + time.Second)
+	assert.False(t, lp.vehicleOutsideGeofence(), "position expired")
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// hookTimeout aborts hook commands running too long
+const hookTimeout = time.Minute
+
+// hookEvents are the loadpoint events hook commands can be configured for
+var hookEvents = []string{
+	evChargeStart, evChargeStop, evVehicleConnect, evVehicleDisconnect,
+	evVehicleIdentified, evVehicleUnidentified, evVehicleTargetSoc, evChargerFault,
+}
+
+// configureHooks validates the hook commands
+func (lp *Loadpoint) configureHooks() error {
+	for event, cmd := range lp.Hooks {
+		if !slices.Contains(hookEvents, event) {
+			return fmt.Errorf("invalid hook event: %s, expected one of %s", event, strings.Join(hookEvents, ", "))
+		}
+
+		if args, err := shellquote.Split(cmd); err != nil || len(args) == 0 {
+			return fmt.Errorf("invalid hook command for %s: %s", event, cmd)
+		}
+	}
+
+	if len(lp.Hooks) > 0 {
+		events := maps.Keys(lp.Hooks)
+		slices.Sort(events)
+		lp.log.DEBUG.Printf("hooks: %s", strings.Join(events, ", "))
+	}
+
+	return nil
+}
+
+// hookEnv provides the loadpoint state to hook commands
+func (lp *Loadpoint) hookEnv(event string) []string {
+	env := map[string]any{
+		"EVENT":           event,
+		"LOADPOINT":       lp.Title(),
+		"MODE":            lp.Mode,
+		"CONNECTED":       lp.connected(),
+		"CHARGING":        lp.charging(),
+		"CHARGE_POWER":    fmt.Sprintf("%.0f", lp.chargePower),
+		"CHARGED_ENERGY":  fmt.Sprintf("%.0f", lp.sessionEnergy.TotalWh()),
+		"CHARGE_DURATION": int64(lp.chargeDuration.Seconds()),
+		"FAULT_CODE":      lp.faultCode,
+	}
+
+	if vehicle := lp.GetVehicle(); vehicle != nil {
+		env["VEHICLE"] = vehicle.Title()
+		env["VEHICLE_SOC"] = fmt.Sprintf("%.0f", lp.vehicleSoc)
+	}
+
+	res := os.Environ()
+	for k, v := range env {
+		res = append(res, fmt.Sprintf("EVCC_%s=%v", k, v))
+	}
+
+	return res
+}
+
+// runHook executes the hook command configured for the event in the background
+func (lp *Loadpoint) runHook(event string) {
+	cmd, ok := lp.Hooks[event]
+	if !ok || lp.dryRunCommand("hook %s: %s", event, cmd) {
+		return
+	}
+
+	// validated during configuration
+	args, _ := shellquote.Split(cmd)
+	env := lp.hookEnv(event)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+
+		c := exec.CommandContext(ctx, args[0], args[1:]...)
+		c.Env = env
+
+		b, err := c.CombinedOutput()
+		out := strings.TrimSpace(string(b))
+
+		if err != nil {
+			lp.log.ERROR.Printf("hook %s: %v: %s", event, err, out)
+			return
+		}
+
+		lp.log.DEBUG.Printf("hook %s: %s", event, out)
+	}()
+}
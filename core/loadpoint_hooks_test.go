@@ -0,0 +1,49 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+
+	lp.Hooks = map[string]string{"foo": "true"}
+	assert.Error(t, lp.configureHooks(), "invalid event")
+
+	lp.Hooks = map[string]string{evChargeStart: `"unterminated`}
+	assert.Error(t, lp.configureHooks(), "invalid command")
+
+	out := filepath.Join(t.TempDir(), "out")
+
+	lp.Title_ = "Garage"
+	lp.status = api.StatusC
+	lp.chargePower = 11000
+	lp.Hooks = map[string]string{evChargeStart: `sh -c 'echo "$EVCC_EVENT $EVCC_LOADPOINT $EVCC_CHARGING $EVCC_CHARGE_POWER" > ` + out + `'`}
+	require.NoError(t, lp.configureHooks())
+
+	// no hook configured
+	lp.runHook(evChargeStop)
+
+	lp.runHook(evChargeStart)
+
+	assert.Eventually(t, func() bool {
+		b, err := os.ReadFile(out)
+		return err == nil && string(b) == "start Garage true 11000\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// dry run
+	require.NoError(t, os.Remove(out))
+	lp.dryRun = true
+	lp.runHook(evChargeStart)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.NoFileExists(t, out)
+}
@@ -2,10 +2,25 @@ package core
 
 import (
 	"math"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 )
 
+// PhaseSwitchConfig defines automatic 1p3p switching in PV mode
+type PhaseSwitchConfig struct {
+	Delay      time.Duration `mapstructure:"delay"`      // switch timer, defaults to enable/disable delay
+	Hysteresis float64       `mapstructure:"hysteresis"` // additional surplus required for switching up (W)
+}
+
+// phaseSwitchDelay returns the phase switch timer duration falling back to the given threshold delay
+func (lp *Loadpoint) phaseSwitchDelay(threshold ThresholdConfig) time.Duration {
+	if lp.PhaseSwitch.Delay > 0 {
+		return lp.PhaseSwitch.Delay
+	}
+	return threshold.Delay
+}
+
 // setConfiguredPhases sets the default phase configuration
 func (lp *Loadpoint) setConfiguredPhases(phases int) {
 	lp.Lock()
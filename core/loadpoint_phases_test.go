@@ -11,6 +11,7 @@ import (
 	"github.com/evcc-io/evcc/mock"
 	"github.com/evcc-io/evcc/util"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 type testCase struct {
@@ -427,3 +428,50 @@ func TestScalePhasesIfAvailable(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestPvScalePhasesSwitchConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := &struct {
+		*mock.MockCharger
+		*mock.MockPhaseSwitcher
+	}{
+		mock.NewMockCharger(ctrl),
+		mock.NewMockPhaseSwitcher(ctrl),
+	}
+
+	Voltage = 230 // V
+
+	clock := clock.NewMock()
+	clock.Add(time.Hour) // avoid time.IsZero
+
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		clock:          clock,
+		charger:        charger,
+		MinCurrent:     minA,
+		MaxCurrent:     maxA,
+		phases:         1,
+		measuredPhases: 1,
+		Enable:         ThresholdConfig{Delay: time.Minute},
+		Disable:        ThresholdConfig{Delay: time.Minute},
+		PhaseSwitch:    PhaseSwitchConfig{Delay: 5 * time.Minute, Hysteresis: 500},
+	}
+
+	// hysteresis not exceeded
+	assert.False(t, lp.pvScalePhases(3*Voltage*minA, minA, maxA))
+	assert.True(t, lp.phaseTimer.IsZero())
+
+	// kickoff
+	assert.False(t, lp.pvScalePhases(3*Voltage*minA+500, minA, maxA))
+	assert.False(t, lp.phaseTimer.IsZero())
+
+	// enable delay elapsed, switch delay not
+	clock.Add(time.Minute)
+	assert.False(t, lp.pvScalePhases(3*Voltage*minA+500, minA, maxA))
+
+	// switch delay elapsed
+	clock.Add(4 * time.Minute)
+	charger.MockPhaseSwitcher.EXPECT().Phases1p3p(3).Return(nil)
+	assert.True(t, lp.pvScalePhases(3*Voltage*minA+500, minA, maxA))
+	assert.Equal(t, 3, lp.phases)
+}
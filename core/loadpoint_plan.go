@@ -32,12 +32,16 @@ func (lp *Loadpoint) planRequiredDuration(maxPower float64) time.Duration {
 		return 0
 	}
 
-	// TODO vehicle soc limit
 	targetSoc := lp.Soc.target
 	if targetSoc == 0 {
 		targetSoc = 100
 	}
 
+	// vehicle will stop charging at its own soc limit
+	if limit := int(lp.vehicleSocLimit); limit > 0 && limit < targetSoc {
+		targetSoc = limit
+	}
+
 	return lp.socEstimator.RemainingChargeDuration(targetSoc, maxPower)
 }
 
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanRequiredDurationVehicleLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Capacity().Return(float64(50)).AnyTimes()
+
+	estimator := soc.NewEstimator(util.NewLogger("foo"), nil, vehicle, false)
+	estimator.Restore(20, 0)
+
+	lp := &Loadpoint{
+		sessionEnergy: NewEnergyMetrics(),
+		socEstimator:  estimator,
+	}
+	lp.Soc.target = 100
+
+	const power = 11000
+
+	// loadpoint target
+	assert.Equal(t, estimator.RemainingChargeDuration(100, power), lp.planRequiredDuration(power))
+
+	// lower vehicle limit
+	lp.vehicleSocLimit = 80
+	assert.Equal(t, estimator.RemainingChargeDuration(80, power), lp.planRequiredDuration(power))
+
+	// higher vehicle limit
+	lp.Soc.target = 60
+	assert.Equal(t, estimator.RemainingChargeDuration(60, power), lp.planRequiredDuration(power))
+}
@@ -0,0 +1,21 @@
+package core
+
+import "github.com/evcc-io/evcc/api"
+
+// setProfile sets the site mode profile which takes precedence over disconnect and vehicle defaults
+func (lp *Loadpoint) setProfile(profile *api.ActionConfig) {
+	lp.Lock()
+	defer lp.Unlock()
+	lp.profile = profile
+}
+
+// applyProfile applies the active site mode profile
+func (lp *Loadpoint) applyProfile() {
+	lp.Lock()
+	profile := lp.profile
+	lp.Unlock()
+
+	if profile != nil {
+		lp.applyAction(*profile)
+	}
+}
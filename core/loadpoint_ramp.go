@@ -0,0 +1,47 @@
+package core
+
+import (
+	"math"
+)
+
+// rampLimit limits the charge current change to the configured ramp rate.
+// Charging is soft-started at min current and ramped down to min current before being disabled.
+func (lp *Loadpoint) rampLimit(chargeCurrent float64) float64 {
+	if lp.RampRate <= 0 {
+		return chargeCurrent
+	}
+
+	now := lp.clock.Now()
+	defer func() { lp.rampUpdated = now }()
+
+	minCurrent := lp.GetMinCurrent()
+
+	// soft start
+	if !lp.enabled {
+		return math.Min(chargeCurrent, minCurrent)
+	}
+
+	if lp.rampUpdated.IsZero() {
+		return chargeCurrent
+	}
+
+	step := lp.RampRate * now.Sub(lp.rampUpdated).Seconds()
+
+	// soft stop
+	if chargeCurrent < minCurrent {
+		if lp.chargeCurrent > minCurrent {
+			current := math.Max(minCurrent, lp.chargeCurrent-step)
+			lp.log.DEBUG.Printf("ramp limit: %.3gA before disabling", current)
+			return current
+		}
+
+		return chargeCurrent
+	}
+
+	if current := math.Min(chargeCurrent, lp.chargeCurrent+step); current < chargeCurrent {
+		lp.log.DEBUG.Printf("ramp limit: %.3gA", current)
+		return current
+	}
+
+	return math.Max(chargeCurrent, lp.chargeCurrent-step)
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRampLimit(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.clock = clck
+
+	// disabled
+	assert.Equal(t, 16.0, lp.rampLimit(16))
+
+	lp.RampRate = 0.1 // A/s
+
+	// soft start
+	assert.Equal(t, 6.0, lp.rampLimit(16))
+	assert.Equal(t, 0.0, lp.rampLimit(0))
+
+	lp.enabled = true
+	lp.chargeCurrent = 6
+
+	// ramp up
+	clck.Add(30 * time.Second)
+	assert.Equal(t, 9.0, lp.rampLimit(16))
+	lp.chargeCurrent = 9
+
+	clck.Add(30 * time.Second)
+	assert.Equal(t, 10.0, lp.rampLimit(10))
+	lp.chargeCurrent = 10
+
+	// ramp down
+	clck.Add(10 * time.Second)
+	assert.Equal(t, 9.0, lp.rampLimit(6))
+	lp.chargeCurrent = 9
+
+	// soft stop
+	clck.Add(20 * time.Second)
+	assert.Equal(t, 7.0, lp.rampLimit(0))
+	lp.chargeCurrent = 7
+
+	clck.Add(20 * time.Second)
+	assert.Equal(t, 6.0, lp.rampLimit(0))
+	lp.chargeCurrent = 6
+
+	clck.Add(20 * time.Second)
+	assert.Equal(t, 0.0, lp.rampLimit(0))
+}
+
+func TestRampHardLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		bus:            evbus.New(),
+		clock:          clck,
+		charger:        charger,
+		enabled:        true,
+		MinCurrent:     minA,
+		MaxCurrent:     maxA,
+		RampRate:       0.1,
+		chargeCurrent:  16,
+		rampUpdated:    clck.Now(),
+		CircuitCurrent: 10,
+		phases:         3,
+	}
+
+	// circuit limit is not ramped down
+	clck.Add(10 * time.Second)
+	charger.EXPECT().MaxCurrent(int64(10)).Return(nil)
+	assert.NoError(t, lp.setLimit(16, false))
+}
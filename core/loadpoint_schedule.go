@@ -0,0 +1,149 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff/fixed"
+	"golang.org/x/exp/slices"
+)
+
+// schedule is a parsed charging schedule window
+type schedule struct {
+	loadpoint.Schedule
+	days  []fixed.Day
+	hours []fixed.TimeRange
+}
+
+// parseSchedules validates and parses the schedule windows
+func parseSchedules(ss []loadpoint.Schedule) ([]schedule, error) {
+	res := make([]schedule, 0, len(ss))
+
+	for i, s := range ss {
+		switch s.Action = loadpoint.ScheduleAction(strings.ToLower(string(s.Action))); s.Action {
+		case "":
+			s.Action = loadpoint.ScheduleBlock
+		case loadpoint.ScheduleBlock, loadpoint.ScheduleAllow:
+		default:
+			return nil, fmt.Errorf("schedule %d: invalid action: %s", i+1, s.Action)
+		}
+
+		days, err := fixed.ParseDays(s.Days)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %d: %w", i+1, err)
+		}
+
+		var hours []fixed.TimeRange
+		if s.Hours != "" {
+			if hours, err = fixed.ParseTimeRanges(s.Hours); err != nil {
+				return nil, fmt.Errorf("schedule %d: %w", i+1, err)
+			}
+		}
+
+		res = append(res, schedule{Schedule: s, days: days, hours: hours})
+	}
+
+	return res, nil
+}
+
+// active returns true if the schedule window contains the given time
+func (s schedule) active(t time.Time) bool {
+	if !slices.Contains(s.days, fixed.Day(t.Weekday())) {
+		return false
+	}
+
+	if len(s.hours) == 0 {
+		return true
+	}
+
+	hm := fixed.HourMin{Hour: t.Hour(), Min: t.Minute()}
+	return slices.ContainsFunc(s.hours, func(tr fixed.TimeRange) bool {
+		return tr.Contains(hm)
+	})
+}
+
+// configureSchedules parses the configured schedules, schedules changed at runtime take precedence
+func (lp *Loadpoint) configureSchedules() error {
+	var persisted []loadpoint.Schedule
+	if err := settings.Json(lp.settingsKey("schedules"), &persisted); err == nil {
+		lp.Schedules = persisted
+	}
+
+	res, err := parseSchedules(lp.Schedules)
+	if err != nil {
+		return err
+	}
+
+	lp.schedules = res
+
+	return nil
+}
+
+// GetSchedules returns the charging schedules
+func (lp *Loadpoint) GetSchedules() []loadpoint.Schedule {
+	lp.Lock()
+	defer lp.Unlock()
+	return lp.getSchedules()
+}
+
+// SetSchedules replaces the charging schedules
+func (lp *Loadpoint) SetSchedules(ss []loadpoint.Schedule) error {
+	res, err := parseSchedules(ss)
+	if err != nil {
+		return err
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Printf("set schedules: %v", ss)
+
+	lp.schedules = res
+	lp.publishSchedules()
+
+	if err := settings.SetJson(lp.settingsKey("schedules"), lp.getSchedules()); err != nil {
+		return err
+	}
+
+	lp.requestUpdate()
+
+	return nil
+}
+
+// getSchedules returns the charging schedules (no mutex)
+func (lp *Loadpoint) getSchedules() []loadpoint.Schedule {
+	res := make([]loadpoint.Schedule, 0, len(lp.schedules))
+	for _, s := range lp.schedules {
+		res = append(res, s.Schedule)
+	}
+	return res
+}
+
+// publishSchedules publishes the charging schedules (no mutex)
+func (lp *Loadpoint) publishSchedules() {
+	lp.publish("schedules", lp.getSchedules())
+}
+
+// scheduleBlocked returns true if charging is blocked by the schedules at the given time
+func (lp *Loadpoint) scheduleBlocked(t time.Time) bool {
+	lp.Lock()
+	defer lp.Unlock()
+
+	var allow, allowed bool
+
+	for _, s := range lp.schedules {
+		active := s.active(t)
+
+		if s.Action == loadpoint.ScheduleAllow {
+			allow = true
+			allowed = allowed || active
+		} else if active {
+			return true
+		}
+	}
+
+	return allow && !allowed
+}
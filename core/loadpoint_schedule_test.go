@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleBlocked(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+
+	monday := time.Date(2023, 7, 3, 0, 0, 0, 0, time.Local)
+
+	// peak tariff on working days
+	var err error
+	lp.schedules, err = parseSchedules([]loadpoint.Schedule{
+		{Days: "Mo-Fr", Hours: "17-20"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, loadpoint.ScheduleBlock, lp.schedules[0].Action)
+
+	assert.False(t, lp.scheduleBlocked(monday.Add(16*time.Hour)))
+	assert.True(t, lp.scheduleBlocked(monday.Add(17*time.Hour)))
+	assert.False(t, lp.scheduleBlocked(monday.Add(20*time.Hour)))
+	assert.False(t, lp.scheduleBlocked(monday.Add(5*24*time.Hour+18*time.Hour))) // saturday
+
+	// cheap night window
+	lp.schedules, err = parseSchedules([]loadpoint.Schedule{
+		{Hours: "22-0,0-6", Action: "allow"},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, lp.scheduleBlocked(monday.Add(2*time.Hour)))
+	assert.True(t, lp.scheduleBlocked(monday.Add(12*time.Hour)))
+	assert.False(t, lp.scheduleBlocked(monday.Add(23*time.Hour)))
+
+	// invalid
+	_, err = parseSchedules([]loadpoint.Schedule{{Hours: "20-17"}})
+	assert.Error(t, err)
+	_, err = parseSchedules([]loadpoint.Schedule{{Action: "foo"}})
+	assert.Error(t, err)
+}
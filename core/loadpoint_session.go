@@ -31,9 +31,11 @@ func (lp *Loadpoint) createSession() {
 	}
 
 	lp.session = lp.db.Session(lp.chargeMeterTotal())
+	lp.session.OffSite = lp.Virtual
 
 	if vehicle := lp.GetVehicle(); vehicle != nil {
 		lp.session.Vehicle = vehicle.Title()
+		lp.session.VehicleID = lp.coordinator.DeviceID(vehicle)
 	}
 
 	if c, ok := lp.charger.(api.Identifier); ok {
@@ -41,6 +43,26 @@ func (lp *Loadpoint) createSession() {
 			lp.session.Identifier = id
 		}
 	}
+
+	// apply tags and notes provided before the session started
+	lp.session.Tags, lp.session.Notes = lp.sessionTags, lp.sessionNotes
+	lp.sessionTags, lp.sessionNotes = "", ""
+}
+
+// SetSessionNotes sets tags and notes of the current charging session.
+// If no session exists yet, they are applied to the next session.
+func (lp *Loadpoint) SetSessionNotes(tags, notes string) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.session == nil {
+		lp.sessionTags, lp.sessionNotes = tags, notes
+		return
+	}
+
+	lp.updateSession(func(session *db.Session) {
+		session.Tags, session.Notes = tags, notes
+	})
 }
 
 // stopSession ends a charging session segment and persists the session.
@@ -67,11 +89,22 @@ func (lp *Loadpoint) stopSession() {
 		lp.sessionEnergy.Update(chargedEnergy)
 	}
 
+	if lp.vehicleSoc > 0 {
+		socEnd := lp.vehicleSoc
+		s.SocEnd = &socEnd
+	}
+
+	chargeDuration := lp.chargeDuration
+	s.ChargeDuration = &chargeDuration
+
 	solarPerc := lp.sessionEnergy.SolarPercentage()
 	s.SolarPercentage = &solarPerc
+	batteryPerc := lp.sessionEnergy.BatteryPercentage()
+	s.BatteryPercentage = &batteryPerc
 	s.Price = lp.sessionEnergy.Price()
 	s.PricePerKWh = lp.sessionEnergy.PricePerKWh()
 	s.Co2PerKWh = lp.sessionEnergy.Co2PerKWh()
+	s.Savings = lp.sessionEnergy.Savings()
 	s.ChargedEnergy = lp.sessionEnergy.TotalWh() / 1e3
 
 	lp.db.Persist(s)
@@ -79,7 +112,7 @@ func (lp *Loadpoint) stopSession() {
 
 type sessionOption func(*db.Session)
 
-// updateSession updates any parameter of a charging session and persists the session.
+// updateSession updates any parameter of a charging session and queues the session for persisting.
 func (lp *Loadpoint) updateSession(opts ...sessionOption) {
 	// test guard
 	if lp.db == nil || lp.session == nil {
@@ -91,7 +124,7 @@ func (lp *Loadpoint) updateSession(opts ...sessionOption) {
 	}
 
 	if !lp.session.Created.IsZero() {
-		lp.db.Persist(lp.session)
+		lp.db.Queue(lp.session)
 	}
 }
 
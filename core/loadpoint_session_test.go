@@ -61,12 +61,16 @@ func TestSession(t *testing.T) {
 	// stop charging
 	clock.Add(time.Hour)
 	lp.sessionEnergy.Update(1.23)
+	lp.chargeDuration = time.Hour
+	lp.vehicleSoc = 80
 	me.EXPECT().TotalEnergy().Return(1.0+lp.getChargedEnergy()/1e3, nil) // match chargedEnergy
 
 	lp.stopSession()
 	assert.NotNil(t, lp.session)
 	assert.Equal(t, lp.getChargedEnergy()/1e3, lp.session.ChargedEnergy)
 	assert.Equal(t, clock.Now(), lp.session.Finished)
+	assert.Equal(t, time.Hour, *lp.session.ChargeDuration)
+	assert.Equal(t, 80.0, *lp.session.SocEnd)
 
 	s, err := db.Sessions()
 	assert.NoError(t, err)
@@ -87,3 +91,36 @@ func TestSession(t *testing.T) {
 	assert.Len(t, s, 1)
 	t.Logf("session: %+v", s)
 }
+
+func TestSessionNotes(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	assert.NoError(t, err)
+
+	db, err := coredb.New("foo")
+	assert.NoError(t, err)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock.NewMock(),
+		db:            db,
+		chargeMeter:   &Null{},
+		sessionEnergy: NewEnergyMetrics(),
+	}
+
+	// notes before session are applied once session is created
+	lp.SetSessionNotes("business", "road trip")
+	lp.createSession()
+	assert.Equal(t, "business", lp.session.Tags)
+	assert.Equal(t, "road trip", lp.session.Notes)
+
+	// notes during session
+	lp.SetSessionNotes("private", "")
+	assert.Equal(t, "private", lp.session.Tags)
+	assert.Empty(t, lp.session.Notes)
+
+	// next session does not inherit notes
+	lp.clearSession()
+	lp.createSession()
+	assert.Empty(t, lp.session.Tags)
+}
@@ -389,6 +389,7 @@ func TestDisableAndEnableAtTargetSoc(t *testing.T) {
 	// wrap vehicle with estimator
 	vehicle.EXPECT().Capacity().Return(float64(10))
 	vehicle.EXPECT().Phases().Return(0).AnyTimes()
+	vehicle.EXPECT().Title().AnyTimes()
 	socEstimator := soc.NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
 
 	lp := &Loadpoint{
@@ -784,3 +785,253 @@ func TestMinSoc(t *testing.T) {
 		assert.Equal(t, tc.res, lp.minSocNotReached(), tc)
 	}
 }
+
+func TestCircuitCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		bus:            evbus.New(),
+		clock:          clock.NewMock(),
+		charger:        charger,
+		enabled:        true,
+		MinCurrent:     minA,
+		MaxCurrent:     maxA,
+		CircuitCurrent: 10,
+		phases:         3,
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// runtime changes are capped
+	lp.SetMaxCurrent(32)
+	assert.Equal(t, 10.0, lp.GetMaxCurrent())
+
+	// charger limit is capped regardless of mode
+	charger.EXPECT().MaxCurrent(int64(10)).Return(nil)
+	assert.NoError(t, lp.setLimit(16, false))
+}
+
+func TestDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl) // no calls expected
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		bus:         evbus.New(),
+		clock:       clock.NewMock(),
+		charger:     charger,
+		dryRun:      true,
+		wakeUpTimer: NewTimer(),
+		MinCurrent:  minA,
+		MaxCurrent:  maxA,
+		phases:      3,
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// decisions are tracked without writing to the charger
+	assert.NoError(t, lp.setLimit(maxA, true))
+	assert.Equal(t, maxA, lp.chargeCurrent)
+	assert.True(t, lp.enabled)
+
+	assert.NoError(t, lp.syncCharger())
+}
+
+type boostVehicle struct {
+	*mock.MockVehicle
+	boost api.PriorityBoost
+}
+
+func (v *boostVehicle) PriorityBoost() api.PriorityBoost {
+	return v.boost
+}
+
+func TestEffectivePriority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.Priority_ = 1
+	lp.vehicle = &boostVehicle{mock.NewMockVehicle(ctrl), api.PriorityBoost{Soc: 50, Priority: 2}}
+
+	// unknown soc
+	assert.Equal(t, 1, lp.EffectivePriority())
+
+	lp.vehicleSoc = 30
+	assert.Equal(t, 3, lp.EffectivePriority())
+
+	lp.vehicleSoc = 50
+	assert.Equal(t, 1, lp.EffectivePriority())
+}
+
+func TestReconcileCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	charger := &struct {
+		*mock.MockCharger
+		*mock.MockCurrentGetter
+	}{
+		mock.NewMockCharger(ctrl),
+		mock.NewMockCurrentGetter(ctrl),
+	}
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clock.NewMock(),
+		charger:       charger,
+		enabled:       true,
+		chargeCurrent: maxA,
+		MinCurrent:    minA,
+		MaxCurrent:    maxA,
+		phases:        3,
+	}
+
+	// charger matches setpoint
+	charger.MockCurrentGetter.EXPECT().GetMaxCurrent().Return(maxA, nil)
+	assert.NoError(t, lp.setLimit(maxA, false))
+
+	// charger clamped setpoint
+	charger.MockCurrentGetter.EXPECT().GetMaxCurrent().Return(10.0, nil)
+	charger.MockCharger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	assert.NoError(t, lp.setLimit(maxA, false))
+	assert.Equal(t, maxA, lp.chargeCurrent)
+}
+
+func TestUpdateNotifier(t *testing.T) {
+	lpChan := make(chan *Loadpoint, 1)
+
+	lp := &Loadpoint{
+		log:    util.NewLogger("foo"),
+		lpChan: lpChan,
+	}
+
+	notifyC := make(chan struct{})
+	go lp.updateNotifier(notifyC)
+
+	notifyC <- struct{}{}
+	assert.Equal(t, lp, <-lpChan)
+
+	close(notifyC)
+}
+
+func TestPVStartupGrace(t *testing.T) {
+	clck := clock.NewMock()
+
+	Voltage = 100
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		clock:          clck,
+		enabled:        true,
+		status:         api.StatusC,
+		chargeCurrent:  minA,
+		chargeCurrents: []float64{0, 0, 0}, // vehicle not yet drawing current
+		MinCurrent:     minA,
+		MaxCurrent:     maxA,
+		StartupGrace:   time.Minute,
+		Disable:        ThresholdConfig{Delay: time.Minute},
+		phases:         3,
+		measuredPhases: 3,
+		guardUpdated:   clck.Now(),
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// measured current ignored during grace period
+	assert.Equal(t, minA, lp.effectiveCurrent())
+
+	// disable timer not started during grace period
+	assert.Equal(t, minA, lp.pvMaxCurrent(api.ModePV, 100, false, false))
+	assert.True(t, lp.pvTimer.IsZero())
+
+	// disable timer started after grace period
+	clck.Add(time.Minute)
+	assert.Equal(t, 2.0, lp.effectiveCurrent())
+	assert.Equal(t, minA, lp.pvMaxCurrent(api.ModePV, 100, false, false))
+	assert.False(t, lp.pvTimer.IsZero())
+}
+
+func TestTargetSocReachedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pushChan := make(chan push.Event, 2)
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.pushChan = pushChan
+	lp.vehicle = mock.NewMockVehicle(ctrl)
+	lp.status = api.StatusB
+	lp.Soc.target = 80
+
+	lp.vehicleSoc = 70
+	lp.notifyTargetSocReached()
+	assert.Len(t, pushChan, 0)
+
+	lp.vehicleSoc = 80
+	lp.notifyTargetSocReached()
+	lp.notifyTargetSocReached()
+	assert.Len(t, pushChan, 1, "single event")
+	assert.Equal(t, evVehicleTargetSoc, (<-pushChan).Event)
+
+	// disconnected and reconnected
+	lp.status = api.StatusA
+	lp.notifyTargetSocReached()
+	lp.status = api.StatusB
+	lp.notifyTargetSocReached()
+	assert.Len(t, pushChan, 1)
+}
+
+type voltageMeter struct {
+	api.Meter
+	u [3]float64
+}
+
+func (m *voltageMeter) Voltages() (float64, float64, float64, error) {
+	return m.u[0], m.u[1], m.u[2], nil
+}
+
+func TestWeakPhases(t *testing.T) {
+	Voltage = 230 // V
+
+	meter := &voltageMeter{u: [3]float64{230, 210, 230}}
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		chargeMeter: meter,
+		charger: &struct {
+			api.Charger
+			api.PhaseSwitcher
+		}{},
+		status: api.StatusB,
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// voltage drop only counts under load
+	lp.updateChargeVoltages()
+	assert.Equal(t, [3]bool{false, false, false}, lp.weakPhases)
+
+	lp.status = api.StatusC
+	lp.updateChargeVoltages()
+	assert.Equal(t, [3]bool{false, true, false}, lp.weakPhases)
+
+	// recovered
+	meter.u[1] = 228
+	lp.updateChargeVoltages()
+	assert.Equal(t, [3]bool{false, false, false}, lp.weakPhases)
+
+	// reset when charging stops
+	meter.u[1] = 210
+	lp.updateChargeVoltages()
+	lp.status = api.StatusB
+	lp.updateChargeVoltages()
+	assert.Equal(t, [3]bool{false, false, false}, lp.weakPhases)
+}
@@ -2,20 +2,20 @@ package core
 
 import (
 	"errors"
-	"regexp"
-	"strings"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/db"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/server/db/settings"
 	"golang.org/x/exp/slices"
 )
 
 const (
 	vehicleDetectInterval = 1 * time.Minute
 	vehicleDetectDuration = 10 * time.Minute
+	preconditionDuration  = 30 * time.Minute
 )
 
 // coordinatedVehicles is the slice of vehicles from the coordinator
@@ -57,43 +57,82 @@ func (lp *Loadpoint) identifyVehicle() {
 	if id != "" {
 		lp.log.DEBUG.Println("charger vehicle id:", id)
 
+		// different vehicle plugged in without disconnect being detected
+		changed := lp.session != nil && lp.session.Identifier != "" && lp.session.Identifier != id
+		if changed {
+			lp.changeVehicleSession()
+		} else {
+			lp.updateSession(func(session *db.Session) {
+				session.Identifier = id
+			})
+		}
+
 		if vehicle := lp.selectVehicleByID(id); vehicle != nil {
 			lp.stopVehicleDetection()
 			lp.setActiveVehicle(vehicle)
+		} else if changed {
+			// previous vehicle is gone
+			lp.setActiveVehicle(nil)
+			lp.startVehicleDetection()
 		}
 	}
 }
 
-// selectVehicleByID selects the vehicle with the given ID
-func (lp *Loadpoint) selectVehicleByID(id string) api.Vehicle {
-	vehicles := lp.coordinatedVehicles()
+// changeVehicleSession closes the session of the previous vehicle and starts a new session
+// for the vehicle identified while the loadpoint remained connected
+func (lp *Loadpoint) changeVehicleSession() {
+	lp.log.INFO.Println("vehicle changed")
 
-	// find exact match
-	for _, vehicle := range vehicles {
-		for _, vid := range vehicle.Identifiers() {
-			if strings.EqualFold(id, vid) {
-				return vehicle
-			}
+	lp.stopSession()
+	lp.clearSession()
+
+	// count energy of the new session from here on
+	if lp.chargeRater != nil {
+		if f, err := lp.chargeRater.ChargedEnergy(); err == nil {
+			lp.chargedAtStartup = f
 		}
 	}
 
-	// find placeholder match
-	for _, vehicle := range vehicles {
-		for _, vid := range vehicle.Identifiers() {
-			// case insensitive match
-			re, err := regexp.Compile("(?i)" + strings.ReplaceAll(vid, "*", ".*?"))
-			if err != nil {
-				lp.log.ERROR.Printf("vehicle id: %v", err)
-				continue
-			}
+	lp.sessionEnergy.Reset()
+	lp.sessionEnergy.Publish("session", lp)
+	lp.publish("chargedEnergy", lp.getChargedEnergy())
 
-			if re.MatchString(id) {
-				return vehicle
-			}
-		}
+	// soc of the previous vehicle must not be used
+	lp.socUpdated = time.Time{}
+	if lp.socEstimator != nil {
+		lp.socEstimator.Reset()
+	}
+
+	// re-plan for the new vehicle
+	lp.setPlanActive(false)
+
+	lp.createSession()
+
+	// charging continues without charge start event
+	if lp.charging() {
+		lp.updateSession(func(session *db.Session) {
+			session.Created = lp.clock.Now()
+		})
+	}
+}
+
+// selectVehicleByID selects the vehicle with the given ID
+func (lp *Loadpoint) selectVehicleByID(id string) api.Vehicle {
+	if lp.coordinator == nil {
+		return nil
+	}
+	return lp.coordinator.IdentifyVehicle(id)
+}
+
+// replaceVehicle swaps the placeholder of a vehicle that failed at startup for the recovered vehicle
+func (lp *Loadpoint) replaceVehicle(from, to api.Vehicle) {
+	if lp.defaultVehicle == from {
+		lp.defaultVehicle = to
 	}
 
-	return nil
+	if lp.GetVehicle() == from {
+		lp.setActiveVehicle(to)
+	}
 }
 
 // setActiveVehicle assigns currently active vehicle, configures soc estimator
@@ -105,14 +144,19 @@ func (lp *Loadpoint) setActiveVehicle(vehicle api.Vehicle) {
 		return
 	}
 
+	// virtual loadpoints don't claim their vehicle to keep it detectable at on-site loadpoints
 	from := "unknown"
 	if lp.vehicle != nil {
-		lp.coordinator.Release(lp.vehicle)
+		if !lp.Virtual {
+			lp.coordinator.Release(lp.vehicle)
+		}
 		from = lp.vehicle.Title()
 	}
 	to := "unknown"
 	if vehicle != nil {
-		lp.coordinator.Acquire(vehicle)
+		if !lp.Virtual {
+			lp.coordinator.Acquire(vehicle)
+		}
 		to = vehicle.Title()
 	}
 
@@ -121,9 +165,15 @@ func (lp *Loadpoint) setActiveVehicle(vehicle api.Vehicle) {
 
 	lp.log.INFO.Printf("vehicle updated: %s -> %s", from, to)
 
+	// position unknown until updated
+	lp.setVehicleAway(false)
+
 	// lock api
 	lp.Lock()
 
+	// revert calibration override of previous vehicle
+	lp.stopCalibration()
+
 	// reset minSoc and targetSoc before change
 	lp.setMinSoc(0)
 	lp.setTargetSoc(100)
@@ -150,7 +200,19 @@ func (lp *Loadpoint) setActiveVehicle(vehicle api.Vehicle) {
 		lp.publish(vehicleCapacity, vehicle.Capacity())
 
 		lp.applyAction(vehicle.OnIdentified())
+		lp.applyProfile()
+		lp.restoreVehicleMinSoc(vehicle)
+		lp.startCalibration(vehicle)
+
+		// notify automations about the connected vehicle
+		if lp.connected() {
+			lp.pushEvent(evVehicleIdentified)
+		}
+
 		lp.addTask(lp.vehicleOdometer)
+		if lp.Geofence.enabled() {
+			lp.addTask(lp.vehiclePosition)
+		}
 
 		lp.progress.Reset()
 	} else {
@@ -167,17 +229,79 @@ func (lp *Loadpoint) setActiveVehicle(vehicle api.Vehicle) {
 	lp.publish(phasesActive, lp.activePhases())
 	lp.unpublishVehicle()
 
+	if vehicle != nil {
+		lp.restoreVehicleSoc(vehicle)
+	}
+
 	lp.updateSession(func(session *db.Session) {
-		var title string
+		var title, id string
 		if vehicle != nil {
 			title = vehicle.Title()
+			id = lp.coordinator.DeviceID(vehicle)
 		}
 
 		lp.session.Vehicle = title
+		lp.session.VehicleID = id
 	})
 }
 
+// restoreVehicleMinSoc applies the minimum soc last set for the vehicle, overriding its configured default
+func (lp *Loadpoint) restoreVehicleMinSoc(vehicle api.Vehicle) {
+	if soc, err := settings.Int(lp.vehicleSettingsKey(vehicle, "minSoc")); err == nil {
+		lp.log.DEBUG.Printf("vehicle min soc: %d%% (restored)", soc)
+		lp.applyMinSoc(int(soc))
+	}
+}
+
+// vehicleSocState is the last known vehicle soc and range as persisted across restarts
+type vehicleSocState struct {
+	Soc     float64   `json:"soc"`
+	Range   int64     `json:"range,omitempty"`
+	Updated time.Time `json:"updated"`
+}
+
+// persistVehicleSoc stores the last vehicle soc and range
+func (lp *Loadpoint) persistVehicleSoc(vehicle api.Vehicle, state vehicleSocState) {
+	if err := settings.SetJson(lp.vehicleSettingsKey(vehicle, "soc"), state); err != nil {
+		lp.log.ERROR.Printf("vehicle soc: %v", err)
+	}
+}
+
+// restoreVehicleSoc publishes the persisted vehicle soc and range if still within the poll interval.
+// The next soc poll is deferred according to the time of the persisted update.
+func (lp *Loadpoint) restoreVehicleSoc(vehicle api.Vehicle) {
+	var state vehicleSocState
+	if err := settings.Json(lp.vehicleSettingsKey(vehicle, "soc"), &state); err != nil || state.Updated.IsZero() {
+		return
+	}
+
+	if age := lp.clock.Since(state.Updated); age < 0 || age > lp.Soc.Poll.Interval {
+		return
+	}
+
+	lp.log.DEBUG.Printf("vehicle soc: %.0f%% (restored from %v)", state.Soc, state.Updated.Round(time.Second))
+
+	lp.socUpdated = state.Updated
+	lp.vehicleSoc = state.Soc
+	lp.vehicleSocEstimated = true
+	lp.publish(vehicleSoc, lp.vehicleSoc)
+	lp.publish("vehicleSocEstimated", lp.vehicleSocEstimated)
+
+	// use as estimation base if vehicle api is unavailable
+	if lp.socEstimator != nil {
+		lp.socEstimator.Restore(state.Soc, lp.getChargedEnergy())
+	}
+
+	if state.Range > 0 {
+		lp.publish(vehicleRange, state.Range)
+	}
+}
+
 func (lp *Loadpoint) wakeUpVehicle() {
+	if lp.dryRunCommand("wake-up vehicle") {
+		return
+	}
+
 	// charger
 	if c, ok := lp.charger.(api.Resurrector); ok {
 		if err := c.WakeUp(); err != nil {
@@ -187,7 +311,10 @@ func (lp *Loadpoint) wakeUpVehicle() {
 
 	// vehicle
 	if vs, ok := lp.GetVehicle().(api.Resurrector); ok {
-		if err := vs.WakeUp(); err != nil {
+		switch err := vs.WakeUp(); {
+		case errors.Is(err, api.ErrMustRetry):
+			lp.log.DEBUG.Println("wake-up vehicle: throttled")
+		case err != nil:
 			lp.log.ERROR.Printf("wake-up vehicle: %v", err)
 		}
 	}
@@ -196,6 +323,8 @@ func (lp *Loadpoint) wakeUpVehicle() {
 // unpublishVehicle resets published vehicle data
 func (lp *Loadpoint) unpublishVehicle() {
 	lp.vehicleSoc = 0
+	lp.vehicleSocLimit = 0
+	lp.vehicleCableLocked = false
 
 	lp.publish(vehicleSoc, 0.0)
 	lp.publish(vehicleRange, int64(0))
@@ -255,7 +384,11 @@ func (lp *Loadpoint) vehicleDefaultOrDetect() {
 		} else {
 			// default vehicle is already active, update odometer anyway
 			// need to do this here since setActiveVehicle would short-circuit
+			lp.startCalibration(lp.defaultVehicle)
 			lp.addTask(lp.vehicleOdometer)
+			if lp.Geofence.enabled() {
+				lp.addTask(lp.vehiclePosition)
+			}
 		}
 	} else if len(lp.coordinatedVehicles()) > 0 && lp.connected() {
 		lp.startVehicleDetection()
@@ -329,6 +462,17 @@ func (lp *Loadpoint) vehicleClimatePollAllowed() bool {
 	}
 }
 
+// refreshVehicle forces a vehicle soc update with the next cycle regardless of the poll interval
+func (lp *Loadpoint) refreshVehicle() {
+	lp.addTask(lp.resetSocUpdated)
+	lp.requestUpdate()
+}
+
+// resetSocUpdated allows soc polling in the current cycle
+func (lp *Loadpoint) resetSocUpdated() {
+	lp.socUpdated = time.Time{}
+}
+
 // vehicleSocPollAllowed validates charging state against polling mode
 func (lp *Loadpoint) vehicleSocPollAllowed() bool {
 	// always update soc when charging
@@ -357,6 +501,30 @@ func (lp *Loadpoint) vehicleSocPollAllowed() bool {
 	return false
 }
 
+// updateVehicleChargePort reads and publishes the vehicle's charge port and cable state
+func (lp *Loadpoint) updateVehicleChargePort() {
+	v := lp.GetVehicle()
+
+	if vp, ok := v.(api.VehicleChargePort); ok {
+		if open, err := vp.ChargePortOpen(); err == nil {
+			lp.log.DEBUG.Printf("vehicle charge port open: %t", open)
+			lp.publish(vehicleChargePortOpen, open)
+		} else if !errors.Is(err, api.ErrNotAvailable) {
+			lp.log.ERROR.Printf("vehicle charge port: %v", err)
+		}
+	}
+
+	if vc, ok := v.(api.VehicleCableLock); ok {
+		if locked, err := vc.CableLocked(); err == nil {
+			lp.log.DEBUG.Printf("vehicle cable locked: %t", locked)
+			lp.vehicleCableLocked = locked
+			lp.publish(vehicleCableLocked, locked)
+		} else if !errors.Is(err, api.ErrNotAvailable) {
+			lp.log.ERROR.Printf("vehicle cable lock: %v", err)
+		}
+	}
+}
+
 // vehicleClimateActive checks if vehicle has active climate request
 func (lp *Loadpoint) vehicleClimateActive() bool {
 	if cl, ok := lp.GetVehicle().(api.VehicleClimater); ok && lp.vehicleClimatePollAllowed() {
@@ -377,3 +545,45 @@ func (lp *Loadpoint) vehicleClimateActive() bool {
 
 	return false
 }
+
+// vehiclePrecondition starts vehicle climatisation once ahead of the target time if power is available,
+// i.e. pv surplus or grid power while fast charging
+func (lp *Loadpoint) vehiclePrecondition(available bool) {
+	cc, ok := lp.GetVehicle().(api.VehicleClimateController)
+	if !ok || !available {
+		return
+	}
+
+	targetTime := lp.GetTargetTime()
+	if targetTime.IsZero() || lp.preconditioned.Equal(targetTime) {
+		return
+	}
+
+	if d := targetTime.Sub(lp.clock.Now()); d <= 0 || d > preconditionDuration {
+		return
+	}
+
+	if !lp.dryRunCommand("vehicle climate start") {
+		if err := cc.StartClimate(); err != nil {
+			lp.log.ERROR.Printf("vehicle climate: %v", err)
+			return
+		}
+
+		lp.log.DEBUG.Println("vehicle climate started")
+	}
+
+	lp.preconditioned = targetTime
+}
+
+// reportVehicleHealth records the vehicle api result and notifies if re-authentication has become required
+func (lp *Loadpoint) reportVehicleHealth(err error) {
+	// test guard
+	if lp.coordinator == nil || lp.vehicle == nil {
+		return
+	}
+
+	if lp.coordinator.ReportHealth(lp.vehicle, err) {
+		lp.log.WARN.Printf("vehicle %s: re-authentication required", lp.vehicle.Title())
+		lp.pushEvent(evVehicleReauth)
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/coordinator"
+	coredb "github.com/evcc-io/evcc/core/db"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/push"
+	serverdb "github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -142,6 +145,15 @@ func TestVehicleDetectByID(t *testing.T) {
 			t.Errorf("expected %v, got %v", tc.res, res)
 		}
 	}
+
+	// loadpoint without coordinator
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+	}
+
+	if res := lp.selectVehicleByID("1"); res != nil {
+		t.Errorf("expected nil, got %v", res)
+	}
 }
 
 func TestDefaultVehicle(t *testing.T) {
@@ -180,6 +192,7 @@ func TestDefaultVehicle(t *testing.T) {
 		Mode:       &off,
 		MinCurrent: &lp.MinCurrent,
 		MaxCurrent: &lp.MaxCurrent,
+		Phases:     &zero,
 		MinSoc:     &zero,
 		TargetSoc:  &hundred,
 		Priority:   &zero,
@@ -383,3 +396,338 @@ func TestReconnectVehicle(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreVehicleSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("restore").AnyTimes()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		Soc: SocConfig{
+			Poll: PollConfig{Interval: time.Hour},
+		},
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// nothing persisted
+	lp.restoreVehicleSoc(vehicle)
+	assert.Zero(t, lp.vehicleSoc)
+	assert.True(t, lp.socUpdated.IsZero())
+
+	updated := clck.Now()
+	lp.persistVehicleSoc(vehicle, vehicleSocState{Soc: 42, Range: 200, Updated: updated})
+
+	// within poll interval
+	clck.Add(30 * time.Minute)
+	lp.restoreVehicleSoc(vehicle)
+	assert.Equal(t, 42.0, lp.vehicleSoc)
+	assert.True(t, updated.Equal(lp.socUpdated))
+
+	// expired
+	lp.vehicleSoc = 0
+	lp.socUpdated = time.Time{}
+	clck.Add(time.Hour)
+	lp.restoreVehicleSoc(vehicle)
+	assert.Zero(t, lp.vehicleSoc)
+	assert.True(t, lp.socUpdated.IsZero())
+}
+
+func TestRestoredSocSurvivesConnect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("connect").AnyTimes()
+	vehicle.EXPECT().Phases().Return(0).AnyTimes()
+	vehicle.EXPECT().OnIdentified().Return(api.ActionConfig{}).AnyTimes()
+
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		clock:          clck,
+		sessionEnergy:  NewEnergyMetrics(),
+		vehicle:        vehicle,
+		defaultVehicle: vehicle,
+		Soc: SocConfig{
+			Poll: PollConfig{Interval: time.Hour},
+		},
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	updated := clck.Now()
+	lp.persistVehicleSoc(vehicle, vehicleSocState{Soc: 42, Updated: updated})
+	lp.restoreVehicleSoc(vehicle)
+
+	// startup connect of the same vehicle keeps restored soc
+	lp.evVehicleConnectHandler()
+	assert.True(t, updated.Equal(lp.socUpdated))
+
+	// vehicle may be driven after disconnect
+	lp.evVehicleDisconnectHandler()
+	assert.True(t, lp.socUpdated.IsZero())
+}
+
+func TestApplyActionPhases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.charger = &struct {
+		*mock.MockCharger
+		*mock.MockPhaseSwitcher
+	}{
+		mock.NewMockCharger(ctrl),
+		mock.NewMockPhaseSwitcher(ctrl),
+	}
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	lp.collectDefaults()
+
+	one := 1
+	lp.applyAction(api.ActionConfig{Phases: &one})
+	assert.Equal(t, 1, lp.ConfiguredPhases)
+
+	// revert to auto
+	lp.applyAction(lp.onDisconnect)
+	assert.Equal(t, 0, lp.ConfiguredPhases)
+
+	// ignored for fixed phase chargers
+	lp.charger = mock.NewMockCharger(ctrl)
+	lp.ConfiguredPhases = 3
+	lp.applyAction(api.ActionConfig{Phases: &one})
+	assert.Equal(t, 3, lp.ConfiguredPhases)
+}
+
+func TestVehicleIdentifiedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("target").AnyTimes()
+	vehicle.EXPECT().Icon().Return("").AnyTimes()
+	vehicle.EXPECT().Capacity().AnyTimes()
+	vehicle.EXPECT().Phases().AnyTimes()
+	vehicle.EXPECT().OnIdentified().AnyTimes()
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.status = api.StatusB
+
+	uiChan := make(chan util.Param, 100)
+	pushChan := make(chan push.Event, 1)
+	attachChannels(lp, uiChan, pushChan, make(chan *Loadpoint, 1))
+
+	lp.setActiveVehicle(vehicle)
+
+	select {
+	case ev := <-pushChan:
+		assert.Equal(t, evVehicleIdentified, ev.Event)
+	default:
+		t.Error("missing identified event")
+	}
+}
+
+func TestRestoreVehicleMinSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	minSoc := 20
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("minsoc").AnyTimes()
+	vehicle.EXPECT().Icon().Return("").AnyTimes()
+	vehicle.EXPECT().Capacity().AnyTimes()
+	vehicle.EXPECT().Phases().AnyTimes()
+	vehicle.EXPECT().OnIdentified().Return(api.ActionConfig{MinSoc: &minSoc}).AnyTimes()
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+
+	// populate channels
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// configured default
+	lp.setActiveVehicle(vehicle)
+	assert.Equal(t, 20, lp.GetMinSoc())
+
+	lp.SetMinSoc(40)
+
+	lp.setActiveVehicle(nil)
+	assert.Equal(t, 0, lp.GetMinSoc())
+
+	// last setting overrides default
+	lp.setActiveVehicle(vehicle)
+	assert.Equal(t, 40, lp.GetMinSoc())
+}
+
+func TestVehicleChangeSession(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	assert.NoError(t, err)
+
+	db, err := coredb.New("foo")
+	assert.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+
+	newVehicle := func(title, id string) *mock.MockVehicle {
+		vehicle := mock.NewMockVehicle(ctrl)
+		vehicle.EXPECT().Title().Return(title).AnyTimes()
+		vehicle.EXPECT().Icon().Return("").AnyTimes()
+		vehicle.EXPECT().Capacity().AnyTimes()
+		vehicle.EXPECT().Phases().AnyTimes()
+		vehicle.EXPECT().Identifiers().Return([]string{id}).AnyTimes()
+		return vehicle
+	}
+
+	targetSoc := 70
+	v1 := newVehicle("first", "1")
+	v1.EXPECT().OnIdentified().AnyTimes()
+	v2 := newVehicle("second", "2")
+	v2.EXPECT().OnIdentified().Return(api.ActionConfig{TargetSoc: &targetSoc}).AnyTimes()
+
+	charger := struct {
+		*mock.MockCharger
+		*mock.MockIdentifier
+	}{
+		MockCharger:    mock.NewMockCharger(ctrl),
+		MockIdentifier: mock.NewMockIdentifier(ctrl),
+	}
+
+	rater := mock.NewMockChargeRater(ctrl)
+
+	clck := clock.NewMock()
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.clock = clck
+	lp.db = db
+	lp.charger = charger
+	lp.chargeMeter = mock.NewMockMeter(ctrl)
+	lp.chargeRater = rater
+	lp.status = api.StatusC
+	lp.coordinator = coordinator.NewAdapter(lp, coordinator.New(util.NewLogger("foo"), []api.Vehicle{v1, v2}))
+
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+
+	// first vehicle charging
+	charger.MockIdentifier.EXPECT().Identify().Return("1", nil).Times(2)
+	lp.createSession()
+	lp.updateSession(func(session *coredb.Session) {
+		session.Created = clck.Now()
+	})
+	lp.identifyVehicle()
+	assert.Equal(t, v1, lp.GetVehicle())
+
+	clck.Add(time.Hour)
+	lp.sessionEnergy.Update(10)
+
+	// second vehicle identified without disconnect
+	charger.MockIdentifier.EXPECT().Identify().Return("2", nil).Times(2)
+	rater.EXPECT().ChargedEnergy().Return(10.0, nil)
+	lp.identifyVehicle()
+
+	assert.Equal(t, v2, lp.GetVehicle())
+	assert.Equal(t, targetSoc, lp.GetTargetSoc())
+	assert.Equal(t, 10.0, lp.chargedAtStartup)
+	assert.Equal(t, 0.0, lp.getChargedEnergy())
+
+	// previous session closed for first vehicle, new session started for second vehicle
+	s, err := db.Sessions()
+	assert.NoError(t, err)
+	assert.Len(t, s, 2)
+	assert.Equal(t, "first", s[0].Vehicle)
+	assert.Equal(t, 10.0, s[0].ChargedEnergy)
+	assert.True(t, clck.Now().Equal(s[0].Finished))
+	assert.Equal(t, "second", s[1].Vehicle)
+	assert.Equal(t, "2", s[1].Identifier)
+	assert.True(t, clck.Now().Equal(s[1].Created))
+}
+
+type climateVehicle struct {
+	api.Vehicle
+	started int
+}
+
+func (v *climateVehicle) StartClimate() error {
+	v.started++
+	return nil
+}
+
+func (v *climateVehicle) StopClimate() error {
+	return nil
+}
+
+func TestVehiclePreconditionDryRun(t *testing.T) {
+	clck := clock.NewMock()
+	vehicle := &climateVehicle{}
+
+	lp := &Loadpoint{
+		log:     util.NewLogger("foo"),
+		clock:   clck,
+		vehicle: vehicle,
+		dryRun:  true,
+	}
+
+	lp.targetTime = clck.Now().Add(10 * time.Minute)
+
+	lp.vehiclePrecondition(true)
+	assert.Equal(t, 0, vehicle.started, "dry run")
+	assert.Equal(t, lp.targetTime, lp.preconditioned)
+
+	lp.dryRun = false
+	lp.preconditioned = time.Time{}
+
+	lp.vehiclePrecondition(true)
+	assert.Equal(t, 1, vehicle.started)
+}
+
+func TestVehiclePreconditionNowMode(t *testing.T) {
+	clck := clock.NewMock()
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+
+	mv := mock.NewMockVehicle(ctrl)
+	mv.EXPECT().Soc().Return(50.0, nil).AnyTimes()
+	mv.EXPECT().Title().Return("climate").AnyTimes()
+	mv.EXPECT().Capacity().Return(50.0).AnyTimes()
+	mv.EXPECT().Phases().Return(0).AnyTimes()
+	vehicle := &climateVehicle{Vehicle: mv}
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		chargeMeter:   &Null{}, // silence nil panics
+		chargeRater:   &Null{}, // silence nil panics
+		chargeTimer:   &Null{}, // silence nil panics
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		socEstimator:  soc.NewEstimator(util.NewLogger("foo"), charger, vehicle, false),
+		MinCurrent:    minA,
+		MaxCurrent:    maxA,
+		phases:        1,
+		status:        api.StatusC,
+		vehicle:       vehicle,
+		Mode:          api.ModeNow,
+	}
+
+	attachListeners(t, lp)
+
+	lp.targetTime = clck.Now().Add(10 * time.Minute)
+
+	charger.EXPECT().Status().Return(api.StatusC, nil)
+	charger.EXPECT().Enabled().Return(true, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA))
+
+	lp.Update(0, false, false, false, 0, nil, nil)
+	assert.Equal(t, 1, vehicle.started, "preconditioning while fast charging")
+}
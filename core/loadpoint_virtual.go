@@ -0,0 +1,163 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+)
+
+// vehicleCharger emulates a charger for virtual loadpoints from the vehicle api.
+// Charging at third-party chargers can't be controlled, power and energy are derived from the soc increase.
+type vehicleCharger struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	vehicle api.Vehicle
+	onSite  func() bool // vehicle is connected to an on-site loadpoint
+	status  api.ChargeStatus
+	enabled bool
+	soc     float64
+	updated time.Time
+	power   float64
+	energy  float64
+}
+
+var _ api.Charger = (*vehicleCharger)(nil)
+var _ api.Meter = (*vehicleCharger)(nil)
+var _ api.MeterEnergy = (*vehicleCharger)(nil)
+
+// configureVirtual sets up the emulated charger of a virtual loadpoint
+func (lp *Loadpoint) configureVirtual() error {
+	switch {
+	case lp.defaultVehicle == nil:
+		return errors.New("virtual loadpoint requires vehicle")
+	case lp.ChargerRef != "" || lp.MeterRef != "":
+		return errors.New("virtual loadpoint must not have charger or meter")
+	case lp.Circuit_ != "":
+		return errors.New("virtual loadpoint must not have circuit")
+	}
+
+	charger, err := newVehicleCharger(lp.clock, lp.defaultVehicle)
+	if err != nil {
+		return err
+	}
+
+	lp.charger = charger
+
+	if lp.ConfiguredPhases == 0 {
+		lp.ConfiguredPhases = 3
+	}
+
+	return nil
+}
+
+func newVehicleCharger(clock clock.Clock, vehicle api.Vehicle) (*vehicleCharger, error) {
+	if _, ok := vehicle.(api.ChargeState); !ok {
+		return nil, errors.New("vehicle does not provide charge status")
+	}
+
+	return &vehicleCharger{
+		clock:   clock,
+		vehicle: vehicle,
+		enabled: true,
+	}, nil
+}
+
+// Status implements the api.Charger interface
+func (c *vehicleCharger) Status() (api.ChargeStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// charging at home is tracked by the on-site loadpoint
+	if c.onSite != nil && c.onSite() {
+		c.status = api.StatusA
+		return c.status, nil
+	}
+
+	status, err := c.vehicle.(api.ChargeState).Status()
+	if err != nil {
+		return api.StatusNone, err
+	}
+
+	c.status = status
+
+	return status, nil
+}
+
+// Enabled implements the api.Charger interface
+func (c *vehicleCharger) Enabled() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enabled, nil
+}
+
+// Enable implements the api.Charger interface
+func (c *vehicleCharger) Enable(enable bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enabled = enable
+	return nil
+}
+
+// MaxCurrent implements the api.Charger interface
+func (c *vehicleCharger) MaxCurrent(current int64) error {
+	return nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (c *vehicleCharger) CurrentPower() (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.status != api.StatusC {
+		c.power = 0
+		c.updated = time.Time{}
+		return 0, nil
+	}
+
+	soc, err := c.vehicle.Soc()
+	if err != nil {
+		// keep estimate until next soc update
+		if errors.Is(err, api.ErrMustRetry) {
+			return c.power, nil
+		}
+		return 0, err
+	}
+
+	now := c.clock.Now()
+
+	// first reading or soc decreased
+	if c.updated.IsZero() || soc < c.soc {
+		c.soc, c.updated = soc, now
+		return c.power, nil
+	}
+
+	if soc > c.soc {
+		energy := (soc - c.soc) / 100 * c.vehicle.Capacity()
+
+		c.energy += energy
+		c.power = 1e3 * energy / now.Sub(c.updated).Hours()
+		c.soc, c.updated = soc, now
+	}
+
+	return c.power, nil
+}
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (c *vehicleCharger) TotalEnergy() (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.energy, nil
+}
+
+// vehicleOnSite returns true if the vehicle is connected to an on-site loadpoint
+func (site *Site) vehicleOnSite(vehicle api.Vehicle) bool {
+	for _, lp := range site.loadpoints {
+		if !lp.Virtual && lp.connected() && lp.GetVehicle() == vehicle {
+			return true
+		}
+	}
+	return false
+}
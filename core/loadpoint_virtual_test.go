@@ -0,0 +1,121 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVehicleCharger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clock := clock.NewMock()
+
+	vehicle := &struct {
+		*mock.MockVehicle
+		*mock.MockChargeState
+	}{
+		mock.NewMockVehicle(ctrl),
+		mock.NewMockChargeState(ctrl),
+	}
+
+	vehicle.MockVehicle.EXPECT().Capacity().Return(50.0).AnyTimes()
+
+	c, err := newVehicleCharger(clock, vehicle)
+	require.NoError(t, err)
+
+	var onSite bool
+	c.onSite = func() bool { return onSite }
+
+	// initial soc reading
+	vehicle.MockChargeState.EXPECT().Status().Return(api.StatusC, nil)
+	vehicle.MockVehicle.EXPECT().Soc().Return(50.0, nil)
+
+	status, err := c.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, api.StatusC, status)
+
+	power, err := c.CurrentPower()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, power)
+
+	// 5kWh charged in 30 minutes
+	clock.Add(30 * time.Minute)
+	vehicle.MockVehicle.EXPECT().Soc().Return(60.0, nil)
+
+	power, err = c.CurrentPower()
+	assert.NoError(t, err)
+	assert.Equal(t, 10e3, power)
+
+	// estimate is kept until soc changes
+	clock.Add(10 * time.Minute)
+	vehicle.MockVehicle.EXPECT().Soc().Return(60.0, nil)
+
+	power, _ = c.CurrentPower()
+	assert.Equal(t, 10e3, power)
+
+	energy, err := c.TotalEnergy()
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, energy)
+
+	// vehicle connected at home
+	onSite = true
+
+	status, err = c.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, api.StatusA, status)
+
+	power, _ = c.CurrentPower()
+	assert.Equal(t, 0.0, power)
+
+	// vehicle without charge status
+	_, err = newVehicleCharger(clock, mock.NewMockVehicle(ctrl))
+	assert.Error(t, err)
+}
+
+func TestVirtualVehicleDetectable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := &struct {
+		*mock.MockVehicle
+		*mock.MockChargeState
+	}{
+		mock.NewMockVehicle(ctrl),
+		mock.NewMockChargeState(ctrl),
+	}
+
+	vehicle.MockVehicle.EXPECT().Title().Return("vehicle").AnyTimes()
+	vehicle.MockVehicle.EXPECT().Icon().Return("").AnyTimes()
+	vehicle.MockVehicle.EXPECT().Capacity().AnyTimes()
+	vehicle.MockVehicle.EXPECT().Phases().AnyTimes()
+	vehicle.MockVehicle.EXPECT().OnIdentified().AnyTimes()
+
+	c := coordinator.New(util.NewLogger("foo"), []api.Vehicle{vehicle})
+
+	virtual := NewLoadpoint(util.NewLogger("foo"))
+	virtual.Virtual = true
+	virtual.coordinator = coordinator.NewAdapter(virtual, c)
+
+	x, y, z := createChannels(t)
+	attachChannels(virtual, x, y, z)
+
+	virtual.setActiveVehicle(vehicle)
+
+	// on-site loadpoint detects the vehicle of the virtual loadpoint
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	adapter := coordinator.NewAdapter(lp, c)
+
+	vehicle.MockChargeState.EXPECT().Status().Return(api.StatusB, nil)
+	assert.Equal(t, vehicle, adapter.IdentifyVehicleByStatus())
+
+	// acquiring the vehicle on-site does not remove it from the virtual loadpoint
+	adapter.Acquire(vehicle)
+	assert.Equal(t, vehicle, virtual.GetVehicle())
+}
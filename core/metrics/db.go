@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sample is a raw metrics value averaged over the recording interval
+type sample struct {
+	ID        uint      `gorm:"primarykey"`
+	Key       string    `gorm:"index:idx_metrics_key_ts"`
+	Timestamp time.Time `gorm:"index:idx_metrics_key_ts"`
+	Value     float64
+}
+
+func (sample) TableName() string {
+	return "metrics"
+}
+
+// aggregate is a downsampled metrics value covering one hour
+type aggregate struct {
+	ID        uint      `gorm:"primarykey"`
+	Key       string    `gorm:"index:idx_metrics_hourly_key_ts"`
+	Timestamp time.Time `gorm:"index:idx_metrics_hourly_key_ts"`
+	Min       float64
+	Max       float64
+	Avg       float64
+	Count     int
+}
+
+func (aggregate) TableName() string {
+	return "metrics_hourly"
+}
+
+// migrate creates or updates the metrics tables
+func migrate(db *gorm.DB) error {
+	return db.AutoMigrate(new(sample), new(aggregate))
+}
+
+// truncateHour truncates to the full local hour, honoring timezones with non-hourly offsets
+func truncateHour(ts time.Time) time.Time {
+	ts = ts.Local()
+	return ts.Truncate(time.Minute).Add(-time.Duration(ts.Minute()) * time.Minute)
+}
+
+// downsample aggregates all raw samples of completed hours up to given time
+// that have not been aggregated before
+func downsample(db *gorm.DB, until time.Time) error {
+	until = truncateHour(until)
+
+	var last time.Time
+	var res aggregate
+	if err := db.Order("timestamp DESC").Limit(1).Find(&res).Error; err != nil {
+		return err
+	}
+	if res.ID != 0 {
+		last = res.Timestamp.Add(time.Hour)
+	}
+
+	var samples []sample
+	if err := db.Where("timestamp >= ? AND timestamp < ?", last, until).Order("timestamp").Find(&samples).Error; err != nil {
+		return err
+	}
+
+	type slot struct {
+		key string
+		ts  time.Time
+	}
+
+	slots := make(map[slot]*aggregate)
+	var order []slot
+
+	for _, s := range samples {
+		k := slot{s.Key, truncateHour(s.Timestamp)}
+
+		a, ok := slots[k]
+		if !ok {
+			a = &aggregate{Key: s.Key, Timestamp: k.ts, Min: s.Value, Max: s.Value}
+			slots[k] = a
+			order = append(order, k)
+		}
+
+		a.Min = math.Min(a.Min, s.Value)
+		a.Max = math.Max(a.Max, s.Value)
+		a.Avg += (s.Value - a.Avg) / float64(a.Count+1)
+		a.Count++
+	}
+
+	if len(order) == 0 {
+		return nil
+	}
+
+	aggregates := make([]*aggregate, 0, len(order))
+	for _, k := range order {
+		aggregates = append(aggregates, slots[k])
+	}
+
+	return db.CreateInBatches(aggregates, 100).Error
+}
+
+// expire removes raw samples and aggregates beyond their retention period.
+// Zero retention keeps data forever.
+func expire(db *gorm.DB, now time.Time, conf Retention) error {
+	if conf.Raw > 0 {
+		if err := db.Where("timestamp < ?", now.Add(-conf.Raw)).Delete(new(sample)).Error; err != nil {
+			return err
+		}
+	}
+
+	if conf.Hourly > 0 {
+		if err := db.Where("timestamp < ?", now.Add(-conf.Hourly)).Delete(new(aggregate)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
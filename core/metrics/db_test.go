@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func testDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), new(gorm.Config))
+	require.NoError(t, err)
+	require.NoError(t, migrate(db))
+	return db
+}
+
+func TestDownsample(t *testing.T) {
+	db := testDB(t)
+
+	start := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	samples := []sample{
+		{Key: "pvPower", Timestamp: start, Value: 1000},
+		{Key: "pvPower", Timestamp: start.Add(30 * time.Minute), Value: 3000},
+		{Key: "pvPower", Timestamp: start.Add(time.Hour), Value: 5000},
+		{Key: "gridPower", Timestamp: start.Add(10 * time.Minute), Value: -500},
+	}
+	require.NoError(t, db.Create(&samples).Error)
+
+	// second hour not yet complete
+	require.NoError(t, downsample(db, start.Add(90*time.Minute)))
+
+	var res []aggregate
+	require.NoError(t, db.Order("key").Find(&res).Error)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, "gridPower", res[0].Key)
+	assert.Equal(t, -500.0, res[0].Avg)
+
+	assert.Equal(t, "pvPower", res[1].Key)
+	assert.Equal(t, 1000.0, res[1].Min)
+	assert.Equal(t, 3000.0, res[1].Max)
+	assert.Equal(t, 2000.0, res[1].Avg)
+	assert.Equal(t, 2, res[1].Count)
+
+	// repeated downsampling must not aggregate twice
+	require.NoError(t, downsample(db, start.Add(90*time.Minute)))
+	require.NoError(t, downsample(db, start.Add(2*time.Hour)))
+
+	res = nil
+	require.NoError(t, db.Order("timestamp").Find(&res).Error)
+	require.Len(t, res, 3)
+	assert.Equal(t, 5000.0, res[2].Avg)
+}
+
+func TestExpire(t *testing.T) {
+	db := testDB(t)
+
+	now := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.Create(&[]sample{
+		{Key: "pvPower", Timestamp: now.Add(-8 * 24 * time.Hour)},
+		{Key: "pvPower", Timestamp: now.Add(-time.Hour)},
+	}).Error)
+	require.NoError(t, db.Create(&[]aggregate{
+		{Key: "pvPower", Timestamp: now.Add(-8 * 24 * time.Hour)},
+	}).Error)
+
+	require.NoError(t, expire(db, now, DefaultRetention))
+
+	var count int64
+	require.NoError(t, db.Model(new(sample)).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, db.Model(new(aggregate)).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestTruncateHour(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	local := time.Local
+	time.Local = loc
+	defer func() { time.Local = local }()
+
+	// UTC+5:30 local hours do not align with UTC hours
+	ts := time.Date(2023, 1, 1, 10, 45, 12, 0, loc)
+	assert.Equal(t, time.Date(2023, 1, 1, 10, 0, 0, 0, loc), truncateHour(ts))
+	assert.Equal(t, time.Date(2023, 1, 1, 10, 0, 0, 0, loc), truncateHour(ts.UTC()))
+}
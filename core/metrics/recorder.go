@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/exp/slices"
+	"gorm.io/gorm"
+)
+
+const (
+	recordInterval       = time.Minute
+	housekeepingInterval = time.Hour
+)
+
+// Keys are the site and loadpoint values recorded by default
+var Keys = []string{
+	"gridPower", "pvPower", "batteryPower", "batterySoc", "homePower",
+	"chargePower", "vehicleSoc",
+}
+
+// Retention defines how long raw samples and hourly aggregates are kept
+type Retention struct {
+	Raw    time.Duration
+	Hourly time.Duration
+}
+
+// DefaultRetention keeps raw samples for a week and hourly aggregates for two years
+var DefaultRetention = Retention{
+	Raw:    7 * 24 * time.Hour,
+	Hourly: 2 * 365 * 24 * time.Hour,
+}
+
+// accumulator averages values over the recording interval
+type accumulator struct {
+	sum   float64
+	count int
+}
+
+// Recorder persists averaged metrics and applies retention policies.
+// Samples are buffered in memory and written in batches to reduce storage wear.
+type Recorder struct {
+	mu        sync.Mutex
+	log       *util.Logger
+	clock     clock.Clock
+	db        *gorm.DB
+	retention Retention
+	flush     time.Duration
+	keys      []string
+	acc       map[string]*accumulator
+	pending   []sample
+}
+
+// NewRecorder creates a metrics recorder for the given database.
+// Buffered samples are written at the given flush interval.
+func NewRecorder(db *gorm.DB, retention Retention, flush time.Duration) (*Recorder, error) {
+	if err := migrate(db); err != nil {
+		return nil, err
+	}
+
+	if flush < recordInterval {
+		flush = recordInterval
+	}
+
+	r := &Recorder{
+		log:       util.NewLogger("metrics"),
+		clock:     clock.New(),
+		db:        db,
+		retention: retention,
+		flush:     flush,
+		keys:      Keys,
+		acc:       make(map[string]*accumulator),
+	}
+
+	return r, nil
+}
+
+// add accumulates numeric values of recorded keys
+func (r *Recorder) add(p util.Param) {
+	if !slices.Contains(r.keys, p.Key) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var val float64
+	switch v := p.Val.(type) {
+	case float64:
+		val = v
+	case int:
+		val = float64(v)
+	case int64:
+		val = float64(v)
+	default:
+		return
+	}
+
+	key := p.UniqueID()
+
+	a, ok := r.acc[key]
+	if !ok {
+		a = new(accumulator)
+		r.acc[key] = a
+	}
+
+	a.sum += val
+	a.count++
+}
+
+// record buffers the averaged values of the elapsed interval
+func (r *Recorder) record(ts time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, a := range r.acc {
+		if a.count > 0 {
+			r.pending = append(r.pending, sample{Key: key, Timestamp: ts, Value: a.sum / float64(a.count)})
+		}
+	}
+
+	r.acc = make(map[string]*accumulator)
+}
+
+// Persist writes all buffered samples to the database
+func (r *Recorder) Persist() {
+	r.mu.Lock()
+	samples := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	if len(samples) == 0 {
+		return
+	}
+
+	if err := r.db.CreateInBatches(samples, 100).Error; err != nil {
+		r.log.ERROR.Println("persist:", err)
+	}
+}
+
+// housekeeping downsamples raw data and applies retention
+func (r *Recorder) housekeeping() {
+	now := r.clock.Now()
+
+	// make sure buffered samples are included
+	r.Persist()
+
+	if err := downsample(r.db, now); err != nil {
+		r.log.ERROR.Println("downsample:", err)
+		return
+	}
+
+	if err := expire(r.db, now, r.retention); err != nil {
+		r.log.ERROR.Println("retention:", err)
+	}
+}
+
+// Run records metrics from the parameter stream
+func (r *Recorder) Run(in <-chan util.Param) {
+	record := r.clock.Ticker(recordInterval)
+	defer record.Stop()
+
+	flush := r.clock.Ticker(r.flush)
+	defer flush.Stop()
+
+	clean := r.clock.Ticker(housekeepingInterval)
+	defer clean.Stop()
+
+	r.housekeeping()
+
+	for {
+		select {
+		case p, ok := <-in:
+			if !ok {
+				r.record(r.clock.Now().Truncate(recordInterval))
+				r.Persist()
+				return
+			}
+			r.add(p)
+
+		case ts := <-record.C:
+			r.record(ts.Truncate(recordInterval))
+
+		case <-flush.C:
+			r.Persist()
+
+		case <-clean.C:
+			r.housekeeping()
+		}
+	}
+}
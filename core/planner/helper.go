@@ -33,9 +33,21 @@ func AverageCost(plan api.Rates) float64 {
 		duration += slotDuration
 		cost += float64(slotDuration) * slot.Price
 	}
+	if duration == 0 {
+		return 0
+	}
 	return cost / float64(duration)
 }
 
+// Cost returns the total cost of charging the plan at given power in W
+func Cost(plan api.Rates, power float64) float64 {
+	var cost float64
+	for _, slot := range plan {
+		cost += slot.End.Sub(slot.Start).Hours() * power / 1e3 * slot.Price
+	}
+	return cost
+}
+
 // SlotAt returns the slot for the given time or an empty slot
 func SlotAt(time time.Time, plan api.Rates) api.Rate {
 	for _, slot := range plan {
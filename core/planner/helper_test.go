@@ -47,3 +47,15 @@ func TestIsFirst(t *testing.T) {
 	// ensure single slot is always first
 	require.True(t, IsFirst(first, []api.Rate{first}))
 }
+
+func TestCost(t *testing.T) {
+	clock := clock.NewMock()
+	plan := rates([]float64{0.2, 0.4}, clock.Now(), time.Hour)
+
+	require.InDelta(t, 0.3, AverageCost(plan), 1e-6)
+	require.InDelta(t, 6.6, Cost(plan, 11000), 1e-6)
+
+	// empty plan
+	require.Zero(t, AverageCost(nil))
+	require.Zero(t, Cost(nil, 11000))
+}
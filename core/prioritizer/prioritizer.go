@@ -21,11 +21,11 @@ func (p *Prioritizer) UpdateChargePowerFlexibility(lp loadpoint.API) {
 }
 
 func (p *Prioritizer) GetChargePowerFlexibility(lp loadpoint.API) float64 {
-	prio := lp.Priority()
+	prio := lp.EffectivePriority()
 
 	var reduceBy float64
 	for lp, power := range p.demand {
-		if lp.Priority() < prio {
+		if lp.EffectivePriority() < prio {
 			reduceBy += power
 		}
 	}
@@ -14,10 +14,10 @@ func TestPrioritzer(t *testing.T) {
 	p := New()
 
 	lo := loadpoint.NewMockAPI(ctrl)
-	lo.EXPECT().Priority().Return(0).AnyTimes()
+	lo.EXPECT().EffectivePriority().Return(0).AnyTimes()
 
 	hi := loadpoint.NewMockAPI(ctrl)
-	hi.EXPECT().Priority().Return(1).AnyTimes()
+	hi.EXPECT().EffectivePriority().Return(1).AnyTimes()
 
 	// no additional power available
 	lo.EXPECT().GetChargePowerFlexibility().Return(300.0)
@@ -30,6 +30,9 @@ type Savings struct {
 	selfConsumptionCharged float64   // Self-produced energy charged since startup (kWh)
 	selfConsumptionCost    float64   // Running total of charged self-produced energy cost (e.g. EUR)
 	hasPublished           bool      // Has initial publish happened?
+
+	// optional grid price override, e.g. from grid meter tariff registers
+	gridPrice func() (float64, error)
 }
 
 func NewSavings(tariffs tariff.Tariffs) *Savings {
@@ -95,7 +98,12 @@ func (s *Savings) SavingsAmount() float64 {
 }
 
 func (s *Savings) currentGridPrice() float64 {
-	price, err := s.tariffs.CurrentGridPrice()
+	priceG := s.tariffs.CurrentGridPrice
+	if s.gridPrice != nil {
+		priceG = s.gridPrice
+	}
+
+	price, err := priceG()
 	if err != nil {
 		price = DefaultGridPrice
 	}
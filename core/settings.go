@@ -0,0 +1,53 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+// deviceSettings are the per-device settings by device class
+var deviceSettings = map[string][]string{
+	"loadpoint": {"schedules", "budget"},
+	"vehicle":   {"minSoc", "soc", "calibrated"},
+}
+
+// deviceSettingsKey is the settings key of a per-device setting.
+// Devices are identified by their persistent id, devices without id by their title.
+func deviceSettingsKey(class, id, title, key string) string {
+	if id == "" {
+		id = title
+	}
+	return class + "." + id + "." + key
+}
+
+// migrateDeviceSettings moves per-device settings stored by device title to the device id
+func migrateDeviceSettings(class, id, title string) error {
+	if id == "" {
+		return nil
+	}
+
+	for _, key := range deviceSettings[class] {
+		err := settings.Rename(deviceSettingsKey(class, "", title, key), deviceSettingsKey(class, id, "", key))
+		if err != nil && !errors.Is(err, settings.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// settingsKey is the settings key of a loadpoint setting
+func (lp *Loadpoint) settingsKey(key string) string {
+	return deviceSettingsKey("loadpoint", lp.id, lp.Title(), key)
+}
+
+// vehicleSettingsKey is the settings key of a vehicle setting
+func (lp *Loadpoint) vehicleSettingsKey(vehicle api.Vehicle, key string) string {
+	var id string
+	if lp.coordinator != nil {
+		id = lp.coordinator.DeviceID(vehicle)
+	}
+	return deviceSettingsKey("vehicle", id, vehicle.Title(), key)
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDeviceSettings(t *testing.T) {
+	// settings are not persisted
+	instance := serverdb.Instance
+	serverdb.Instance = nil
+	defer func() { serverdb.Instance = instance }()
+
+	settings.SetInt("vehicle.My Car.minSoc", 20)
+	settings.SetString("vehicle.My Car.calibrated", "foo")
+	settings.SetString("vehicle.id.calibrated", "bar")
+
+	require.NoError(t, migrateDeviceSettings("vehicle", "id", "My Car"))
+
+	soc, err := settings.Int("vehicle.id.minSoc")
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), soc)
+
+	// settings stored by id take precedence
+	calibrated, _ := settings.String("vehicle.id.calibrated")
+	assert.Equal(t, "bar", calibrated)
+
+	// title keys are migrated once
+	_, err = settings.Int("vehicle.My Car.minSoc")
+	assert.ErrorIs(t, err, settings.ErrNotFound)
+	_, err = settings.String("vehicle.My Car.calibrated")
+	assert.ErrorIs(t, err, settings.ErrNotFound)
+
+	// devices without id keep their title keys
+	assert.Equal(t, "vehicle.My Car.soc", deviceSettingsKey("vehicle", "", "My Car", "soc"))
+	assert.Equal(t, "vehicle.id.soc", deviceSettingsKey("vehicle", "id", "My Car", "soc"))
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/telemetry"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 const standbyPower = 10 // consider less than 10W as charger in standby
@@ -46,6 +48,7 @@ type batteryMeasurement struct {
 // Site is the main configuration container. A site can host multiple loadpoints.
 type Site struct {
 	uiChan       chan<- util.Param // client push messages
+	pushChan     chan<- push.Event // push notifications
 	lpUpdateChan chan *Loadpoint
 
 	*Health
@@ -54,15 +57,29 @@ type Site struct {
 	log *util.Logger
 
 	// configuration
-	Title                             string       `mapstructure:"title"`         // UI title
-	Voltage                           float64      `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
-	ResidualPower                     float64      `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
-	Meters                            MetersConfig // Meter references
-	PrioritySoc                       float64      `mapstructure:"prioritySoc"`                       // prefer battery up to this Soc
-	BufferSoc                         float64      `mapstructure:"bufferSoc"`                         // continue charging on battery above this Soc
-	BufferStartSoc                    float64      `mapstructure:"bufferStartSoc"`                    // start charging on battery above this Soc
-	MaxGridSupplyWhileBatteryCharging float64      `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
-	SmartCostLimit                    float64      `mapstructure:"smartCostLimit"`                    // always charge if cost is below this value
+	Title                             string                      `mapstructure:"title"`         // UI title
+	Voltage                           float64                     `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
+	ResidualPower                     float64                     `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
+	Meters                            MetersConfig                // Meter references
+	PrioritySoc                       float64                     `mapstructure:"prioritySoc"`                       // prefer battery up to this Soc
+	BatteryPriority                   string                      `mapstructure:"batteryPriority"`                   // soc, vehicle or battery
+	BufferSoc                         float64                     `mapstructure:"bufferSoc"`                         // continue charging on battery above this Soc
+	BufferStartSoc                    float64                     `mapstructure:"bufferStartSoc"`                    // start charging on battery above this Soc
+	MaxGridSupplyWhileBatteryCharging float64                     `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
+	SmartCostLimit                    float64                     `mapstructure:"smartCostLimit"`                    // always charge if cost is below this value
+	Capacity                          CapacityConfig              `mapstructure:"capacity"`                          // off-grid source capacity
+	Frequency                         FrequencyConfig             `mapstructure:"frequency"`                         // grid frequency curtailment
+	Identifiers                       map[string]string           `mapstructure:"identifiers"`                       // charger-reported identifiers to vehicle references
+	Profiles                          map[string]api.ActionConfig `mapstructure:"profiles"`                          // named loadpoint settings applied to all loadpoints
+	ProfileSchedule                   []ProfileScheduleConfig     `mapstructure:"profileSchedule"`                   // yearly profile switches
+	AuxLoads                          []AuxLoadConfig             `mapstructure:"auxLoads"`                          // relay switched consumers using remaining pv surplus
+	BatteryDischargeControl           bool                        `mapstructure:"batteryDischargeControl"`           // inhibit battery discharge while fast charging
+	TariffRegisters                   []float64                   `mapstructure:"tariffRegisters"`                   // grid prices of meter tariff registers 1 and 2 (HT/NT)
+	ExportLimit                       ExportLimitConfig           `mapstructure:"exportLimit"`                       // raise charging power if pv is curtailed at the export limit
+	Circuits                          []CircuitConfig             `mapstructure:"circuits"`                          // shared fuses limiting the total current of their loadpoints
+	DryRun                            bool                        `mapstructure:"dryRun"`                            // run control loop without controlling devices
+	Smoothing                         SmoothingConfig             `mapstructure:"smoothing"`                         // grid and pv power filter for the surplus calculation
+	Budgets                           []BudgetConfig              `mapstructure:"budgets"`                           // monthly charging energy budgets per loadpoint
 
 	// meters
 	gridMeter     api.Meter   // Grid usage meter
@@ -75,12 +92,30 @@ type Site struct {
 	coordinator *coordinator.Coordinator // Vehicles
 	prioritizer *prioritizer.Prioritizer // Power budgets
 	savings     *Savings                 // Savings
+	frequency   *frequencyGuard          // Grid frequency curtailment
+	stale       *staleGuard              // Meter data staleness
+	clockJump   *clockGuard              // System clock jump detection
+	auxLoads    []*auxLoad               // Relay switched consumers
+	registers   tariffRegisters          // Grid meter tariff registers
+	gridFilter  *powerFilter             // Grid power smoothing
+	pvFilter    *powerFilter             // PV power smoothing
 
 	// cached state
-	gridPower    float64 // Grid power
-	pvPower      float64 // PV power
-	batteryPower float64 // Battery charge power
-	batterySoc   float64 // Battery soc
+	gridPower          float64         // Grid power
+	pvPower            float64         // PV power
+	batteryPower       float64         // Battery charge power
+	gridCurrents       []float64       // Grid phase currents
+	batterySoc         float64         // Battery soc
+	batteryMode        api.BatteryMode // Battery operation mode
+	batteryModeUpdated time.Time       // Last battery mode write
+	profile            string          // Active mode profile
+
+	profileSchedule  []profileSchedule // parsed profile schedule
+	profileScheduled string            // profile currently scheduled
+
+	exportLimitCurtailed bool // pv curtailment estimated from grid export
+
+	vehicleRefreshed map[api.Vehicle]time.Time // last forced vehicle refresh
 
 	publishCache map[string]any // store last published values to avoid unnecessary republishing
 }
@@ -101,7 +136,7 @@ func NewSiteFromConfig(
 	cp configProvider,
 	other map[string]interface{},
 	loadpoints []*Loadpoint,
-	vehicles []api.Vehicle,
+	vehicles map[string]api.Vehicle,
 	tariffs tariff.Tariffs,
 ) (*Site, error) {
 	site := NewSite()
@@ -112,9 +147,28 @@ func NewSiteFromConfig(
 	Voltage = site.Voltage
 	site.loadpoints = loadpoints
 	site.tariffs = tariffs
-	site.coordinator = coordinator.New(log, vehicles)
+	// vehicles ordered by name
+	names := maps.Keys(vehicles)
+	slices.Sort(names)
+
+	ordered := make([]api.Vehicle, 0, len(names))
+	for _, name := range names {
+		ordered = append(ordered, vehicles[name])
+	}
+
+	site.coordinator = coordinator.New(log, ordered)
+	for id, ref := range site.Identifiers {
+		vehicle, err := cp.Vehicle(ref)
+		if err != nil {
+			return nil, fmt.Errorf("identifier %s: %w", id, err)
+		}
+		site.coordinator.AddIdentifier(id, vehicle)
+	}
 	site.prioritizer = prioritizer.New()
 	site.savings = NewSavings(tariffs)
+	site.frequency.FrequencyConfig = site.Frequency
+	site.gridFilter = newPowerFilter(site.Smoothing.Grid, site.Smoothing.Hysteresis)
+	site.pvFilter = newPowerFilter(site.Smoothing.PV, 0)
 
 	site.restoreSettings()
 
@@ -127,22 +181,61 @@ func NewSiteFromConfig(
 
 	tariff := site.GetTariff(PlannerTariff)
 
+	if site.DryRun {
+		site.log.WARN.Println("dry run: chargers, vehicles, relays and batteries will not be controlled")
+	}
+
 	// give loadpoints access to vehicles and database
 	for _, lp := range loadpoints {
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
 		lp.planner = planner.New(lp.log, tariff)
+		lp.dryRun = site.DryRun
+
+		// charging at home is tracked by the on-site loadpoint
+		if vc, ok := lp.charger.(*vehicleCharger); ok {
+			vc.onSite = func() bool { return site.vehicleOnSite(vc.vehicle) }
+		}
 
 		if serverdb.Instance != nil {
-			var err error
-			if lp.db, err = db.New(lp.Title()); err != nil {
+			sessionDB, err := db.New(lp.Title())
+			if err == nil && lp.id != "" {
+				err = sessionDB.SetID(lp.id)
+			}
+			if err != nil {
 				return nil, err
 			}
+			lp.db = sessionDB
 
 			// NOTE: this requires stopSession to respect async access
 			shutdown.Register(lp.stopSession)
 		}
 	}
 
+	if err := site.configureProfiles(); err != nil {
+		return nil, err
+	}
+
+	// persistent vehicle ids, sessions and settings recorded by vehicle title are linked to the id
+	for _, name := range names {
+		id := cp.DeviceID("vehicle", name)
+		if id == "" {
+			continue
+		}
+
+		vehicle := vehicles[name]
+		site.coordinator.SetDeviceID(vehicle, id)
+
+		if err := migrateDeviceSettings("vehicle", id, vehicle.Title()); err != nil {
+			return nil, err
+		}
+
+		if serverdb.Instance != nil && len(loadpoints) > 0 {
+			if err := db.AdoptVehicle(id, vehicle.Title()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// grid meter
 	if site.Meters.GridMeterRef != "" {
 		var err error
@@ -192,6 +285,36 @@ func NewSiteFromConfig(
 		site.auxMeters = append(site.auxMeters, meter)
 	}
 
+	// auxiliary loads
+	for _, cc := range site.AuxLoads {
+		relay, err := cp.Charger(cc.Charger)
+		if err != nil {
+			return nil, fmt.Errorf("aux load %s: %w", cc.Title, err)
+		}
+
+		enabled, err := relay.Enabled()
+		if err != nil {
+			return nil, fmt.Errorf("aux load %s: %w", cc.Title, err)
+		}
+
+		if cc.Delay == 0 {
+			cc.Delay = time.Minute
+		}
+
+		if cc.Mode == "" {
+			cc.Mode = api.ModePV
+		}
+		if !slices.Contains(auxLoadModes, cc.Mode) {
+			return nil, fmt.Errorf("aux load %s: invalid mode: %s", cc.Title, cc.Mode)
+		}
+
+		site.auxLoads = append(site.auxLoads, &auxLoad{
+			AuxLoadConfig: cc,
+			relay:         relay,
+			enabled:       enabled,
+		})
+	}
+
 	// configure meter from references
 	if site.gridMeter == nil && len(site.pvMeters) == 0 {
 		return nil, errors.New("missing either grid or pv meter")
@@ -201,6 +324,32 @@ func NewSiteFromConfig(
 		site.log.WARN.Println("bufferStartSoc must be larger than bufferSoc")
 	}
 
+	if site.BatteryPriority != "" && !slices.Contains(BatteryPriorities, site.BatteryPriority) {
+		return nil, fmt.Errorf("invalid battery priority: %s", site.BatteryPriority)
+	}
+
+	if len(site.TariffRegisters) > 0 {
+		if len(site.TariffRegisters) != 2 {
+			return nil, errors.New("tariff registers: need one price per register, total two")
+		}
+
+		if api.GetMeterCapabilities(site.gridMeter).TariffEnergy == nil {
+			return nil, errors.New("tariff registers: grid meter does not provide tariff energy")
+		}
+
+		site.savings.gridPrice = site.currentGridPrice
+	}
+
+	site.restoreTariffRegisters()
+
+	if err := site.configureCircuits(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configureBudgets(); err != nil {
+		return nil, err
+	}
+
 	if site.BufferSoc != 0 && site.BufferSoc <= site.PrioritySoc {
 		site.log.WARN.Println("bufferSoc must be larger than prioritySoc")
 	}
@@ -213,6 +362,9 @@ func NewSite() *Site {
 	lp := &Site{
 		log:          util.NewLogger("site"),
 		publishCache: make(map[string]any),
+		frequency:    new(frequencyGuard),
+		stale:        new(staleGuard),
+		clockJump:    new(clockGuard),
 		Voltage:      230, // V
 	}
 
@@ -241,6 +393,9 @@ func (site *Site) restoreSettings() {
 	if v, err := settings.Float("site.smartCostLimit"); err == nil {
 		site.SmartCostLimit = v
 	}
+	if v, err := settings.String("site.batteryPriority"); err == nil && v != "" {
+		site.BatteryPriority = v
+	}
 }
 
 func meterCapabilities(name string, meter interface{}) string {
@@ -361,6 +516,14 @@ func (site *Site) publishDelta(key string, val interface{}) {
 	site.publish(key, val)
 }
 
+// dryRunCommand logs the command in dry run mode and returns true if it must not be executed
+func (site *Site) dryRunCommand(format string, v ...any) bool {
+	if site.DryRun {
+		site.log.INFO.Printf("dry run: "+format, v...)
+	}
+	return site.DryRun
+}
+
 // updateMeter updates and publishes single meter
 func (site *Site) updateMeter(meter api.Meter, power *float64) func() error {
 	return func() error {
@@ -394,6 +557,7 @@ func (site *Site) updateMeters() error {
 	}
 
 	if len(site.pvMeters) > 0 {
+		var pvErr error
 		site.pvPower = 0
 		mm := make([]meterMeasurement, len(site.pvMeters))
 
@@ -410,11 +574,13 @@ func (site *Site) updateMeters() error {
 					site.log.WARN.Printf("pv %d power: %.0fW is negative - check configuration if sign is correct", i+1, power)
 				}
 			} else {
-				err = fmt.Errorf("pv %d power: %v", i+1, err)
-				site.log.ERROR.Println(err)
+				pvErr = fmt.Errorf("pv %d power: %v", i+1, err)
+				site.log.ERROR.Println(pvErr)
 			}
 		}
 
+		site.updateStale("pv", pvErr)
+
 		site.log.DEBUG.Printf("pv power: %.0fW", site.pvPower)
 		site.publish("pvPower", site.pvPower)
 
@@ -427,6 +593,7 @@ func (site *Site) updateMeters() error {
 		site.batterySoc = 0
 
 		mm := make([]batteryMeasurement, len(site.batteryMeters))
+		var batteryErr error
 
 		for i, meter := range site.batteryMeters {
 			var power float64
@@ -440,6 +607,7 @@ func (site *Site) updateMeters() error {
 					site.log.DEBUG.Printf("battery %d power: %.0fW", i+1, power)
 				}
 			} else {
+				batteryErr = err
 				site.log.ERROR.Printf("battery %d power: %v", i+1, err)
 			}
 
@@ -485,9 +653,13 @@ func (site *Site) updateMeters() error {
 		site.publish("batteryPower", site.batteryPower)
 
 		site.publish("battery", mm)
+		site.updateStale("battery", batteryErr)
 	}
 
 	err := retryMeter("grid", site.gridMeter, &site.gridPower)
+	if site.gridMeter != nil {
+		site.updateStale("grid", err)
+	}
 
 	// powers
 	var p1, p2, p3 float64
@@ -503,6 +675,7 @@ func (site *Site) updateMeters() error {
 	}
 
 	// currents
+	site.gridCurrents = nil
 	if phaseMeter, ok := site.gridMeter.(api.PhaseCurrents); err == nil && ok {
 		var i1, i2, i3 float64
 		i1, i2, i3, err = phaseMeter.Currents()
@@ -510,11 +683,17 @@ func (site *Site) updateMeters() error {
 			phases := []float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}
 			site.log.DEBUG.Printf("grid currents: %.3gA", phases)
 			site.publish("gridCurrents", phases)
+			site.gridCurrents = phases
 		} else {
 			err = fmt.Errorf("grid currents: %w", err)
 		}
 	}
 
+	// frequency
+	if err == nil {
+		site.updateFrequency()
+	}
+
 	// energy
 	if energyMeter, ok := site.gridMeter.(api.MeterEnergy); err == nil && ok {
 		val, err := energyMeter.TotalEnergy()
@@ -525,6 +704,11 @@ func (site *Site) updateMeters() error {
 		}
 	}
 
+	// tariff registers
+	if err == nil {
+		site.updateTariffRegisters()
+	}
+
 	return err
 }
 
@@ -537,9 +721,11 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		return 0, false, false, err
 	}
 
+	now := time.Now()
+
 	// allow using PV as estimate for grid power
 	if site.gridMeter == nil {
-		site.gridPower = totalChargePower - site.pvPower
+		site.gridPower = totalChargePower - site.pvFilter.add(site.pvPower, now)
 	}
 
 	// allow using grid and charge as estimate for pv power
@@ -562,8 +748,8 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		site.Lock()
 		defer site.Unlock()
 
-		// if battery is charging below prioritySoc give it priority
-		if site.batterySoc < site.PrioritySoc && batteryPower < 0 {
+		// if battery is charging with priority don't use its charge power for vehicles
+		if site.batteryPriority() && batteryPower < 0 {
 			site.log.DEBUG.Printf("giving priority to battery charging at soc: %.0f%%", site.batterySoc)
 			batteryPower = 0
 		} else {
@@ -573,7 +759,13 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		}
 	}
 
-	sitePower := sitePower(site.log, site.MaxGridSupplyWhileBatteryCharging, site.gridPower, batteryPower, site.ResidualPower)
+	// smooth short-term fluctuations like passing clouds or kettle spikes
+	gridPower := site.gridFilter.add(site.gridPower, now)
+	if gridPower != site.gridPower {
+		site.log.DEBUG.Printf("smoothed grid power: %.0fW", gridPower)
+	}
+
+	sitePower := sitePower(site.log, site.MaxGridSupplyWhileBatteryCharging, gridPower, batteryPower, site.ResidualPower)
 
 	// deduct smart loads
 	if len(site.auxMeters) > 0 {
@@ -598,6 +790,9 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		site.publish("aux", mm)
 	}
 
+	// uncover curtailed pv power
+	sitePower -= site.exportLimitBoost()
+
 	// handle priority
 	if flexiblePower > 0 {
 		site.log.DEBUG.Printf("giving loadpoint priority for additional: %.0fW", flexiblePower)
@@ -626,9 +821,56 @@ func (site *Site) greenShare() float64 {
 	return share
 }
 
+// batteryShare returns the share of home battery discharge in the site's energy sources.
+// The battery share is part of the green share.
+func (site *Site) batteryShare() float64 {
+	batteryDischarge := math.Max(0, site.batteryPower)
+	batteryCharge := -math.Min(0, site.batteryPower)
+	pvConsumption := math.Min(site.pvPower, site.pvPower+site.gridPower-batteryCharge)
+
+	gridImport := math.Max(0, site.gridPower)
+	selfConsumption := math.Max(0, batteryDischarge+pvConsumption+batteryCharge)
+
+	share := batteryDischarge / (gridImport + selfConsumption)
+
+	if math.IsNaN(share) {
+		return 0
+	}
+
+	return share
+}
+
+// batteryPriorityMode returns the effective battery priority mode
+func (site *Site) batteryPriorityMode() string {
+	if site.BatteryPriority == "" {
+		return BatteryPrioritySoc
+	}
+	return site.BatteryPriority
+}
+
+// batteryPriority returns true if battery charging has priority over vehicle charging
+func (site *Site) batteryPriority() bool {
+	switch site.batteryPriorityMode() {
+	case BatteryPriorityBattery:
+		return true
+	case BatteryPriorityVehicle:
+		return false
+	default:
+		return site.batterySoc < site.PrioritySoc
+	}
+}
+
+// gridPrice returns the current grid price if available
+func (s *Site) gridPrice() *float64 {
+	if grid, err := s.currentGridPrice(); err == nil {
+		return &grid
+	}
+	return nil
+}
+
 // effectivePrice calculates the real energy price based on self-produced and grid-imported energy.
 func (s *Site) effectivePrice(greenShare float64) *float64 {
-	if grid, err := s.tariffs.CurrentGridPrice(); err == nil {
+	if grid, err := s.currentGridPrice(); err == nil {
 		feedin, err := s.tariffs.CurrentFeedInPrice()
 		if err != nil {
 			feedin = 0
@@ -648,12 +890,47 @@ func (s *Site) effectiveCo2(greenShare float64) *float64 {
 	return nil
 }
 
+// greenShareLoadpoints returns the share of self-produced energy in the loadpoints' charge power.
+// Household consumption is assumed to be covered by self-produced energy first.
+func (s *Site) greenShareLoadpoints(totalChargePower float64) float64 {
+	if totalChargePower <= 0 {
+		return 0
+	}
+
+	consumption := s.gridPower + math.Max(0, s.pvPower) + s.batteryPower
+	selfProduced := consumption - math.Max(0, s.gridPower)
+	homePower := math.Max(0, consumption-totalChargePower)
+
+	share := (selfProduced - homePower) / totalChargePower
+
+	return math.Max(0, math.Min(1, share))
+}
+
+// publishLoadpointTariffs publishes price and co2 of the energy currently charged by each loadpoint
+func (s *Site) publishLoadpointTariffs(totalChargePower float64) {
+	greenShare := s.greenShareLoadpoints(totalChargePower)
+	price, co2 := s.effectivePrice(greenShare), s.effectiveCo2(greenShare)
+
+	for _, lp := range s.loadpoints {
+		if lp.Virtual || !lp.charging() {
+			lp.publish("chargeGreenShare", nil)
+			lp.publish("chargePrice", nil)
+			lp.publish("chargeCo2", nil)
+			continue
+		}
+
+		lp.publish("chargeGreenShare", greenShare)
+		lp.publish("chargePrice", price)
+		lp.publish("chargeCo2", co2)
+	}
+}
+
 func (s *Site) publishTariffs() {
 	greenShare := s.greenShare()
 
 	s.publish("greenShare", greenShare)
 
-	if gridPrice, err := s.tariffs.CurrentGridPrice(); err == nil {
+	if gridPrice, err := s.currentGridPrice(); err == nil {
 		s.publishDelta("tariffGrid", gridPrice)
 	}
 	if feedInPrice, err := s.tariffs.CurrentFeedInPrice(); err == nil {
@@ -673,15 +950,26 @@ func (s *Site) publishTariffs() {
 func (site *Site) update(lp Updater) {
 	site.log.DEBUG.Println("----")
 
+	site.updateClock()
+	site.updateProfileSchedule(time.Now())
+	site.updateVehicles()
+
 	// update all loadpoint's charge power
 	var totalChargePower float64
 	for _, lp := range site.loadpoints {
 		lp.UpdateChargePower()
+		if lp.Virtual {
+			continue
+		}
+
 		totalChargePower += lp.GetChargePower()
 
 		site.prioritizer.UpdateChargePowerFlexibility(lp)
 	}
 
+	site.publishGroups()
+	site.publishCircuits()
+
 	// prioritize if possible
 	var flexiblePower float64
 	if lp.GetMode() == api.ModePV {
@@ -707,13 +995,36 @@ func (site *Site) update(lp Updater) {
 
 	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
 		greenShare := site.greenShare()
+
+		if lp, ok := lp.(*Loadpoint); ok {
+			// limit charging to source capacity
+			site.updateCapacity(lp)
+
+			// limit charging to shared circuit
+			site.updateCircuit(lp)
+
+			// reference for session savings
+			lp.sessionEnergy.SetGridPrice(site.gridPrice())
+
+			// session energy source attribution
+			lp.sessionEnergy.SetBatteryShare(site.batteryShare())
+		}
+
 		lp.Update(sitePower, autoCharge, batteryBuffered, batteryStart, greenShare, site.effectivePrice(greenShare), site.effectiveCo2(greenShare))
 
+		// use remaining surplus for auxiliary loads
+		site.updateAuxLoads()
+
+		// prevent battery from discharging into ev
+		site.updateBatteryMode()
+
 		// ignore negative pvPower values as that means it is not an energy source but consumption
 		homePower := site.gridPower + math.Max(0, site.pvPower) + site.batteryPower - totalChargePower
 		homePower = math.Max(homePower, 0)
 		site.publish("homePower", homePower)
 
+		site.publishLoadpointTariffs(totalChargePower)
+
 		site.Health.Update()
 	}
 
@@ -737,8 +1048,12 @@ func (site *Site) prepare() {
 	site.publish("bufferSoc", site.BufferSoc)
 	site.publish("bufferStartSoc", site.BufferStartSoc)
 	site.publish("prioritySoc", site.PrioritySoc)
+	site.publish("batteryPriority", site.batteryPriorityMode())
 	site.publish("residualPower", site.ResidualPower)
+	site.publish("dryRun", site.DryRun)
 	site.publish("smartCostLimit", site.SmartCostLimit)
+	site.publish("profiles", site.GetProfiles())
+	site.publish("profile", site.profile)
 	site.publish("smartCostType", nil)
 	if tariff := site.GetTariff(PlannerTariff); tariff != nil {
 		site.publish("smartCostType", tariff.Type().String())
@@ -747,11 +1062,18 @@ func (site *Site) prepare() {
 	site.publish("savingsSince", site.savings.Since())
 
 	site.publish("vehicles", vehicleTitles(site.GetVehicles()))
+
+	for id, a := range site.auxLoads {
+		site.publishAuxLoad(id, "title", a.Title)
+		site.publishAuxLoad(id, "mode", a.Mode)
+		site.publishAuxLoad(id, "priority", a.Priority)
+	}
 }
 
 // Prepare attaches communication channels to site and loadpoints
 func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event) {
 	site.uiChan = uiChan
+	site.pushChan = pushChan
 	site.lpUpdateChan = make(chan *Loadpoint, 1) // 1 capacity to avoid deadlock
 
 	site.prepare()
@@ -791,6 +1113,7 @@ func (site *Site) loopLoadpoints(next chan<- Updater) {
 // updating measurements and executing control logic.
 func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 	site.Health = NewHealth(time.Minute + interval)
+	site.stale.timeout = 3 * interval
 
 	loadpointChan := make(chan Updater)
 	go site.loopLoadpoints(loadpointChan)
@@ -805,6 +1128,12 @@ func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 		case lp := <-site.lpUpdateChan:
 			site.update(lp)
 		case <-stopC:
+			// release battery lock on shutdown
+			if site.batteryMode == api.BatteryHold {
+				if err := site.setBatteryMode(api.BatteryNormal); err != nil {
+					site.log.ERROR.Println("battery mode:", err)
+				}
+			}
 			return
 		}
 	}
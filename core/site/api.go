@@ -2,6 +2,7 @@ package site
 
 import (
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/coordinator"
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
@@ -9,6 +10,11 @@ import (
 type API interface {
 	Healthy() bool
 	Loadpoints() []loadpoint.API
+	// GetGroups returns the loadpoint titles by group
+	GetGroups() map[string][]string
+
+	// GetTitle returns the site title
+	GetTitle() string
 
 	//
 	// battery
@@ -20,6 +26,9 @@ type API interface {
 	SetBufferStartSoc(float64) error
 	GetPrioritySoc() float64
 	SetPrioritySoc(float64) error
+	// GetBatteryPriority returns the battery priority mode (soc, vehicle or battery)
+	GetBatteryPriority() string
+	SetBatteryPriority(string) error
 
 	//
 	// power and energy
@@ -28,12 +37,36 @@ type API interface {
 	GetResidualPower() float64
 	SetResidualPower(float64) error
 
+	//
+	// auxiliary loads
+	//
+
+	// GetAuxLoadTitles returns the titles of the auxiliary loads
+	GetAuxLoadTitles() []string
+	// SetAuxLoadMode sets the mode of the auxiliary load by index
+	SetAuxLoadMode(int, api.ChargeMode) error
+	// SetAuxLoadPriority sets the priority of the auxiliary load by index
+	SetAuxLoadPriority(int, int) error
+
+	//
+	// profiles
+	//
+
+	// GetProfiles returns the names of the configured mode profiles
+	GetProfiles() []string
+	GetProfile() string
+	SetProfile(string) error
+
 	//
 	// vehicles
 	//
 
 	// GetVehicles is the list of vehicles
 	GetVehicles() []api.Vehicle
+	// GetVehicleHealth returns the api health status by vehicle title
+	GetVehicleHealth() map[string]coordinator.Health
+	// RefreshVehicle forces an immediate vehicle data update, rate limited per vehicle
+	RefreshVehicle(api.Vehicle) error
 
 	//
 	// tariffs and costs
@@ -2,20 +2,42 @@ package core
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/coordinator"
 	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/server/db/settings"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 var _ site.API = (*Site)(nil)
 
+// GetTitle returns the site title
+func (site *Site) GetTitle() string {
+	site.Lock()
+	defer site.Unlock()
+	return site.Title
+}
+
 const (
 	GridTariff    = "grid"
 	FeedinTariff  = "feedin"
 	PlannerTariff = "planner"
 )
 
+const (
+	BatteryPrioritySoc     = "soc"     // battery has priority below prioritySoc
+	BatteryPriorityVehicle = "vehicle" // vehicles have priority
+	BatteryPriorityBattery = "battery" // battery has priority
+)
+
+// BatteryPriorities are the valid battery priority modes
+var BatteryPriorities = []string{BatteryPrioritySoc, BatteryPriorityVehicle, BatteryPriorityBattery}
+
 // GetPrioritySoc returns the PrioritySoc
 func (site *Site) GetPrioritySoc() float64 {
 	site.Lock()
@@ -39,6 +61,33 @@ func (site *Site) SetPrioritySoc(soc float64) error {
 	return nil
 }
 
+// GetBatteryPriority returns the battery priority mode
+func (site *Site) GetBatteryPriority() string {
+	site.Lock()
+	defer site.Unlock()
+	return site.batteryPriorityMode()
+}
+
+// SetBatteryPriority sets the battery priority mode
+func (site *Site) SetBatteryPriority(mode string) error {
+	site.Lock()
+	defer site.Unlock()
+
+	if len(site.batteryMeters) == 0 {
+		return errors.New("battery not configured")
+	}
+
+	if !slices.Contains(BatteryPriorities, mode) {
+		return fmt.Errorf("invalid battery priority: %s", mode)
+	}
+
+	site.BatteryPriority = mode
+	settings.SetString("site.batteryPriority", site.BatteryPriority)
+	site.publish("batteryPriority", site.BatteryPriority)
+
+	return nil
+}
+
 // GetBufferSoc returns the BufferSoc
 func (site *Site) GetBufferSoc() float64 {
 	site.Lock()
@@ -122,6 +171,97 @@ func (site *Site) SetSmartCostLimit(val float64) error {
 	return nil
 }
 
+// GetAuxLoadTitles returns the titles of the auxiliary loads
+func (site *Site) GetAuxLoadTitles() []string {
+	res := make([]string, 0, len(site.auxLoads))
+	for _, a := range site.auxLoads {
+		res = append(res, a.Title)
+	}
+
+	return res
+}
+
+// SetAuxLoadMode sets the mode of the auxiliary load
+func (site *Site) SetAuxLoadMode(id int, mode api.ChargeMode) error {
+	site.Lock()
+	defer site.Unlock()
+
+	if id < 0 || id >= len(site.auxLoads) {
+		return fmt.Errorf("invalid aux load: %d", id+1)
+	}
+
+	if !slices.Contains(auxLoadModes, mode) {
+		return fmt.Errorf("invalid mode: %s", mode)
+	}
+
+	a := site.auxLoads[id]
+	site.log.DEBUG.Printf("aux load %s: set mode: %s", a.Title, mode)
+	a.Mode = mode
+	site.publishAuxLoad(id, "mode", mode)
+
+	return nil
+}
+
+// SetAuxLoadPriority sets the priority of the auxiliary load
+func (site *Site) SetAuxLoadPriority(id, prio int) error {
+	site.Lock()
+	defer site.Unlock()
+
+	if id < 0 || id >= len(site.auxLoads) {
+		return fmt.Errorf("invalid aux load: %d", id+1)
+	}
+
+	if prio < 0 {
+		return fmt.Errorf("invalid priority: %d", prio)
+	}
+
+	a := site.auxLoads[id]
+	site.log.DEBUG.Printf("aux load %s: set priority: %d", a.Title, prio)
+	a.Priority = prio
+	site.publishAuxLoad(id, "priority", prio)
+
+	return nil
+}
+
+// GetProfiles returns the names of the configured mode profiles
+func (site *Site) GetProfiles() []string {
+	res := maps.Keys(site.Profiles)
+	slices.Sort(res)
+	return res
+}
+
+// GetProfile returns the active mode profile
+func (site *Site) GetProfile() string {
+	site.Lock()
+	defer site.Unlock()
+	return site.profile
+}
+
+// SetProfile applies the named mode profile to all loadpoints
+func (site *Site) SetProfile(name string) error {
+	profile, ok := site.Profiles[name]
+	if !ok {
+		return fmt.Errorf("invalid profile: %s", name)
+	}
+
+	site.log.DEBUG.Printf("set profile: %s (%v)", name, profile)
+
+	// loadpoints re-apply the profile after disconnect and vehicle defaults
+	for _, lp := range site.loadpoints {
+		lp.setProfile(&profile)
+		lp.applyProfile()
+	}
+
+	site.Lock()
+	defer site.Unlock()
+
+	site.profile = name
+	site.publish("profile", site.profile)
+	settings.SetString(profileKey, name)
+
+	return nil
+}
+
 // GetVehicles is the list of vehicles
 func (site *Site) GetVehicles() []api.Vehicle {
 	site.Lock()
@@ -129,6 +269,43 @@ func (site *Site) GetVehicles() []api.Vehicle {
 	return site.coordinator.GetVehicles()
 }
 
+// GetVehicleHealth returns the api health status by vehicle title
+func (site *Site) GetVehicleHealth() map[string]coordinator.Health {
+	return site.coordinator.Health()
+}
+
+// vehicleRefreshInterval is the minimum interval between forced vehicle refreshes
+const vehicleRefreshInterval = time.Minute
+
+// RefreshVehicle forces an immediate vehicle data update bypassing the cache interval once
+func (site *Site) RefreshVehicle(v api.Vehicle) error {
+	site.Lock()
+	if site.vehicleRefreshed == nil {
+		site.vehicleRefreshed = make(map[api.Vehicle]time.Time)
+	}
+
+	if time.Since(site.vehicleRefreshed[v]) < vehicleRefreshInterval {
+		site.Unlock()
+		return api.ErrMustRetry
+	}
+
+	site.vehicleRefreshed[v] = time.Now()
+	site.Unlock()
+
+	site.log.DEBUG.Printf("vehicle api refresh: %s", v.Title())
+	if !provider.ResetCachedScope(v) {
+		site.log.DEBUG.Printf("vehicle api refresh: %s has no cache scope, using cached data", v.Title())
+	}
+
+	for _, lp := range site.loadpoints {
+		if lp.GetVehicle() == v {
+			lp.refreshVehicle()
+		}
+	}
+
+	return nil
+}
+
 // GetTariff returns the respective tariff if configured or nil
 func (site *Site) GetTariff(tariff string) api.Tariff {
 	site.Lock()
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// AuxLoadConfig is the configuration of a relay switched consumer like a SG-Ready heat pump
+type AuxLoadConfig struct {
+	Title     string         `mapstructure:"title"`     // display name for UI
+	Charger   string         `mapstructure:"charger"`   // switch socket or relay charger reference
+	Power     float64        `mapstructure:"power"`     // nominal consumption (W)
+	Threshold float64        `mapstructure:"threshold"` // additional surplus required for switching on and grid import tolerated before switching off (W)
+	Delay     time.Duration  `mapstructure:"delay"`     // duration the switching condition must persist
+	Priority  int            `mapstructure:"priority"`  // surplus is used by loadpoints of same or higher priority first
+	Mode      api.ChargeMode `mapstructure:"mode"`      // off, now or pv (default)
+}
+
+// auxLoadModes are the supported auxiliary load modes
+var auxLoadModes = []api.ChargeMode{api.ModeOff, api.ModeNow, api.ModePV}
+
+// auxLoad is an on/off consumer switched by pv surplus after ev demand is met
+type auxLoad struct {
+	AuxLoadConfig
+	relay   api.Charger
+	enabled bool
+	pending time.Time // start of pending switching condition
+}
+
+// update evaluates the grid power and returns true if the load should be switched.
+// Load is switched on when export exceeds its power plus threshold and no ev can use the surplus.
+// It is switched off when grid import exceeds the threshold or an ev could use its power.
+func (a *auxLoad) update(gridPower float64, evDemand bool, now time.Time) bool {
+	var cond bool
+	if a.enabled {
+		cond = gridPower > a.Threshold || evDemand
+	} else {
+		cond = -gridPower >= a.Power+a.Threshold && !evDemand
+	}
+
+	if !cond {
+		a.pending = time.Time{}
+		return false
+	}
+
+	if a.pending.IsZero() {
+		a.pending = now
+	}
+
+	if now.Sub(a.pending) < a.Delay {
+		return false
+	}
+
+	a.pending = time.Time{}
+	return true
+}
+
+// evSurplusDemand returns true if any connected pv mode loadpoint of at least the given priority
+// has not reached its target and is not yet charging at its maximum current
+func (site *Site) evSurplusDemand(prio int) bool {
+	for _, lp := range site.loadpoints {
+		if mode := lp.GetMode(); lp.Virtual || mode != api.ModePV && mode != api.ModeMinPV {
+			continue
+		}
+
+		if lp.Priority() < prio {
+			continue
+		}
+
+		if !lp.connected() || lp.targetSocReached() || lp.targetEnergyReached() {
+			continue
+		}
+
+		if !lp.charging() || lp.effectiveCurrent() < lp.GetMaxCurrent() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publishAuxLoad publishes an auxiliary load value using the loadpoint layout
+func (site *Site) publishAuxLoad(id int, key string, val interface{}) {
+	// test helper
+	if site.uiChan == nil {
+		return
+	}
+
+	site.uiChan <- util.Param{
+		AuxLoad: &id,
+		Key:     key,
+		Val:     val,
+	}
+}
+
+// updateAuxLoads switches auxiliary loads according to their mode and the remaining pv surplus
+func (site *Site) updateAuxLoads() {
+	for id, a := range site.auxLoads {
+		site.Lock()
+		mode, prio := a.Mode, a.Priority
+		site.Unlock()
+
+		var toggle bool
+		switch mode {
+		case api.ModeOff:
+			toggle = a.enabled
+			a.pending = time.Time{}
+		case api.ModeNow:
+			toggle = !a.enabled
+			a.pending = time.Time{}
+		default:
+			toggle = a.update(site.gridPower, site.evSurplusDemand(prio), time.Now())
+		}
+
+		if toggle {
+			var err error
+			if !site.dryRunCommand("aux load %s: enabled %t", a.Title, !a.enabled) {
+				err = a.relay.Enable(!a.enabled)
+			}
+
+			if err != nil {
+				site.log.ERROR.Printf("aux load %s: %v", a.Title, err)
+			} else {
+				a.enabled = !a.enabled
+				site.log.DEBUG.Printf("aux load %s: enabled %t", a.Title, a.enabled)
+			}
+		}
+
+		site.publishAuxLoadStatus(id, a)
+	}
+}
+
+// publishAuxLoadStatus publishes the status and power of the auxiliary load
+func (site *Site) publishAuxLoadStatus(id int, a *auxLoad) {
+	status, err := a.relay.Status()
+	if err != nil {
+		site.log.ERROR.Printf("aux load %s: %v", a.Title, err)
+	}
+
+	// measured power if available, nominal power otherwise
+	var power float64
+	if m, ok := a.relay.(api.Meter); ok {
+		if power, err = m.CurrentPower(); err != nil {
+			site.log.ERROR.Printf("aux load %s: %v", a.Title, err)
+		}
+	} else if a.enabled {
+		power = a.Power
+	}
+
+	site.publishAuxLoad(id, "status", status)
+	site.publishAuxLoad(id, "enabled", a.enabled)
+	site.publishAuxLoad(id, "power", power)
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuxLoad(t *testing.T) {
+	a := &auxLoad{
+		AuxLoadConfig: AuxLoadConfig{Power: 2000, Threshold: 200, Delay: time.Minute},
+	}
+
+	now := time.Now()
+
+	assert.False(t, a.update(-1000, false, now), "insufficient surplus")
+	assert.False(t, a.update(-3000, true, now), "ev demand has priority")
+
+	assert.False(t, a.update(-2500, false, now), "surplus, delay not elapsed")
+	assert.False(t, a.update(-1000, false, now.Add(30*time.Second)), "surplus drop resets delay")
+	assert.False(t, a.update(-2500, false, now.Add(40*time.Second)))
+	assert.True(t, a.update(-2500, false, now.Add(100*time.Second)), "delay elapsed")
+	a.enabled = true
+
+	assert.False(t, a.update(100, false, now), "import within threshold")
+	assert.False(t, a.update(500, false, now), "import, delay not elapsed")
+	assert.True(t, a.update(500, false, now.Add(time.Minute)), "delay elapsed")
+	a.enabled = false
+
+	a.Delay = 0
+	assert.False(t, a.update(-2100, false, now), "surplus below power plus threshold")
+	assert.True(t, a.update(-2200, false, now), "switch on immediately")
+	a.enabled = true
+	assert.True(t, a.update(-500, true, now), "switch off for ev demand")
+}
+
+func TestEVSurplusDemand(t *testing.T) {
+	lp := &Loadpoint{
+		Mode:          api.ModePV,
+		MaxCurrent:    16,
+		status:        api.StatusA,
+		sessionEnergy: NewEnergyMetrics(),
+	}
+
+	s := &Site{loadpoints: []*Loadpoint{lp}}
+
+	assert.False(t, s.evSurplusDemand(0), "disconnected")
+
+	// connected, waiting for surplus
+	lp.status = api.StatusB
+	assert.True(t, s.evSurplusDemand(0), "waiting for surplus")
+
+	lp.status = api.StatusC
+	lp.chargeCurrent = 10
+	assert.True(t, s.evSurplusDemand(0), "charging below max current")
+
+	lp.chargeCurrent = 16
+	assert.False(t, s.evSurplusDemand(0), "charging at max current")
+
+	// target reached
+	lp.status = api.StatusB
+	lp.vehicle = &struct{ api.Vehicle }{}
+	lp.vehicleSoc = 80
+	lp.Soc.target = 80
+	assert.False(t, s.evSurplusDemand(0), "target soc reached")
+
+	lp.Mode = api.ModeNow
+	lp.vehicleSoc = 50
+	assert.False(t, s.evSurplusDemand(0), "now mode")
+
+	// lower priority loadpoint
+	lp.Mode = api.ModePV
+	assert.True(t, s.evSurplusDemand(0), "same priority")
+	assert.False(t, s.evSurplusDemand(1), "lower priority")
+}
+
+func TestAuxLoadSettings(t *testing.T) {
+	s := &Site{
+		log:      util.NewLogger("foo"),
+		auxLoads: []*auxLoad{{AuxLoadConfig: AuxLoadConfig{Title: "heater", Mode: api.ModePV}}},
+	}
+
+	assert.Equal(t, []string{"heater"}, s.GetAuxLoadTitles())
+
+	assert.NoError(t, s.SetAuxLoadPriority(0, 2))
+	assert.Equal(t, 2, s.auxLoads[0].Priority)
+
+	assert.Error(t, s.SetAuxLoadPriority(1, 2), "invalid index")
+	assert.Error(t, s.SetAuxLoadPriority(0, -1), "invalid priority")
+
+	assert.NoError(t, s.SetAuxLoadMode(0, api.ModeNow))
+	assert.Equal(t, api.ModeNow, s.auxLoads[0].Mode)
+
+	assert.Error(t, s.SetAuxLoadMode(1, api.ModeOff), "invalid index")
+	assert.Error(t, s.SetAuxLoadMode(0, api.ModeMinPV), "invalid mode")
+}
+
+func TestAuxLoadMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	relay := mock.NewMockCharger(ctrl)
+	relay.EXPECT().Status().Return(api.StatusB, nil).AnyTimes()
+
+	a := &auxLoad{
+		AuxLoadConfig: AuxLoadConfig{Title: "heater", Power: 2000, Mode: api.ModeNow},
+		relay:         relay,
+	}
+
+	s := &Site{
+		log:      util.NewLogger("foo"),
+		auxLoads: []*auxLoad{a},
+	}
+
+	// switched on without surplus
+	relay.EXPECT().Enable(true).Return(nil)
+	s.updateAuxLoads()
+	assert.True(t, a.enabled)
+
+	s.updateAuxLoads()
+
+	// switched off despite surplus
+	s.gridPower = -5000
+	a.Mode = api.ModeOff
+	relay.EXPECT().Enable(false).Return(nil)
+	s.updateAuxLoads()
+	assert.False(t, a.enabled)
+
+	s.updateAuxLoads()
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// batteryModeRefresh is the interval for rewriting a non-normal battery mode.
+// Devices like SMA fall back to normal operation if the mode is not refreshed cyclically.
+const batteryModeRefresh = time.Minute
+
+// requiredBatteryMode returns the battery mode required by the loadpoints.
+// Battery discharge is inhibited while any loadpoint is fast charging from grid.
+func (site *Site) requiredBatteryMode() api.BatteryMode {
+	for _, lp := range site.loadpoints {
+		if !lp.Virtual && lp.GetMode() == api.ModeNow && lp.GetStatus() == api.StatusC {
+			return api.BatteryHold
+		}
+	}
+
+	return api.BatteryNormal
+}
+
+// setBatteryMode applies the battery mode to all controllable battery meters
+func (site *Site) setBatteryMode(mode api.BatteryMode) error {
+	if !site.dryRunCommand("battery mode: %s", mode) {
+		for i, meter := range site.batteryMeters {
+			if setMode := api.GetMeterCapabilities(meter).SetBatteryMode; setMode != nil {
+				if err := setMode(mode); err != nil {
+					return fmt.Errorf("battery %d: %w", i+1, err)
+				}
+			}
+		}
+	}
+
+	site.batteryMode = mode
+	site.batteryModeUpdated = time.Now()
+	site.publish("batteryMode", mode.String())
+
+	return nil
+}
+
+// updateBatteryMode locks battery discharge during fast charging if enabled
+func (site *Site) updateBatteryMode() {
+	if !site.BatteryDischargeControl {
+		return
+	}
+
+	mode := site.requiredBatteryMode()
+	refresh := mode != api.BatteryNormal && time.Since(site.batteryModeUpdated) >= batteryModeRefresh
+
+	if mode != site.batteryMode || refresh {
+		site.log.DEBUG.Printf("set battery mode: %s", mode)
+
+		if err := site.setBatteryMode(mode); err != nil {
+			site.log.ERROR.Println("battery mode:", err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type batteryController struct {
+	api.Meter
+	modes []api.BatteryMode
+}
+
+func (b *batteryController) SetBatteryMode(mode api.BatteryMode) error {
+	b.modes = append(b.modes, mode)
+	return nil
+}
+
+func TestBatteryDischargeControl(t *testing.T) {
+	battery := new(batteryController)
+	lp := &Loadpoint{Mode: api.ModePV, status: api.StatusC}
+
+	s := &Site{
+		log:                     util.NewLogger("foo"),
+		BatteryDischargeControl: true,
+		batteryMeters:           []api.Meter{battery},
+		loadpoints:              []*Loadpoint{lp},
+	}
+
+	s.updateBatteryMode()
+	assert.Equal(t, []api.BatteryMode{api.BatteryNormal}, battery.modes, "pv charging")
+
+	s.updateBatteryMode()
+	assert.Len(t, battery.modes, 1, "unchanged mode not applied again")
+
+	lp.Mode = api.ModeNow
+	s.updateBatteryMode()
+	assert.Equal(t, api.BatteryHold, s.batteryMode, "fast charging")
+
+	s.updateBatteryMode()
+	assert.Len(t, battery.modes, 2, "hold mode not refreshed before interval")
+
+	s.batteryModeUpdated = s.batteryModeUpdated.Add(-batteryModeRefresh)
+	s.updateBatteryMode()
+	assert.Equal(t, []api.BatteryMode{api.BatteryNormal, api.BatteryHold, api.BatteryHold}, battery.modes, "hold mode refreshed")
+
+	lp.status = api.StatusB
+	s.updateBatteryMode()
+	assert.Equal(t, []api.BatteryMode{api.BatteryNormal, api.BatteryHold, api.BatteryHold, api.BatteryNormal}, battery.modes, "charging stopped")
+
+	s.BatteryDischargeControl = false
+	lp.status = api.StatusC
+	s.updateBatteryMode()
+	assert.Len(t, battery.modes, 4, "control disabled")
+}
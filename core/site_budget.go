@@ -0,0 +1,54 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+	"golang.org/x/exp/slices"
+)
+
+// BudgetConfig is the monthly charging energy budget of a loadpoint, e.g. for rented wallboxes with flat-rate contracts
+type BudgetConfig struct {
+	Loadpoint string  `mapstructure:"loadpoint"` // loadpoint title
+	Energy    float64 `mapstructure:"energy"`    // monthly energy budget (kWh)
+	Action    string  `mapstructure:"action"`    // off (default) or pv when exceeded
+}
+
+// configureBudgets validates budget configuration and assigns the budgets to their loadpoints
+func (site *Site) configureBudgets() error {
+	for _, b := range site.Budgets {
+		idx := slices.IndexFunc(site.loadpoints, func(lp *Loadpoint) bool {
+			return lp.Title() == b.Loadpoint
+		})
+		if idx < 0 {
+			return fmt.Errorf("budget: invalid loadpoint: %s", b.Loadpoint)
+		}
+
+		if b.Energy <= 0 {
+			return fmt.Errorf("budget %s: missing energy", b.Loadpoint)
+		}
+
+		action := api.ModeOff
+		switch b.Action {
+		case "", string(api.ModeOff):
+		case string(api.ModePV):
+			action = api.ModePV
+		default:
+			return fmt.Errorf("budget %s: invalid action: %s", b.Loadpoint, b.Action)
+		}
+
+		lp := site.loadpoints[idx]
+		if lp.budget != nil {
+			return fmt.Errorf("budget %s: duplicate loadpoint", b.Loadpoint)
+		}
+
+		// energy of previous sessions is required for the monthly total
+		if lp.db == nil {
+			return fmt.Errorf("budget %s: database required", b.Loadpoint)
+		}
+
+		lp.configureBudget(b.Energy, action)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"math"
+)
+
+// CapacityConfig limits total power drawn from an inverter or generator in off-grid operation
+type CapacityConfig struct {
+	Power float64 `mapstructure:"power"` // source capacity (W)
+	Ramp  float64 `mapstructure:"ramp"`  // max charge power increase per update cycle (W)
+}
+
+// capacity is the power budget and ramp rate available to a loadpoint
+type capacity struct {
+	power float64 // available charge power (W)
+	ramp  float64 // max charge power increase (W)
+}
+
+// updateCapacity calculates the loadpoint's share of the source capacity
+func (site *Site) updateCapacity(lp *Loadpoint) {
+	if site.Capacity.Power == 0 && site.Capacity.Ramp == 0 {
+		return
+	}
+
+	res := &capacity{
+		power: math.Inf(1),
+		ramp:  site.Capacity.Ramp,
+	}
+
+	if site.Capacity.Power > 0 {
+		// total consumption supplied by the source excluding this loadpoint
+		consumption := site.gridPower + math.Max(0, site.pvPower) + site.batteryPower - lp.GetChargePower()
+		res.power = math.Max(0, site.Capacity.Power-consumption)
+
+		site.log.DEBUG.Printf("capacity: %.0fW available for %s", res.power, lp.Title())
+	}
+
+	lp.capacity = res
+}
+
+// capacityLimit limits charge current to the available source capacity with soft-start ramping
+func (lp *Loadpoint) capacityLimit(chargeCurrent float64) float64 {
+	if lp.capacity == nil || chargeCurrent == 0 {
+		return chargeCurrent
+	}
+
+	scale := Voltage * float64(lp.activePhases())
+
+	if current := lp.capacity.power / scale; chargeCurrent > current {
+		lp.log.DEBUG.Printf("capacity limit: %.3gA", current)
+		chargeCurrent = current
+	}
+
+	if lp.capacity.ramp > 0 {
+		if !lp.enabled {
+			// soft-start at min current
+			chargeCurrent = math.Min(chargeCurrent, lp.GetMinCurrent())
+		} else if chargeCurrent > lp.chargeCurrent {
+			chargeCurrent = math.Min(chargeCurrent, lp.chargeCurrent+lp.capacity.ramp/scale)
+		}
+	}
+
+	return chargeCurrent
+}
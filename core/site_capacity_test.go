@@ -0,0 +1,68 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapacityLimit(t *testing.T) {
+	Voltage = 230 // V
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.phases = 1
+
+	// unlimited
+	assert.Equal(t, 16.0, lp.capacityLimit(16))
+
+	// power limit
+	lp.capacity = &capacity{power: 10 * Voltage, ramp: 0}
+	assert.Equal(t, 10.0, lp.capacityLimit(16))
+	assert.Equal(t, 0.0, lp.capacityLimit(0))
+
+	// soft-start
+	lp.capacity = &capacity{power: math.Inf(1), ramp: 2 * Voltage}
+	assert.Equal(t, 6.0, lp.capacityLimit(16))
+
+	// ramp
+	lp.enabled = true
+	lp.chargeCurrent = 6
+	assert.Equal(t, 8.0, lp.capacityLimit(16))
+	assert.Equal(t, 7.0, lp.capacityLimit(7))
+}
+
+func TestCapacityLimitBypassesGuard(t *testing.T) {
+	Voltage = 230 // V
+
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		wakeUpTimer:   NewTimer(),
+		enabled:       true,
+		chargeCurrent: 16,
+		MinCurrent:    minA,
+		MaxCurrent:    maxA,
+		GuardDuration: 5 * time.Minute,
+		guardUpdated:  clck.Now(),
+		phases:        1,
+	}
+
+	// capacity below min current disables the charger despite the guard
+	lp.capacity = &capacity{power: 3 * Voltage}
+	charger.EXPECT().Enable(false).Return(nil)
+	assert.NoError(t, lp.setLimit(16, false))
+	assert.False(t, lp.enabled)
+}
@@ -0,0 +1,272 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+	"golang.org/x/exp/slices"
+)
+
+// circuit current distribution modes
+const (
+	circuitEqual    = "equal"    // equal share for all active loadpoints
+	circuitPriority = "priority" // higher effective loadpoint priority first
+	circuitFifo     = "fifo"     // first connected vehicle first
+)
+
+// CircuitDistributions are the valid circuit distribution modes
+var CircuitDistributions = []string{circuitEqual, circuitPriority, circuitFifo}
+
+// CircuitConfig is a shared fuse limiting the total current of its loadpoints
+type CircuitConfig struct {
+	Name         string  `mapstructure:"name"`         // circuit reference used by loadpoints
+	MaxCurrent   float64 `mapstructure:"maxCurrent"`   // fuse rating per phase (A)
+	Distribution string  `mapstructure:"distribution"` // equal (default), priority or fifo
+	Grid         bool    `mapstructure:"grid"`         // grid connection point, household consumption reduces available current
+}
+
+// circuitMeasurement is the aggregation of a circuit's live values
+type circuitMeasurement struct {
+	Loadpoints int     `json:"loadpoints"`
+	Current    float64 `json:"current"`
+	MaxCurrent float64 `json:"maxCurrent"`
+	Household  float64 `json:"household,omitempty"`
+}
+
+// configureCircuits validates circuit configuration and loadpoint references
+func (site *Site) configureCircuits() error {
+	names := make(map[string]bool)
+
+	for i, c := range site.Circuits {
+		if c.Name == "" {
+			return errors.New("circuit: missing name")
+		}
+		if names[c.Name] {
+			return fmt.Errorf("circuit %s: duplicate name", c.Name)
+		}
+		if c.MaxCurrent <= 0 {
+			return fmt.Errorf("circuit %s: missing maxCurrent", c.Name)
+		}
+
+		if c.Grid {
+			if site.gridMeter == nil {
+				return fmt.Errorf("circuit %s: grid connection requires grid meter", c.Name)
+			}
+			if _, ok := site.gridMeter.(api.PhaseCurrents); !ok {
+				site.log.WARN.Printf("circuit %s: grid meter does not provide phase currents, assuming symmetric household load", c.Name)
+			}
+		}
+
+		if c.Distribution == "" {
+			site.Circuits[i].Distribution = circuitEqual
+		} else if !slices.Contains(CircuitDistributions, c.Distribution) {
+			return fmt.Errorf("circuit %s: invalid distribution: %s", c.Name, c.Distribution)
+		}
+
+		names[c.Name] = true
+	}
+
+	for _, lp := range site.loadpoints {
+		if lp.Circuit_ != "" && !names[lp.Circuit_] {
+			return fmt.Errorf("loadpoint %s: unknown circuit: %s", lp.Title(), lp.Circuit_)
+		}
+	}
+
+	return nil
+}
+
+// circuit returns the loadpoint's circuit or nil
+func (site *Site) circuit(lp *Loadpoint) *CircuitConfig {
+	if lp.Circuit_ == "" {
+		return nil
+	}
+
+	for i, c := range site.Circuits {
+		if c.Name == lp.Circuit_ {
+			return &site.Circuits[i]
+		}
+	}
+
+	return nil
+}
+
+// circuitLoadpoints returns the loadpoints attached to the circuit
+func (site *Site) circuitLoadpoints(c *CircuitConfig) []*Loadpoint {
+	var res []*Loadpoint
+	for _, lp := range site.loadpoints {
+		if lp.Circuit_ == c.Name {
+			res = append(res, lp)
+		}
+	}
+	return res
+}
+
+// loadpointCurrents returns the loadpoint's per-phase charge currents
+func loadpointCurrents(lp *Loadpoint) []float64 {
+	if lp.chargeCurrents != nil {
+		return lp.chargeCurrents
+	}
+
+	res := make([]float64, 3)
+	current := lp.effectiveCurrent()
+	for i := 0; i < lp.activePhases() && i < len(res); i++ {
+		res[i] = current
+	}
+
+	return res
+}
+
+// householdCurrent returns the highest phase current at the grid connection not drawn by the given loadpoints
+func (site *Site) householdCurrent(loadpoints []*Loadpoint) float64 {
+	if site.gridCurrents == nil {
+		// assume symmetric distribution across phases
+		power := site.gridPower
+		for _, lp := range loadpoints {
+			power -= lp.GetChargePower()
+		}
+
+		return math.Max(0, power/(3*Voltage))
+	}
+
+	currents := slices.Clone(site.gridCurrents)
+	for _, lp := range loadpoints {
+		for i, current := range loadpointCurrents(lp) {
+			if i < len(currents) {
+				currents[i] -= current
+			}
+		}
+	}
+
+	return math.Max(0, max(currents))
+}
+
+// availableCurrent returns the circuit's max current reduced by household consumption at the grid connection
+func (site *Site) availableCurrent(c *CircuitConfig, loadpoints []*Loadpoint) float64 {
+	if !c.Grid {
+		return c.MaxCurrent
+	}
+
+	return math.Max(0, c.MaxCurrent-site.householdCurrent(loadpoints))
+}
+
+// circuitDemand checks if the loadpoint requires a share of the circuit current.
+// Connected loadpoints only reserve a share while charging or when requesting current themselves.
+func circuitDemand(lp, requester *Loadpoint) bool {
+	if !lp.connected() || lp.GetMode() == api.ModeOff {
+		return false
+	}
+
+	return lp == requester || lp.charging()
+}
+
+// circuitShares distributes the available circuit current across the loadpoints with demand
+func circuitShares(c *CircuitConfig, available float64, loadpoints []*Loadpoint, requester *Loadpoint) map[*Loadpoint]float64 {
+	var active []*Loadpoint
+	for _, lp := range loadpoints {
+		if circuitDemand(lp, requester) {
+			active = append(active, lp)
+		}
+	}
+
+	res := make(map[*Loadpoint]float64, len(active))
+	remaining := available
+
+	switch c.Distribution {
+	case circuitPriority:
+		slices.SortStableFunc(active, func(i, j *Loadpoint) bool {
+			return i.EffectivePriority() > j.EffectivePriority()
+		})
+
+	case circuitFifo:
+		slices.SortStableFunc(active, func(i, j *Loadpoint) bool {
+			return i.connectedTime.Before(j.connectedTime)
+		})
+
+	default:
+		// hand out smallest demand first to redistribute unused share
+		slices.SortStableFunc(active, func(i, j *Loadpoint) bool {
+			return i.GetMaxCurrent() < j.GetMaxCurrent()
+		})
+
+		for i, lp := range active {
+			share := math.Min(lp.GetMaxCurrent(), remaining/float64(len(active)-i))
+			res[lp] = share
+			remaining -= share
+		}
+
+		return res
+	}
+
+	for _, lp := range active {
+		share := math.Min(lp.GetMaxCurrent(), remaining)
+		res[lp] = share
+		remaining -= share
+	}
+
+	return res
+}
+
+// updateCircuit calculates the loadpoint's share of its circuit's max current
+func (site *Site) updateCircuit(lp *Loadpoint) {
+	c := site.circuit(lp)
+	if c == nil {
+		return
+	}
+
+	loadpoints := site.circuitLoadpoints(c)
+	available := site.availableCurrent(c, loadpoints)
+	share := circuitShares(c, available, loadpoints, lp)[lp]
+
+	// never exceed the fuse while other loadpoints have not yet adapted to their share
+	var others float64
+	for _, other := range loadpoints {
+		if other != lp && other.enabled {
+			others += other.chargeCurrent
+		}
+	}
+
+	share = math.Max(0, math.Min(share, available-others))
+	site.log.DEBUG.Printf("circuit %s: %.3gA available for %s", c.Name, share, lp.Title())
+
+	lp.circuitShare = &share
+}
+
+// publishCircuits publishes the aggregated live values of all circuits
+func (site *Site) publishCircuits() {
+	if len(site.Circuits) == 0 {
+		return
+	}
+
+	res := make(map[string]circuitMeasurement, len(site.Circuits))
+
+	for i, c := range site.Circuits {
+		m := circuitMeasurement{MaxCurrent: c.MaxCurrent}
+
+		loadpoints := site.circuitLoadpoints(&site.Circuits[i])
+		for _, lp := range loadpoints {
+			m.Loadpoints++
+			m.Current += lp.effectiveCurrent()
+		}
+
+		if c.Grid {
+			m.Household = site.householdCurrent(loadpoints)
+		}
+
+		res[c.Name] = m
+	}
+
+	site.publish("circuits", res)
+}
+
+// circuitLimit limits charge current to the loadpoint's share of its circuit
+func (lp *Loadpoint) circuitLimit(chargeCurrent float64) float64 {
+	if lp.circuitShare == nil || chargeCurrent <= *lp.circuitShare {
+		return chargeCurrent
+	}
+
+	lp.log.DEBUG.Printf("circuit limit: %.3gA", *lp.circuitShare)
+
+	return *lp.circuitShare
+}
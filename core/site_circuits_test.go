@@ -0,0 +1,195 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func circuitLoadpoint(title string, prio int, connected time.Time) *Loadpoint {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.Title_ = title
+	lp.Circuit_ = "garage"
+	lp.Priority_ = prio
+	lp.Mode = api.ModeNow
+	lp.status = api.StatusC
+	lp.connectedTime = connected
+	return lp
+}
+
+func TestCircuitShares(t *testing.T) {
+	now := time.Now()
+
+	a := circuitLoadpoint("a", 0, now)
+	b := circuitLoadpoint("b", 1, now.Add(time.Minute))
+	c := circuitLoadpoint("c", 0, now.Add(2*time.Minute))
+	c.MaxCurrent = 6
+
+	idle := circuitLoadpoint("idle", 2, now)
+	idle.status = api.StatusA
+
+	// connected but not charging
+	waiting := circuitLoadpoint("waiting", 3, now)
+	waiting.status = api.StatusB
+
+	lps := []*Loadpoint{a, b, c, idle, waiting}
+
+	// equal share, unused share of c is redistributed
+	res := circuitShares(&CircuitConfig{Distribution: circuitEqual}, 32, lps, a)
+	assert.Equal(t, map[*Loadpoint]float64{a: 13, b: 13, c: 6}, res)
+
+	res = circuitShares(&CircuitConfig{Distribution: circuitPriority}, 32, lps, a)
+	assert.Equal(t, map[*Loadpoint]float64{b: 16, a: 16, c: 0}, res)
+
+	res = circuitShares(&CircuitConfig{Distribution: circuitFifo}, 20, lps, a)
+	assert.Equal(t, map[*Loadpoint]float64{a: 16, b: 4, c: 0}, res)
+
+	// requesting loadpoint receives a share without charging
+	res = circuitShares(&CircuitConfig{Distribution: circuitPriority}, 32, lps, waiting)
+	assert.Equal(t, map[*Loadpoint]float64{waiting: 16, b: 16, a: 0, c: 0}, res)
+}
+
+func TestUpdateCircuit(t *testing.T) {
+	now := time.Now()
+
+	a := circuitLoadpoint("a", 0, now)
+	b := circuitLoadpoint("b", 0, now)
+	other := NewLoadpoint(util.NewLogger("foo"))
+
+	s := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{a, b, other},
+		Circuits:   []CircuitConfig{{Name: "garage", MaxCurrent: 20}},
+	}
+
+	assert.NoError(t, s.configureCircuits())
+	assert.Equal(t, circuitEqual, s.Circuits[0].Distribution)
+
+	// b still charging at full current
+	b.enabled = true
+	b.chargeCurrent = 16
+
+	s.updateCircuit(a)
+	assert.Equal(t, 4.0, a.circuitLimit(16))
+
+	// b has adapted to its share
+	b.chargeCurrent = 10
+
+	s.updateCircuit(a)
+	assert.Equal(t, 10.0, a.circuitLimit(16))
+	assert.Equal(t, 0.0, a.circuitLimit(0))
+
+	// loadpoint without circuit
+	s.updateCircuit(other)
+	assert.Equal(t, 16.0, other.circuitLimit(16))
+
+	// invalid references
+	other.Circuit_ = "foo"
+	assert.Error(t, s.configureCircuits())
+}
+
+func TestGridCircuit(t *testing.T) {
+	Voltage = 230 // V
+
+	a := circuitLoadpoint("a", 0, time.Now())
+	a.chargeCurrents = []float64{10, 10, 10}
+
+	s := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{a},
+	}
+
+	c := &CircuitConfig{Name: "garage", MaxCurrent: 35, Grid: true}
+
+	// household load on phase 2
+	s.gridCurrents = []float64{10, 25, 10}
+	assert.Equal(t, 15.0, s.householdCurrent(s.loadpoints))
+	assert.Equal(t, 20.0, s.availableCurrent(c, s.loadpoints))
+
+	// grid export does not increase available current
+	s.gridCurrents = []float64{-5, -5, -5}
+	assert.Equal(t, 0.0, s.householdCurrent(s.loadpoints))
+
+	// symmetric fallback without phase currents
+	s.gridCurrents = nil
+	s.gridPower = 3 * 230 * 5
+	assert.Equal(t, 5.0, s.householdCurrent(nil))
+}
+
+func TestCircuitLimitBypassesGuard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		bus:           evbus.New(),
+		clock:         clck,
+		charger:       charger,
+		wakeUpTimer:   NewTimer(),
+		enabled:       true,
+		chargeCurrent: 16,
+		MinCurrent:    minA,
+		MaxCurrent:    maxA,
+		GuardDuration: 5 * time.Minute,
+		guardUpdated:  clck.Now(),
+		phases:        3,
+	}
+
+	share := 3.0
+	lp.circuitShare = &share
+
+	// share below min current disables the charger despite the guard
+	charger.EXPECT().Enable(false).Return(nil)
+	assert.NoError(t, lp.setLimit(16, false))
+	assert.False(t, lp.enabled)
+
+	// regular disable is delayed by the guard
+	lp.circuitShare = nil
+	charger.EXPECT().Enable(true).Return(nil)
+	clck.Add(5 * time.Minute)
+	assert.NoError(t, lp.setLimit(16, false))
+
+	assert.NoError(t, lp.setLimit(0, false))
+	assert.True(t, lp.enabled)
+}
+
+func TestGridCircuitBypassesGuard(t *testing.T) {
+	Voltage = 230 // V
+
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+	clck := clock.NewMock()
+
+	lp := circuitLoadpoint("a", 0, clck.Now())
+	lp.clock = clck
+	lp.charger = charger
+	lp.wakeUpTimer = NewTimer()
+	lp.enabled = true
+	lp.chargeCurrent = 16
+	lp.chargeCurrents = []float64{16, 16, 16}
+	lp.GuardDuration = 5 * time.Minute
+	lp.guardUpdated = clck.Now()
+	lp.phases = 3
+
+	s := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{lp},
+		Circuits:   []CircuitConfig{{Name: "garage", MaxCurrent: 35, Grid: true}},
+	}
+
+	// household consumption leaves less than min current at the grid connection
+	s.gridCurrents = []float64{46, 46, 46}
+	s.updateCircuit(lp)
+
+	charger.EXPECT().Enable(false).Return(nil)
+	assert.NoError(t, lp.setLimit(16, false))
+	assert.False(t, lp.enabled)
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/tariff"
+)
+
+// clockJumpThreshold is the minimum wall clock deviation considered a clock jump
+const clockJumpThreshold = time.Minute
+
+// clockGuard detects jumps of the system wall clock, e.g. time synchronization after boot on devices without rtc
+type clockGuard struct {
+	last time.Time
+}
+
+// update returns the offset the wall clock has jumped by since the last update or zero if it did not jump
+func (g *clockGuard) update(now time.Time) time.Duration {
+	last := g.last
+	g.last = now
+
+	if last.IsZero() {
+		return 0
+	}
+
+	// Round(0) strips the monotonic clock reading
+	return clockJump(now.Round(0).Sub(last.Round(0)), now.Sub(last))
+}
+
+// clockJump returns the difference between elapsed wall clock and monotonic clock time if it exceeds the threshold
+func clockJump(wall, monotonic time.Duration) time.Duration {
+	if offset := wall - monotonic; offset <= -clockJumpThreshold || offset >= clockJumpThreshold {
+		return offset
+	}
+	return 0
+}
+
+// updateClock re-evaluates time dependent state after the system clock has jumped
+func (site *Site) updateClock() {
+	// test guard
+	if site.clockJump == nil {
+		return
+	}
+
+	offset := site.clockJump.update(time.Now())
+	if offset == 0 {
+		return
+	}
+
+	site.log.WARN.Printf("system clock jumped by %v", offset.Round(time.Second))
+
+	// rates were requested for the previous time
+	tariff.Refresh()
+
+	for _, lp := range site.loadpoints {
+		lp.clockJumped(offset)
+	}
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	coredb "github.com/evcc-io/evcc/core/db"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockJump(t *testing.T) {
+	assert.Equal(t, time.Duration(0), clockJump(10*time.Second, 10*time.Second), "no jump")
+	assert.Equal(t, time.Duration(0), clockJump(40*time.Second, 10*time.Second), "small drift")
+	assert.Equal(t, 24*time.Hour, clockJump(24*time.Hour+10*time.Second, 10*time.Second), "forward")
+	assert.Equal(t, -time.Hour, clockJump(10*time.Second-time.Hour, 10*time.Second), "backward")
+
+	g := new(clockGuard)
+	now := time.Now()
+	assert.Equal(t, time.Duration(0), g.update(now), "first update")
+	assert.Equal(t, time.Duration(0), g.update(now.Add(time.Hour)), "wall and monotonic clock in sync")
+}
+
+func TestLoadpointClockJumped(t *testing.T) {
+	var err error
+	serverdb.Instance, err = serverdb.New("sqlite", ":memory:")
+	assert.NoError(t, err)
+
+	db, err := coredb.New("foo")
+	assert.NoError(t, err)
+
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	lp.db = db
+	lp.planActive = true
+	lp.planSlotEnd = time.Now()
+
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	lp.session = &coredb.Session{Created: created}
+
+	lp.clockJumped(time.Hour)
+
+	assert.False(t, lp.planActive)
+	assert.True(t, lp.planSlotEnd.IsZero())
+	assert.Equal(t, created.Add(time.Hour), lp.session.Created)
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+// exportLimitTolerance is the margin below the export limit at which curtailment is assumed
+const exportLimitTolerance = 100 // W
+
+// ExportLimitConfig is the grid export limitation configuration
+type ExportLimitConfig struct {
+	Power float64 `mapstructure:"power"` // maximum grid export (W), 0 for zero feed-in
+	Boost float64 `mapstructure:"boost"` // additional surplus offered to loadpoints while pv is curtailed (W)
+}
+
+// curtailed returns true if pv generation is curtailed at the export limit.
+// Without inverter curtailment status, grid export at a non-zero limit is assumed to be curtailing.
+func (site *Site) curtailed() bool {
+	if site.pvPower <= 0 {
+		site.exportLimitCurtailed = false
+		return false
+	}
+
+	var reported bool
+	for i, meter := range site.pvMeters {
+		curtailedG := api.GetMeterCapabilities(meter).Curtailed
+		if curtailedG == nil {
+			continue
+		}
+
+		curtailed, err := curtailedG()
+		if err != nil {
+			site.log.ERROR.Printf("pv %d curtailment: %v", i+1, err)
+			continue
+		}
+
+		if curtailed {
+			return true
+		}
+
+		reported = true
+	}
+
+	site.exportLimitCurtailed = !reported && site.exportLimitEstimate()
+
+	return site.exportLimitCurtailed
+}
+
+// exportLimitEstimate estimates curtailment from grid export.
+// Once curtailed, the boost itself lowers grid export. Curtailment is only released
+// when export drops below the limit by more than the boost to avoid toggling.
+func (site *Site) exportLimitEstimate() bool {
+	// zero feed-in can't be told apart from self-consumption, charging battery absorbs surplus
+	if site.ExportLimit.Power <= 0 || site.batteryPower < -exportLimitTolerance {
+		return false
+	}
+
+	threshold := site.ExportLimit.Power - exportLimitTolerance
+	if site.exportLimitCurtailed {
+		threshold -= site.ExportLimit.Boost
+	}
+
+	export := -site.gridPower
+
+	return export > 0 && export >= threshold
+}
+
+// exportLimitBoost returns the additional surplus offered to loadpoints if pv is curtailed
+func (site *Site) exportLimitBoost() float64 {
+	if site.ExportLimit.Boost == 0 {
+		return 0
+	}
+
+	curtailed := site.curtailed()
+	site.publish("curtailed", curtailed)
+
+	if !curtailed {
+		return 0
+	}
+
+	site.log.DEBUG.Printf("pv curtailed at export limit, offering additional: %.0fW", site.ExportLimit.Boost)
+
+	return site.ExportLimit.Boost
+}
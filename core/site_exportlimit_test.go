@@ -0,0 +1,101 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type curtailmentMeter struct {
+	api.Meter
+	curtailed bool
+}
+
+func (m *curtailmentMeter) Curtailed() (bool, error) {
+	return m.curtailed, nil
+}
+
+func TestExportLimitBoost(t *testing.T) {
+	tc := []struct {
+		title             string
+		grid, pv, battery float64
+		status, curtailed bool
+		boost             float64
+	}{
+		{"no pv", -4950, 0, 0, false, false, 0},
+		{"export at limit", -4950, 6000, 0, false, false, 1000},
+		{"export below limit", -3000, 6000, 0, false, false, 0},
+		{"battery charging", -4950, 6000, -2000, false, false, 0},
+		{"inverter not curtailed", -4950, 6000, 0, true, false, 0},
+		{"inverter curtailed", -3000, 6000, 0, true, true, 1000},
+	}
+
+	for _, tc := range tc {
+		t.Log(tc.title)
+
+		var pv api.Meter = struct{ api.Meter }{}
+		if tc.status {
+			pv = &curtailmentMeter{curtailed: tc.curtailed}
+		}
+
+		s := &Site{
+			log:          util.NewLogger("foo"),
+			ExportLimit:  ExportLimitConfig{Power: 5000, Boost: 1000},
+			pvMeters:     []api.Meter{pv},
+			gridPower:    tc.grid,
+			pvPower:      tc.pv,
+			batteryPower: tc.battery,
+		}
+
+		assert.Equal(t, tc.boost, s.exportLimitBoost(), tc.title)
+	}
+
+	s := &Site{
+		ExportLimit: ExportLimitConfig{Power: 5000},
+		gridPower:   -5000,
+		pvPower:     6000,
+	}
+
+	assert.Equal(t, 0.0, s.exportLimitBoost(), "disabled")
+}
+
+func TestExportLimitZeroFeedIn(t *testing.T) {
+	s := &Site{
+		log:         util.NewLogger("foo"),
+		ExportLimit: ExportLimitConfig{Power: 0, Boost: 1000},
+		pvMeters:    []api.Meter{struct{ api.Meter }{}},
+		pvPower:     3000,
+	}
+
+	for _, grid := range []float64{100, 0, -50} {
+		s.gridPower = grid
+		assert.Equal(t, 0.0, s.exportLimitBoost(), "grid %.0fW", grid)
+	}
+}
+
+func TestExportLimitHysteresis(t *testing.T) {
+	s := &Site{
+		log:         util.NewLogger("foo"),
+		ExportLimit: ExportLimitConfig{Power: 5000, Boost: 1000},
+		pvMeters:    []api.Meter{struct{ api.Meter }{}},
+		pvPower:     6000,
+	}
+
+	tc := []struct {
+		grid  float64
+		boost float64
+	}{
+		{-4500, 0},    // below limit
+		{-4950, 1000}, // at limit
+		{-4000, 1000}, // boost consumed, still curtailed
+		{-3800, 0},    // below hysteresis
+		{-4000, 0},    // not curtailed again until limit reached
+	}
+
+	for i, tc := range tc {
+		s.gridPower = tc.grid
+		assert.Equal(t, tc.boost, s.exportLimitBoost(), "step %d", i)
+	}
+}
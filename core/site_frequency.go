@@ -0,0 +1,90 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+const frequencySource = "frequency"
+
+// FrequencyConfig is the grid frequency curtailment configuration
+type FrequencyConfig struct {
+	Min   float64       `mapstructure:"min"`   // curtail charging below this grid frequency
+	Delay time.Duration `mapstructure:"delay"` // re-enable delay after frequency has recovered
+}
+
+// frequencyGuard tracks under-frequency events
+type frequencyGuard struct {
+	FrequencyConfig
+	curtailed bool
+	recovered time.Time
+}
+
+// update evaluates the grid frequency and returns true if curtailment status has changed
+func (g *frequencyGuard) update(frequency float64, now time.Time) bool {
+	// under-frequency curtails immediately
+	if frequency < g.Min {
+		g.recovered = time.Time{}
+
+		if !g.curtailed {
+			g.curtailed = true
+			return true
+		}
+
+		return false
+	}
+
+	if !g.curtailed {
+		return false
+	}
+
+	if g.recovered.IsZero() {
+		g.recovered = now
+	}
+
+	// re-enable after delay
+	if now.Sub(g.recovered) >= g.Delay {
+		g.curtailed = false
+		g.recovered = time.Time{}
+		return true
+	}
+
+	return false
+}
+
+// updateFrequency reads grid frequency and curtails charging on under-frequency
+func (site *Site) updateFrequency() {
+	frequency := api.GetMeterCapabilities(site.gridMeter).Frequency
+	if frequency == nil {
+		return
+	}
+
+	f, err := frequency()
+	if err != nil {
+		site.log.ERROR.Printf("grid frequency: %v", err)
+		return
+	}
+
+	site.log.DEBUG.Printf("grid frequency: %.2fHz", f)
+	site.publish("gridFrequency", f)
+
+	if site.frequency.Min == 0 || f == 0 || !site.frequency.update(f, time.Now()) {
+		return
+	}
+
+	demand := loadpoint.RemoteEnable
+	if site.frequency.curtailed {
+		site.log.WARN.Printf("grid under-frequency: %.2fHz < %.2fHz, curtailing charging", f, site.frequency.Min)
+		demand = loadpoint.RemoteHardDisable
+	} else {
+		site.log.INFO.Printf("grid frequency recovered: %.2fHz, re-enabling charging", f)
+	}
+
+	site.publish("frequencyCurtailed", site.frequency.curtailed)
+
+	for _, lp := range site.loadpoints {
+		lp.RemoteControl(frequencySource, demand)
+	}
+}
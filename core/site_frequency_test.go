@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrequencyGuard(t *testing.T) {
+	g := &frequencyGuard{
+		FrequencyConfig: FrequencyConfig{Min: 49.8, Delay: time.Minute},
+	}
+
+	now := time.Now()
+
+	assert.False(t, g.update(50, now), "nominal frequency")
+	assert.False(t, g.curtailed)
+
+	assert.True(t, g.update(49.7, now), "under-frequency")
+	assert.True(t, g.curtailed)
+
+	assert.False(t, g.update(49.6, now), "still under-frequency")
+
+	assert.False(t, g.update(50, now), "recovered, delay not elapsed")
+	assert.True(t, g.curtailed)
+
+	assert.False(t, g.update(49.7, now.Add(30*time.Second)), "under-frequency resets delay")
+	assert.False(t, g.update(50, now.Add(40*time.Second)))
+	assert.False(t, g.update(50, now.Add(90*time.Second)), "delay not elapsed since recovery")
+
+	assert.True(t, g.update(50, now.Add(100*time.Second)), "delay elapsed")
+	assert.False(t, g.curtailed)
+}
+
+func TestFrequencyRemoteDemand(t *testing.T) {
+	lp := &Loadpoint{log: util.NewLogger("foo")}
+
+	lp.RemoteControl("mqtt", loadpoint.RemoteSoftDisable)
+	lp.RemoteControl(frequencySource, loadpoint.RemoteHardDisable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteHardDisable))
+
+	// frequency recovered, other source's demand remains
+	lp.RemoteControl(frequencySource, loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteSoftDisable))
+
+	lp.RemoteControl("mqtt", loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteEnable))
+}
@@ -0,0 +1,56 @@
+package core
+
+// groupMeasurement is the aggregation of a loadpoint group's live values
+type groupMeasurement struct {
+	Loadpoints    int     `json:"loadpoints"`
+	Charging      int     `json:"charging"`
+	ChargePower   float64 `json:"chargePower"`
+	ChargedEnergy float64 `json:"chargedEnergy"`
+}
+
+// GetGroups returns the loadpoint titles by group
+func (site *Site) GetGroups() map[string][]string {
+	res := make(map[string][]string)
+
+	for _, lp := range site.loadpoints {
+		if group := lp.Group(); group != "" {
+			res[group] = append(res[group], lp.Title())
+		}
+	}
+
+	return res
+}
+
+// groups aggregates the loadpoints' live values by group
+func (site *Site) groups() map[string]groupMeasurement {
+	res := make(map[string]groupMeasurement)
+
+	for _, lp := range site.loadpoints {
+		group := lp.Group()
+		if group == "" {
+			continue
+		}
+
+		m := res[group]
+		m.Loadpoints++
+		if lp.charging() {
+			m.Charging++
+		}
+		m.ChargePower += lp.GetChargePower()
+		m.ChargedEnergy += lp.getChargedEnergy()
+
+		res[group] = m
+	}
+
+	return res
+}
+
+// publishGroups publishes the aggregated live values of all loadpoint groups
+func (site *Site) publishGroups() {
+	groups := site.groups()
+	if len(groups) == 0 {
+		return
+	}
+
+	site.publish("groups", groups)
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteGroups(t *testing.T) {
+	lp := func(title, group string, status api.ChargeStatus, power float64) *Loadpoint {
+		lp := NewLoadpoint(util.NewLogger("foo"))
+		lp.Title_ = title
+		lp.Group_ = group
+		lp.status = status
+		lp.chargePower = power
+		return lp
+	}
+
+	site := &Site{
+		loadpoints: []*Loadpoint{
+			lp("garage 1", "garage", api.StatusC, 11000),
+			lp("garage 2", "garage", api.StatusB, 0),
+			lp("carport", "", api.StatusC, 3700),
+		},
+	}
+
+	assert.Equal(t, map[string][]string{"garage": {"garage 1", "garage 2"}}, site.GetGroups())
+	assert.Equal(t, map[string]groupMeasurement{
+		"garage": {Loadpoints: 2, Charging: 1, ChargePower: 11000},
+	}, site.groups())
+}
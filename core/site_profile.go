@@ -0,0 +1,121 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+const profileKey = "site.profile"
+
+// ProfileScheduleConfig switches the mode profile on a yearly date
+type ProfileScheduleConfig struct {
+	Profile string `mapstructure:"profile"` // profile name
+	From    string `mapstructure:"from"`    // yearly start date (MM-DD)
+}
+
+// profileSchedule is a parsed profile schedule entry
+type profileSchedule struct {
+	profile    string
+	month, day int
+}
+
+// before returns true if the schedule starts before or on the given date
+func (s profileSchedule) before(t time.Time) bool {
+	return int(t.Month()) > s.month || int(t.Month()) == s.month && t.Day() >= s.day
+}
+
+// parseProfileSchedule validates the profile schedule
+func (site *Site) parseProfileSchedule() ([]profileSchedule, error) {
+	res := make([]profileSchedule, 0, len(site.ProfileSchedule))
+
+	for i, s := range site.ProfileSchedule {
+		if _, ok := site.Profiles[s.Profile]; !ok {
+			return nil, fmt.Errorf("profile schedule %d: invalid profile: %s", i+1, s.Profile)
+		}
+
+		t, err := time.Parse("01-02", s.From)
+		if err != nil {
+			return nil, fmt.Errorf("profile schedule %d: invalid date: %s", i+1, s.From)
+		}
+
+		res = append(res, profileSchedule{profile: s.Profile, month: int(t.Month()), day: t.Day()})
+	}
+
+	return res, nil
+}
+
+// scheduledProfile returns the profile scheduled for the given date.
+// Before the first start date of the year, the last schedule of the previous year applies.
+func (site *Site) scheduledProfile(t time.Time) string {
+	var res *profileSchedule
+
+	for i, s := range site.profileSchedule {
+		if !s.before(t) {
+			continue
+		}
+		if res == nil || s.month > res.month || s.month == res.month && s.day > res.day {
+			res = &site.profileSchedule[i]
+		}
+	}
+
+	if res == nil {
+		for i, s := range site.profileSchedule {
+			if res == nil || s.month > res.month || s.month == res.month && s.day > res.day {
+				res = &site.profileSchedule[i]
+			}
+		}
+	}
+
+	if res == nil {
+		return ""
+	}
+
+	return res.profile
+}
+
+// configureProfiles parses the profile schedule and restores the active profile.
+// A profile set at runtime remains active until the next scheduled switch.
+func (site *Site) configureProfiles() error {
+	schedule, err := site.parseProfileSchedule()
+	if err != nil {
+		return err
+	}
+
+	site.profileSchedule = schedule
+	site.profileScheduled = site.scheduledProfile(time.Now())
+
+	name, err := settings.String(profileKey)
+	if err != nil || name == "" {
+		name = site.profileScheduled
+	}
+
+	profile, ok := site.Profiles[name]
+	if !ok {
+		return nil
+	}
+
+	site.profile = name
+	for _, lp := range site.loadpoints {
+		lp.setProfile(&profile)
+		lp.addTask(lp.applyProfile)
+	}
+
+	return nil
+}
+
+// updateProfileSchedule switches to the scheduled profile once its start date is reached
+func (site *Site) updateProfileSchedule(now time.Time) {
+	name := site.scheduledProfile(now)
+	if name == site.profileScheduled {
+		return
+	}
+
+	site.profileScheduled = name
+	site.log.INFO.Printf("scheduled profile: %s", name)
+
+	if err := site.SetProfile(name); err != nil {
+		site.log.ERROR.Println(err)
+	}
+}
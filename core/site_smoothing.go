@@ -0,0 +1,60 @@
+package core
+
+import (
+	"math"
+	"time"
+)
+
+// SmoothingConfig configures filtering of grid and pv power for the surplus calculation
+type SmoothingConfig struct {
+	Grid       time.Duration `mapstructure:"grid"`       // grid power time constant
+	PV         time.Duration `mapstructure:"pv"`         // pv power time constant, applies if grid power is estimated from pv
+	Hysteresis float64       `mapstructure:"hysteresis"` // minimum change of the filtered grid power (W)
+}
+
+// powerFilter is an exponential moving average for irregularly sampled power values
+type powerFilter struct {
+	tau        time.Duration
+	hysteresis float64
+	value      float64 // filtered value
+	output     float64 // last value exceeding hysteresis
+	updated    time.Time
+}
+
+func newPowerFilter(tau time.Duration, hysteresis float64) *powerFilter {
+	if tau <= 0 && hysteresis <= 0 {
+		return nil
+	}
+
+	return &powerFilter{
+		tau:        tau,
+		hysteresis: hysteresis,
+	}
+}
+
+// add adds a new sample and returns the filtered value. A nil filter returns the sample.
+func (f *powerFilter) add(power float64, now time.Time) float64 {
+	if f == nil {
+		return power
+	}
+
+	switch {
+	case f.updated.IsZero():
+		f.value = power
+		f.output = power
+	case f.tau <= 0:
+		f.value = power
+	default:
+		// weight depends on elapsed time to cope with irregular update intervals
+		alpha := 1 - math.Exp(-float64(now.Sub(f.updated))/float64(f.tau))
+		f.value += alpha * (power - f.value)
+	}
+
+	f.updated = now
+
+	if math.Abs(f.value-f.output) > f.hysteresis {
+		f.output = f.value
+	}
+
+	return f.output
+}
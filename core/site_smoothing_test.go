@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerFilter(t *testing.T) {
+	now := time.Now()
+
+	// disabled
+	assert.Nil(t, newPowerFilter(0, 0))
+	assert.Equal(t, 1000.0, (*powerFilter)(nil).add(1000, now))
+
+	f := newPowerFilter(time.Minute, 0)
+	assert.Equal(t, -2000.0, f.add(-2000, now))
+
+	// spike is damped
+	now = now.Add(10 * time.Second)
+	assert.InDelta(t, -1693, f.add(0, now), 1)
+
+	// step converges after several time constants
+	for i := 0; i < 60; i++ {
+		now = now.Add(10 * time.Second)
+		f.add(0, now)
+	}
+	assert.InDelta(t, 0, f.add(0, now), 1)
+
+	// hysteresis
+	f = newPowerFilter(0, 100)
+	assert.Equal(t, 500.0, f.add(500, now))
+	assert.Equal(t, 500.0, f.add(550, now.Add(time.Second)))
+	assert.Equal(t, 650.0, f.add(650, now.Add(2*time.Second)))
+}
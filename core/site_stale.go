@@ -0,0 +1,61 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/push"
+)
+
+const evDeviceOffline = "offline" // site meter stopped delivering data
+
+// staleGuard tracks the last successful update of site meters
+type staleGuard struct {
+	timeout time.Duration
+	updated map[string]time.Time
+}
+
+// update records the meter update result and returns true if the meter's data is stale.
+// Data is stale if the meter has not delivered a successful reading within timeout.
+func (g *staleGuard) update(name string, err error, now time.Time) bool {
+	if g.updated == nil {
+		g.updated = make(map[string]time.Time)
+	}
+
+	if err == nil {
+		g.updated[name] = now
+		return false
+	}
+
+	updated, ok := g.updated[name]
+	if !ok {
+		// never updated, start counting from first failure
+		g.updated[name] = now
+		return false
+	}
+
+	return g.timeout > 0 && now.Sub(updated) > g.timeout
+}
+
+// updateStale publishes the staleness of meter data and notifies when the meter goes offline
+func (site *Site) updateStale(name string, err error) {
+	// test guard
+	if site.stale == nil {
+		return
+	}
+
+	key := name + "Stale"
+	stale := site.stale.update(name, err, time.Now())
+
+	if prev, _ := site.publishCache[key].(bool); stale && !prev {
+		site.log.WARN.Printf("%s meter offline: %v", name, err)
+
+		if site.pushChan != nil {
+			site.pushChan <- push.Event{
+				Event:      evDeviceOffline,
+				Attributes: map[string]interface{}{"device": name},
+			}
+		}
+	}
+
+	site.publishDelta(key, stale)
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleGuard(t *testing.T) {
+	g := &staleGuard{timeout: time.Minute}
+
+	now := time.Now()
+	errFoo := errors.New("foo")
+
+	assert.False(t, g.update("grid", errFoo, now), "never updated")
+	assert.False(t, g.update("grid", errFoo, now.Add(time.Minute)), "timeout not exceeded")
+	assert.True(t, g.update("grid", errFoo, now.Add(2*time.Minute)), "timeout exceeded")
+
+	assert.False(t, g.update("grid", nil, now.Add(3*time.Minute)), "updated")
+	assert.False(t, g.update("pv", nil, now), "other meter")
+	assert.True(t, g.update("pv", errFoo, now.Add(3*time.Minute)), "other meter timeout exceeded")
+}
+
+func TestStaleOfflineEvent(t *testing.T) {
+	pushChan := make(chan push.Event, 2)
+
+	site := &Site{
+		log:          util.NewLogger("foo"),
+		pushChan:     pushChan,
+		publishCache: make(map[string]any),
+		stale:        &staleGuard{timeout: time.Nanosecond},
+	}
+
+	errFoo := errors.New("foo")
+
+	site.updateStale("grid", nil)
+	site.updateStale("grid", errFoo)
+	site.updateStale("grid", errFoo)
+
+	assert.Len(t, pushChan, 1, "single event while offline")
+
+	ev := <-pushChan
+	assert.Equal(t, evDeviceOffline, ev.Event)
+	assert.Equal(t, "grid", ev.Attributes["device"])
+
+	site.updateStale("grid", nil)
+	site.updateStale("grid", errFoo)
+	assert.Len(t, pushChan, 1, "event after being online again")
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+const tariffRegistersKey = "site.tariffRegisters"
+
+// tariffAccount is the grid import energy and cost attributed to the tariff registers
+type tariffAccount struct {
+	Energy [2]float64 `json:"energy"` // imported energy per register since accounting start (kWh)
+	Cost   [2]float64 `json:"cost"`   // cost of imported energy per register (e.g. EUR)
+}
+
+// tariffRegisters tracks the grid meter's tariff registers (HT/NT) to determine the active tariff
+type tariffRegisters struct {
+	energy  [2]float64    // last register readings (kWh)
+	updated bool          // readings available
+	active  int           // active tariff register, 0 if unknown
+	account tariffAccount // consumption attributed to the registers
+}
+
+// update stores the register readings and returns the active tariff register.
+// The active register is the one that has advanced since the last reading.
+// Consumption since the last reading is attributed to its register at the register's price.
+func (r *tariffRegisters) update(t1, t2 float64, prices [2]float64) int {
+	if r.updated {
+		switch {
+		case t1 > r.energy[0] && t2 == r.energy[1]:
+			r.active = 1
+		case t2 > r.energy[1] && t1 == r.energy[0]:
+			r.active = 2
+		}
+
+		// ignore decreasing readings, e.g. after meter replacement
+		for i, val := range [2]float64{t1, t2} {
+			if delta := val - r.energy[i]; delta > 0 {
+				r.account.Energy[i] += delta
+				r.account.Cost[i] += delta * prices[i]
+			}
+		}
+	}
+
+	r.energy = [2]float64{t1, t2}
+	r.updated = true
+
+	return r.active
+}
+
+// updateTariffRegisters reads the grid meter's tariff registers and attributes consumption to the active tariff
+func (site *Site) updateTariffRegisters() {
+	tariffEnergy := api.GetMeterCapabilities(site.gridMeter).TariffEnergy
+	if tariffEnergy == nil {
+		return
+	}
+
+	t1, t2, err := tariffEnergy()
+	if err != nil {
+		if !errors.Is(err, api.ErrNotAvailable) {
+			site.log.ERROR.Printf("grid tariff energy: %v", err)
+		}
+		return
+	}
+
+	site.publish("gridEnergyTariffs", []float64{t1, t2})
+
+	prev := site.registers.account
+	if active := site.registers.update(t1, t2, site.tariffRegisterPrices()); active > 0 {
+		site.log.DEBUG.Printf("grid tariff register: %d", active)
+		site.publish("gridTariffRegister", active)
+	}
+
+	account := site.registers.account
+	if account != prev {
+		if err := settings.SetJson(tariffRegistersKey, account); err != nil {
+			site.log.ERROR.Printf("grid tariff accounting: %v", err)
+		}
+	}
+
+	site.publish("gridTariffEnergy", account.Energy[:])
+	site.publish("gridTariffCost", account.Cost[:])
+}
+
+// restoreTariffRegisters restores the persisted tariff register accounting
+func (site *Site) restoreTariffRegisters() {
+	var account tariffAccount
+	if err := settings.Json(tariffRegistersKey, &account); err == nil {
+		site.registers.account = account
+	}
+}
+
+// tariffRegisterPrices returns the grid prices of tariff registers 1 and 2.
+// Without configured register prices, both registers use the current grid price.
+func (site *Site) tariffRegisterPrices() [2]float64 {
+	if len(site.TariffRegisters) == 2 {
+		return [2]float64{site.TariffRegisters[0], site.TariffRegisters[1]}
+	}
+
+	price, err := site.tariffs.CurrentGridPrice()
+	if err != nil {
+		price = DefaultGridPrice
+	}
+
+	return [2]float64{price, price}
+}
+
+// currentGridPrice returns the price of the active grid meter tariff register if configured.
+// Otherwise, the grid tariff's current price is returned.
+func (site *Site) currentGridPrice() (float64, error) {
+	if active := site.registers.active; active > 0 && len(site.TariffRegisters) == 2 {
+		return site.TariffRegisters[active-1], nil
+	}
+
+	return site.tariffs.CurrentGridPrice()
+}
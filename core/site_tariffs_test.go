@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTariffRegisters(t *testing.T) {
+	r := new(tariffRegisters)
+	prices := [2]float64{0.3, 0.2}
+
+	assert.Equal(t, 0, r.update(100, 50, prices), "initial reading")
+	assert.Equal(t, 0, r.update(100, 50, prices), "no consumption")
+	assert.Equal(t, 1, r.update(101, 50, prices), "tariff 1 advanced")
+	assert.Equal(t, 1, r.update(101, 50, prices), "no consumption keeps tariff")
+	assert.Equal(t, 1, r.update(102, 51, prices), "both advanced keeps tariff")
+	assert.Equal(t, 2, r.update(102, 52, prices), "tariff 2 advanced")
+	assert.Equal(t, 2, r.update(90, 53, prices), "register reset")
+
+	assert.Equal(t, [2]float64{2, 3}, r.account.Energy, "energy")
+	assert.InDelta(t, 0.6, r.account.Cost[0], 1e-6, "cost tariff 1")
+	assert.InDelta(t, 0.6, r.account.Cost[1], 1e-6, "cost tariff 2")
+}
+
+func TestTariffRegistersPrice(t *testing.T) {
+	grid, err := tariff.NewFixedFromConfig(map[string]interface{}{"price": 0.3})
+	assert.NoError(t, err)
+
+	s := &Site{
+		tariffs:         tariff.Tariffs{Grid: grid},
+		TariffRegisters: []float64{0.32, 0.24},
+	}
+
+	price, err := s.currentGridPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.3, price, "unknown register uses grid tariff")
+
+	s.registers.active = 2
+	price, err = s.currentGridPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.24, price, "active register price")
+}
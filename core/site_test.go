@@ -2,8 +2,13 @@ package core
 
 import (
 	"testing"
+	"time"
 
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
 	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSitePower(t *testing.T) {
@@ -33,31 +38,31 @@ func TestSitePower(t *testing.T) {
 
 func TestGreenShare(t *testing.T) {
 	tc := []struct {
-		title             string
-		grid, pv, battery float64
-		share             float64
+		title               string
+		grid, pv, battery   float64
+		share, batteryShare float64
 	}{
 		{"half grid, half pv",
 			2500, 2500, 0,
-			0.5},
+			0.5, 0},
 		{"full pv",
 			0, 5000, 0,
-			1},
+			1, 0},
 		{"full grid",
 			5000, 0, 0,
-			0},
+			0, 0},
 		{"half grid, half battery",
 			2500, 0, 2500,
-			0.5},
+			0.5, 0.5},
 		{"full pv, pv export",
 			-5000, 10000, 0,
-			1},
+			1, 0},
 		{"full pv, pv export, battery charge",
 			-2500, 10000, -2500,
-			1},
+			1, 0},
 		{"double charge speed, full grid",
 			10000, 0, 0,
-			0},
+			0, 0},
 	}
 
 	for _, tc := range tc {
@@ -73,5 +78,168 @@ func TestGreenShare(t *testing.T) {
 		if share != tc.share {
 			t.Errorf("greenShare wanted %.f, got %.f", tc.share, share)
 		}
+
+		if battery := s.batteryShare(); battery != tc.batteryShare {
+			t.Errorf("batteryShare wanted %.f, got %.f", tc.batteryShare, battery)
+		}
+	}
+}
+
+func TestGreenShareLoadpoints(t *testing.T) {
+	tc := []struct {
+		title             string
+		grid, pv, battery float64
+		charge            float64
+		share             float64
+	}{
+		{"not charging",
+			-1000, 2000, 0,
+			0,
+			0},
+		{"pv covers home and charging",
+			-1000, 6000, 0,
+			4000,
+			1},
+		{"pv covers home, half charging",
+			2000, 3000, 0,
+			4000,
+			0.5},
+		{"pv below home consumption",
+			4500, 500, 0,
+			4000,
+			0},
+		{"battery covers charging",
+			0, 1000, 4000,
+			4000,
+			1},
+		{"pv export, battery charge",
+			-500, 8000, -2500,
+			4000,
+			1},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		s := &Site{
+			gridPower:    tc.grid,
+			pvPower:      tc.pv,
+			batteryPower: tc.battery,
+		}
+
+		if share := s.greenShareLoadpoints(tc.charge); share != tc.share {
+			t.Errorf("greenShareLoadpoints wanted %.2f, got %.2f", tc.share, share)
+		}
+	}
+}
+
+func TestSiteProfile(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"))
+	x, y, z := createChannels(t)
+	attachChannels(lp, x, y, z)
+	lp.collectDefaults()
+
+	off := api.ModeOff
+	targetSoc := 80
+
+	s := &Site{
+		log:        util.NewLogger("foo"),
+		loadpoints: []*Loadpoint{lp},
+		Profiles: map[string]api.ActionConfig{
+			"holiday": {Mode: &off, TargetSoc: &targetSoc},
+		},
 	}
+
+	assert.Error(t, s.SetProfile("summer"))
+	assert.Empty(t, s.GetProfile())
+
+	assert.NoError(t, s.SetProfile("holiday"))
+	assert.Equal(t, "holiday", s.GetProfile())
+	assert.Equal(t, api.ModeOff, lp.GetMode())
+	assert.Equal(t, 80, lp.GetTargetSoc())
+	assert.Equal(t, []string{"holiday"}, s.GetProfiles())
+
+	// profile survives disconnect defaults
+	pv := api.ModePV
+	lp.ResetOnDisconnect = true
+	lp.onDisconnect.Mode = &pv
+	lp.evVehicleDisconnectHandler()
+	assert.Equal(t, api.ModeOff, lp.GetMode())
+}
+
+func TestSiteProfileSchedule(t *testing.T) {
+	s := &Site{
+		log: util.NewLogger("foo"),
+		Profiles: map[string]api.ActionConfig{
+			"summer": {}, "winter": {},
+		},
+		ProfileSchedule: []ProfileScheduleConfig{
+			{Profile: "summer", From: "04-01"},
+			{Profile: "winter", From: "10-15"},
+		},
+	}
+
+	assert.NoError(t, s.configureProfiles())
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 12, 0, 0, 0, time.Local)
+	}
+
+	assert.Equal(t, "winter", s.scheduledProfile(date(1, 10)), "previous year")
+	assert.Equal(t, "summer", s.scheduledProfile(date(4, 1)))
+	assert.Equal(t, "summer", s.scheduledProfile(date(10, 14)))
+	assert.Equal(t, "winter", s.scheduledProfile(date(10, 15)))
+
+	s.ProfileSchedule = []ProfileScheduleConfig{{Profile: "holiday", From: "08-01"}}
+	assert.Error(t, s.configureProfiles(), "unknown profile")
+
+	s.ProfileSchedule = []ProfileScheduleConfig{{Profile: "summer", From: "13-01"}}
+	assert.Error(t, s.configureProfiles(), "invalid date")
+}
+
+func TestBatteryPriority(t *testing.T) {
+	tc := []struct {
+		mode        string
+		soc         float64
+		prioritySoc float64
+		res         bool
+	}{
+		{"", 40, 50, true},
+		{"", 60, 50, false},
+		{BatteryPrioritySoc, 40, 50, true},
+		{BatteryPriorityVehicle, 40, 50, false},
+		{BatteryPriorityBattery, 60, 50, true},
+		{BatteryPriorityBattery, 60, 0, true},
+	}
+
+	for _, tc := range tc {
+		s := &Site{
+			BatteryPriority: tc.mode,
+			PrioritySoc:     tc.prioritySoc,
+			batterySoc:      tc.soc,
+		}
+
+		if res := s.batteryPriority(); res != tc.res {
+			t.Errorf("%+v: batteryPriority wanted %t, got %t", tc, tc.res, res)
+		}
+	}
+}
+
+func TestRefreshVehicle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("foo").AnyTimes()
+
+	s := &Site{
+		log: util.NewLogger("foo"),
+	}
+
+	assert.NoError(t, s.RefreshVehicle(vehicle))
+	assert.ErrorIs(t, s.RefreshVehicle(vehicle), api.ErrMustRetry)
+
+	// other vehicles are not affected
+	other := mock.NewMockVehicle(ctrl)
+	other.EXPECT().Title().Return("bar").AnyTimes()
+	assert.NoError(t, s.RefreshVehicle(other))
 }
@@ -0,0 +1,35 @@
+package core
+
+import "github.com/evcc-io/evcc/api"
+
+// recoverable is the placeholder of a vehicle that failed at startup and is retried in the background
+type recoverable interface {
+	Recovered() api.Vehicle
+}
+
+// updateVehicles swaps recovered vehicles for their placeholders.
+// The placeholder only provides the basic vehicle api, the recovered vehicle may implement further interfaces.
+func (site *Site) updateVehicles() {
+	for _, v := range site.coordinator.GetVehicles() {
+		r, ok := v.(recoverable)
+		if !ok {
+			continue
+		}
+
+		vehicle := r.Recovered()
+		if vehicle == nil {
+			continue
+		}
+
+		site.log.INFO.Printf("vehicle recovered: %s", vehicle.Title())
+		site.coordinator.Replace(v, vehicle)
+
+		for _, lp := range site.loadpoints {
+			lp.replaceVehicle(v, vehicle)
+		}
+
+		if _, ok := vehicle.(api.ChargeState); !ok && len(site.GetVehicles()) > 1 {
+			site.log.WARN.Printf("vehicle '%s' does not support automatic detection", vehicle.Title())
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type placeholder struct {
+	*mock.MockVehicle
+	recovered api.Vehicle
+}
+
+func (v *placeholder) Recovered() api.Vehicle {
+	return v.recovered
+}
+
+func TestUpdateVehicles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("foo").AnyTimes()
+
+	v := &placeholder{MockVehicle: mock.NewMockVehicle(ctrl)}
+
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		defaultVehicle: v,
+	}
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		coordinator: coordinator.New(util.NewLogger("foo"), []api.Vehicle{v}),
+		loadpoints:  []*Loadpoint{lp},
+	}
+	site.coordinator.AddIdentifier("id", v)
+	lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
+
+	// not recovered yet
+	site.updateVehicles()
+	assert.Equal(t, []api.Vehicle{v}, site.GetVehicles())
+
+	v.recovered = vehicle
+	site.updateVehicles()
+
+	assert.Equal(t, []api.Vehicle{vehicle}, site.GetVehicles())
+	assert.Equal(t, api.Vehicle(vehicle), lp.defaultVehicle)
+	assert.Equal(t, api.Vehicle(vehicle), lp.coordinator.IdentifyVehicle("id"))
+}
@@ -30,6 +30,9 @@ type Estimator struct {
 	minChargePower    float64 // Lowest charge power (just before vehicle stops charging at 100%)
 	maxChargePower    float64 // Highest charge power the battery can handle on any charger
 	maxChargeSoc      float64 // SoC at/after which maxChargePower is degressive
+	estimated         bool    // last Soc was interpolated or taken from stale data
+	vehicleQueried    bool    // vehicle api was queried by last Soc call
+	vehicleErr        error   // vehicle api error of last Soc call
 }
 
 // NewEstimator creates new estimator
@@ -51,6 +54,7 @@ func (s *Estimator) Reset() {
 	s.prevSoc = 0
 	s.prevChargedEnergy = 0
 	s.initialSoc = 0
+	s.estimated = false
 	s.capacity = float64(s.vehicle.Capacity()) * 1e3  // cache to simplify debugging
 	s.virtualCapacity = s.capacity / ChargeEfficiency // initial capacity taking efficiency into account
 	s.energyPerSocStep = s.virtualCapacity / 100
@@ -59,6 +63,29 @@ func (s *Estimator) Reset() {
 	s.maxChargeSoc = 50      // default 50%
 }
 
+// Restore seeds the estimator with a previously known Soc, e.g. persisted across restarts.
+// The value is used as base for estimation until the vehicle provides a valid Soc.
+func (s *Estimator) Restore(soc, chargedEnergy float64) {
+	if s.prevSoc != 0 {
+		return
+	}
+
+	s.vehicleSoc = soc
+	s.prevSoc = soc
+	s.prevChargedEnergy = math.Max(chargedEnergy, 0)
+	s.estimated = true
+}
+
+// VehicleResult returns true if the vehicle api was queried by the last Soc call and the api error, if any
+func (s *Estimator) VehicleResult() (bool, error) {
+	return s.vehicleQueried, s.vehicleErr
+}
+
+// Estimated returns true if the last Soc was not directly provided by the vehicle or charger
+func (s *Estimator) Estimated() bool {
+	return s.estimated
+}
+
 // RemainingChargeDuration returns the estimated remaining duration
 func (s *Estimator) RemainingChargeDuration(targetSoc int, chargePower float64) time.Duration {
 	const minChargeSoc = 100
@@ -107,6 +134,7 @@ func (s *Estimator) RemainingChargeEnergy(targetSoc int) float64 {
 // Soc replaces the api.Vehicle.Soc interface to take charged energy into account
 func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 	var fetchedSoc *float64
+	s.vehicleQueried, s.vehicleErr = false, nil
 
 	if charger, ok := s.charger.(api.Battery); ok {
 		f, err := charger.Soc()
@@ -124,6 +152,8 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 				s.log.WARN.Printf("vehicle soc (charger): %v (ignored by estimator)", err)
 			}
 
+			s.estimated = err != nil
+
 			fetchedSoc = &f
 			s.vehicleSoc = f
 		}
@@ -131,6 +161,8 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 
 	if fetchedSoc == nil {
 		f, err := s.vehicle.Soc()
+		s.vehicleQueried, s.vehicleErr = true, err
+
 		if err != nil {
 			// required for online APIs with refreshkey
 			if errors.Is(err, api.ErrMustRetry) {
@@ -147,6 +179,8 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 			s.log.WARN.Printf("vehicle soc: %v (ignored by estimator)", err)
 		}
 
+		s.estimated = err != nil
+
 		fetchedSoc = &f
 		s.vehicleSoc = f
 	}
@@ -195,6 +229,7 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 			s.prevSoc = s.vehicleSoc
 		} else {
 			s.vehicleSoc = math.Min(*fetchedSoc+energyDelta/s.energyPerSocStep, 100)
+			s.estimated = s.estimated || energyDelta > 0
 			s.log.DEBUG.Printf("soc estimated: %.2f%% (vehicle: %.2f%%)", s.vehicleSoc, *fetchedSoc)
 		}
 	}
@@ -243,3 +243,30 @@ func TestImprovedEstimatorRemainingChargeDuration(t *testing.T) {
 		assert.Equal(t, tc.duration, ce.RemainingChargeDuration(tc.targetsoc, tc.chargePower))
 	}
 }
+
+func TestSocEstimationRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+	vehicle := mock.NewMockVehicle(ctrl)
+
+	// 9 kWh user battery capacity is converted to initial value of 10 kWh virtual capacity
+	vehicle.EXPECT().Capacity().Return(float64(9))
+
+	ce := NewEstimator(util.NewLogger("foo"), charger, vehicle, true)
+	ce.Restore(40, 0)
+	assert.True(t, ce.Estimated())
+
+	// vehicle api unavailable, estimate from restored soc
+	vehicle.EXPECT().Soc().Return(0.0, errors.New("unavailable"))
+	soc, err := ce.Soc(1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, soc)
+	assert.True(t, ce.Estimated())
+
+	// vehicle api recovered
+	vehicle.EXPECT().Soc().Return(55.0, nil)
+	soc, err = ce.Soc(1500)
+	assert.NoError(t, err)
+	assert.Equal(t, 55.0, soc)
+	assert.False(t, ce.Estimated())
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/db"
+	"github.com/evcc-io/evcc/push"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+)
+
+// summary periods
+const (
+	SummaryDaily  = "daily"
+	SummaryWeekly = "weekly"
+)
+
+const evSummary = "summary" // periodic session summary
+
+// summaryPeriod returns the reporting period that has ended at the last period boundary before now
+func summaryPeriod(period string, now time.Time) (time.Time, time.Time) {
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if period == SummaryWeekly {
+		// weeks start on monday
+		to = to.AddDate(0, 0, -(int(to.Weekday())+6)%7)
+		return to.AddDate(0, 0, -7), to
+	}
+
+	return to.AddDate(0, 0, -1), to
+}
+
+// nextSummary returns the end of the current reporting period
+func nextSummary(period string, now time.Time) time.Time {
+	_, to := summaryPeriod(period, now)
+
+	if period == SummaryWeekly {
+		return to.AddDate(0, 0, 7)
+	}
+
+	return to.AddDate(0, 0, 1)
+}
+
+// summaryAttributes converts the report into push message template values
+func summaryAttributes(period string, r db.Report) map[string]interface{} {
+	deref := func(f *float64) float64 {
+		if f == nil {
+			return 0
+		}
+		return *f
+	}
+
+	res := map[string]interface{}{
+		"summaryPeriod":            period,
+		"summaryFrom":              r.From,
+		"summaryTo":                r.To,
+		"summarySessions":          r.Sessions,
+		"summaryChargedEnergy":     r.ChargedEnergy,
+		"summarySolarPercentage":   deref(r.SolarPercentage),
+		"summaryBatteryPercentage": deref(r.BatteryPercentage),
+		"summaryPrice":             deref(r.Price),
+		"summaryPricePerKWh":       deref(r.PricePerKWh),
+		"summaryCheapest":          r.Cheapest,
+		"summaryMostExpensive":     r.MostExpensive,
+	}
+
+	if r.Cheapest != nil {
+		res["summaryCheapestPricePerKWh"] = *r.Cheapest.PricePerKWh
+		res["summaryMostExpensivePricePerKWh"] = *r.MostExpensive.PricePerKWh
+	}
+
+	return res
+}
+
+// RunSummary sends a summary of the charging sessions via the push messengers once per period
+func RunSummary(period string, pushChan chan<- push.Event) error {
+	if period != SummaryDaily && period != SummaryWeekly {
+		return fmt.Errorf("invalid summary period: %s", period)
+	}
+
+	if serverdb.Instance == nil {
+		return fmt.Errorf("summary requires database")
+	}
+
+	go runSummary(clock.New(), period, pushChan)
+
+	return nil
+}
+
+func runSummary(clock clock.Clock, period string, pushChan chan<- push.Event) {
+	log := util.NewLogger("summary")
+
+	for {
+		clock.Sleep(clock.Until(nextSummary(period, clock.Now())))
+
+		from, to := summaryPeriod(period, clock.Now())
+
+		var sessions db.Sessions
+		if err := serverdb.Instance.Where("created >= ? AND created < ?", from, to).Find(&sessions).Error; err != nil {
+			log.ERROR.Println(err)
+			continue
+		}
+
+		pushChan <- push.Event{
+			Event:      evSummary,
+			Attributes: summaryAttributes(period, sessions.Report(from, to)),
+		}
+	}
+}
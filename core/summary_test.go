@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryPeriod(t *testing.T) {
+	// wednesday
+	now := time.Date(2023, 6, 14, 8, 30, 0, 0, time.Local)
+
+	from, to := summaryPeriod(SummaryDaily, now)
+	assert.Equal(t, time.Date(2023, 6, 13, 0, 0, 0, 0, time.Local), from)
+	assert.Equal(t, time.Date(2023, 6, 14, 0, 0, 0, 0, time.Local), to)
+	assert.Equal(t, time.Date(2023, 6, 15, 0, 0, 0, 0, time.Local), nextSummary(SummaryDaily, now))
+
+	from, to = summaryPeriod(SummaryWeekly, now)
+	assert.Equal(t, time.Date(2023, 6, 5, 0, 0, 0, 0, time.Local), from)
+	assert.Equal(t, time.Date(2023, 6, 12, 0, 0, 0, 0, time.Local), to)
+	assert.Equal(t, time.Date(2023, 6, 19, 0, 0, 0, 0, time.Local), nextSummary(SummaryWeekly, now))
+
+	// sunday belongs to the running week
+	_, to = summaryPeriod(SummaryWeekly, time.Date(2023, 6, 18, 23, 0, 0, 0, time.Local))
+	assert.Equal(t, time.Date(2023, 6, 12, 0, 0, 0, 0, time.Local), to)
+
+	// monday starts a new week
+	_, to = summaryPeriod(SummaryWeekly, time.Date(2023, 6, 19, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, time.Date(2023, 6, 19, 0, 0, 0, 0, time.Local), to)
+}
@@ -10,6 +10,11 @@ import (
 	"github.com/evcc-io/evcc/util"
 )
 
+const (
+	maxChargePower   = 350  // kW, upper bound for plausible counter increases
+	counterTolerance = 0.01 // kWh, counter decreases below this are considered jitter
+)
+
 // ChargeRater is responsible for providing charged energy amount
 // by implementing api.ChargeRater. It uses the charge meter's TotalEnergy or
 // keeps track of consumed energy by regularly updating consumed power.
@@ -22,6 +27,8 @@ type ChargeRater struct {
 	start         time.Time
 	startEnergy   float64
 	chargedEnergy float64
+	lastEnergy    float64
+	lastUpdated   time.Time
 }
 
 // NewChargeRater creates charge rater and initializes realtime clock
@@ -46,6 +53,8 @@ func (cr *ChargeRater) StartCharge(continued bool) {
 	if m, ok := cr.meter.(api.MeterEnergy); ok {
 		if f, err := m.TotalEnergy(); err == nil {
 			cr.startEnergy = f
+			cr.lastEnergy = f
+			cr.lastUpdated = cr.start
 			cr.log.DEBUG.Printf("charge start energy: %.3gkWh", f)
 		} else {
 			cr.log.ERROR.Printf("charge meter: %v", err)
@@ -70,7 +79,7 @@ func (cr *ChargeRater) StopCharge() {
 	// get end energy amount
 	if m, ok := cr.meter.(api.MeterEnergy); ok {
 		if f, err := m.TotalEnergy(); err == nil {
-			cr.chargedEnergy += f - cr.startEnergy
+			cr.chargedEnergy = cr.energy(f)
 			cr.log.DEBUG.Printf("final charge energy: %.3gkWh", cr.chargedEnergy)
 		} else {
 			cr.log.ERROR.Printf("charge meter error %v", err)
@@ -78,6 +87,34 @@ func (cr *ChargeRater) StopCharge() {
 	}
 }
 
+// energy returns the charged energy for the given meter reading. Counter resets
+// and implausible jumps (e.g. firmware updates, 16/32 bit wraps) rebase the
+// session baseline instead of producing negative or huge charged energies.
+func (cr *ChargeRater) energy(f float64) float64 {
+	now := cr.clck.Now()
+
+	switch delta := f - cr.lastEnergy; {
+	case delta < 0 && -delta < counterTolerance:
+		// jitter, keep last reading
+		f = cr.lastEnergy
+
+	case delta < 0:
+		cr.log.WARN.Printf("charge meter counter reset: %.3fkWh -> %.3fkWh", cr.lastEnergy, f)
+		cr.chargedEnergy += cr.lastEnergy - cr.startEnergy
+		cr.startEnergy = 0
+
+	case delta > maxChargePower*now.Sub(cr.lastUpdated).Hours()+counterTolerance:
+		cr.log.WARN.Printf("charge meter counter jump: %.3fkWh -> %.3fkWh", cr.lastEnergy, f)
+		cr.chargedEnergy += cr.lastEnergy - cr.startEnergy
+		cr.startEnergy = f
+	}
+
+	cr.lastEnergy = f
+	cr.lastUpdated = now
+
+	return cr.chargedEnergy + f - cr.startEnergy
+}
+
 // SetChargePower increments consumed energy by amount in kWh since last update
 func (cr *ChargeRater) SetChargePower(power float64) {
 	cr.Lock()
@@ -112,7 +149,7 @@ func (cr *ChargeRater) ChargedEnergy() (float64, error) {
 		f, err := m.TotalEnergy()
 
 		if err == nil {
-			return cr.chargedEnergy + f - cr.startEnergy, nil
+			return cr.energy(f), nil
 		}
 
 		return 0, fmt.Errorf("charge meter error %v", err)
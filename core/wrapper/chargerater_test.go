@@ -111,3 +111,54 @@ func TestWrappedMeter(t *testing.T) {
 		t.Errorf("energy: %.1f %v", f, err)
 	}
 }
+
+func TestWrappedMeterCounterReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mm := mock.NewMockMeter(ctrl)
+	me := mock.NewMockMeterEnergy(ctrl)
+
+	type EnergyDecorator struct {
+		api.Meter
+		api.MeterEnergy
+	}
+
+	cm := &EnergyDecorator{Meter: mm, MeterEnergy: me}
+
+	cr := NewChargeRater(util.NewLogger("foo"), cm)
+	clck := clock.NewMock()
+	cr.clck = clck
+
+	me.EXPECT().TotalEnergy().Return(100.0, nil)
+	cr.StartCharge(true)
+
+	// 2kWh
+	clck.Add(time.Hour)
+	me.EXPECT().TotalEnergy().Return(102.0, nil)
+	if f, err := cr.ChargedEnergy(); f != 2 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+
+	// counter reset, 1kWh after reset
+	clck.Add(time.Hour)
+	me.EXPECT().TotalEnergy().Return(1.0, nil)
+	if f, err := cr.ChargedEnergy(); f != 3 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+
+	// implausible jump, ignored
+	clck.Add(time.Minute)
+	me.EXPECT().TotalEnergy().Return(65536.0, nil)
+	if f, err := cr.ChargedEnergy(); f != 3 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+
+	// 1kWh after jump
+	clck.Add(time.Hour)
+	me.EXPECT().TotalEnergy().Return(65537.0, nil)
+	cr.StopCharge()
+
+	if f, err := cr.ChargedEnergy(); f != 4 || err != nil {
+		t.Errorf("energy: %.1f %v", f, err)
+	}
+}
@@ -0,0 +1,54 @@
+package openadr
+
+import (
+	"bytes"
+	"text/template"
+)
+
+var requestEventTmpl = template.Must(template.New("requestEvent").Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<oadr:oadrPayload xmlns:oadr="http://openadr.org/oadr-2.0b/2012/07" xmlns:ei="http://docs.oasis-open.org/ns/energyinterop/201110" xmlns:pyld="http://docs.oasis-open.org/ns/energyinterop/201110/payloads">
+  <oadr:oadrSignedObject>
+    <oadr:oadrRequestEvent ei:schemaVersion="2.0b">
+      <pyld:eiRequestEvent>
+        <pyld:requestID>{{ html .RequestID }}</pyld:requestID>
+        <ei:venID>{{ html .VenID }}</ei:venID>
+      </pyld:eiRequestEvent>
+    </oadr:oadrRequestEvent>
+  </oadr:oadrSignedObject>
+</oadr:oadrPayload>`))
+
+var createdEventTmpl = template.Must(template.New("createdEvent").Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<oadr:oadrPayload xmlns:oadr="http://openadr.org/oadr-2.0b/2012/07" xmlns:ei="http://docs.oasis-open.org/ns/energyinterop/201110" xmlns:pyld="http://docs.oasis-open.org/ns/energyinterop/201110/payloads">
+  <oadr:oadrSignedObject>
+    <oadr:oadrCreatedEvent ei:schemaVersion="2.0b">
+      <pyld:eiCreatedEvent>
+        <ei:eiResponse>
+          <ei:responseCode>200</ei:responseCode>
+          <ei:responseDescription>OK</ei:responseDescription>
+          <pyld:requestID>{{ html .RequestID }}</pyld:requestID>
+        </ei:eiResponse>
+        <ei:eventResponses>
+          {{- range .Events }}
+          <ei:eventResponse>
+            <ei:responseCode>200</ei:responseCode>
+            <ei:responseDescription>OK</ei:responseDescription>
+            <pyld:requestID>{{ html $.RequestID }}</pyld:requestID>
+            <ei:qualifiedEventID>
+              <ei:eventID>{{ html .EiEvent.EventDescriptor.EventID }}</ei:eventID>
+              <ei:modificationNumber>{{ .EiEvent.EventDescriptor.ModificationNumber }}</ei:modificationNumber>
+            </ei:qualifiedEventID>
+            <ei:optType>optIn</ei:optType>
+          </ei:eventResponse>
+          {{- end }}
+        </ei:eventResponses>
+        <ei:venID>{{ html .VenID }}</ei:venID>
+      </pyld:eiCreatedEvent>
+    </oadr:oadrCreatedEvent>
+  </oadr:oadrSignedObject>
+</oadr:oadrPayload>`))
+
+func render(tmpl *template.Template, data any) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	err := tmpl.Execute(buf, data)
+	return buf, err
+}
@@ -0,0 +1,220 @@
+package openadr
+
+import (
+	"crypto/tls"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+	"github.com/google/uuid"
+)
+
+const (
+	remoteSource = "openadr"
+	eiEvent      = "/OpenADR2/Simple/2.0b/EiEvent"
+)
+
+// OpenADR is an OpenADR 2.0b virtual end node (VEN) polling a utility's virtual top node (VTN) for demand response events
+type OpenADR struct {
+	*request.Helper
+	log          *util.Logger
+	site         site.API
+	uri          string
+	venID        string
+	interval     time.Duration
+	acknowledged map[string]int // event id and modification number
+	demand       loadpoint.RemoteDemand
+}
+
+// New creates OpenADR VEN
+func New(conf map[string]interface{}, site site.API) (*OpenADR, error) {
+	cc := struct {
+		URI              string
+		VenID            string
+		Certificate, Key string
+		Insecure         bool
+		Interval         time.Duration
+	}{
+		Interval: time.Minute,
+	}
+
+	if err := util.DecodeOther(conf, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" || cc.VenID == "" {
+		return nil, errors.New("missing uri or ven id")
+	}
+
+	log := util.NewLogger("openadr")
+
+	s := &OpenADR{
+		Helper:       request.NewHelper(log),
+		log:          log,
+		site:         site,
+		uri:          strings.TrimSuffix(cc.URI, "/"),
+		venID:        cc.VenID,
+		interval:     cc.Interval,
+		acknowledged: make(map[string]int),
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: cc.Insecure}
+
+	// client certificate authentication
+	if cc.Certificate != "" {
+		cert, err := tls.X509KeyPair([]byte(cc.Certificate), []byte(cc.Key))
+		if err != nil {
+			return nil, fmt.Errorf("certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	t := transport.Default()
+	t.TLSClientConfig = tlsConfig
+	s.Client.Transport = t
+
+	return s, nil
+}
+
+// Run executes the OpenADR VEN
+func (s *OpenADR) Run() {
+	for tick := time.Tick(s.interval); ; <-tick {
+		if err := s.update(); err != nil {
+			s.log.ERROR.Println(err)
+		}
+	}
+}
+
+// post sends an OpenADR message to the VTN
+func (s *OpenADR) post(body fmt.Stringer) (Payload, error) {
+	var res Payload
+
+	req, err := request.New(http.MethodPost, s.uri+eiEvent, strings.NewReader(body.String()), map[string]string{
+		"Content-Type": "application/xml",
+	})
+	if err == nil {
+		var b []byte
+		if b, err = s.DoBody(req); err == nil {
+			err = xml.Unmarshal(b, &res)
+		}
+	}
+
+	return res, err
+}
+
+func (s *OpenADR) update() error {
+	body, err := render(requestEventTmpl, map[string]string{
+		"RequestID": uuid.NewString(),
+		"VenID":     s.venID,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := s.post(body)
+	if err != nil {
+		return err
+	}
+
+	dist := res.SignedObject.DistributeEvent
+	if dist == nil {
+		return errors.New("missing distribute event")
+	}
+
+	if code := dist.EiResponse.ResponseCode; code != "" && code != "200" {
+		return fmt.Errorf("vtn: %s %s", code, dist.EiResponse.ResponseDescription)
+	}
+
+	if err := s.acknowledge(dist); err != nil {
+		s.log.ERROR.Printf("created event: %v", err)
+	}
+
+	s.apply(dist.Events)
+
+	return nil
+}
+
+// acknowledge opts in to new or modified events requiring a response
+func (s *OpenADR) acknowledge(dist *DistributeEvent) error {
+	var events []Event
+	for _, e := range dist.Events {
+		mod := e.EiEvent.EventDescriptor.ModificationNumber
+		if ack, ok := s.acknowledged[e.ID()]; e.ResponseRequired == responseRequiredAlways && (!ok || ack != mod) {
+			events = append(events, e)
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	body, err := render(createdEventTmpl, map[string]any{
+		"RequestID": dist.RequestID,
+		"VenID":     s.venID,
+		"Events":    events,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := s.post(body)
+	if err != nil {
+		return err
+	}
+
+	if res.SignedObject.Response != nil {
+		if code := res.SignedObject.Response.EiResponse.ResponseCode; code != "" && code != "200" {
+			return fmt.Errorf("vtn: %s %s", code, res.SignedObject.Response.EiResponse.ResponseDescription)
+		}
+	}
+
+	for _, e := range events {
+		s.acknowledged[e.ID()] = e.EiEvent.EventDescriptor.ModificationNumber
+	}
+
+	return nil
+}
+
+// apply translates the highest active event level into loadpoint remote demand
+func (s *OpenADR) apply(events []Event) {
+	now := time.Now()
+
+	var level int
+	for _, e := range events {
+		if e.Active(now) && e.Level() > level {
+			level = e.Level()
+		}
+	}
+
+	demand := demandLevel(level)
+	if demand == s.demand {
+		return
+	}
+
+	s.log.DEBUG.Printf("event level %d: remote demand: %q", level, demand)
+	s.demand = demand
+
+	for _, lp := range s.site.Loadpoints() {
+		lp.RemoteControl(remoteSource, demand)
+	}
+}
+
+// demandLevel maps SIMPLE signal levels to remote demand
+func demandLevel(level int) loadpoint.RemoteDemand {
+	switch {
+	case level >= 2:
+		return loadpoint.RemoteHardDisable
+	case level == 1:
+		return loadpoint.RemoteSoftDisable
+	default:
+		return loadpoint.RemoteEnable
+	}
+}
@@ -0,0 +1,122 @@
+package openadr
+
+import (
+	"time"
+
+	"github.com/dylanmei/iso8601"
+)
+
+// OpenADR 2.0b simple http pull profile
+// https://www.openadr.org/specification
+
+const (
+	eventStatusActive    = "active"
+	eventStatusCompleted = "completed"
+	eventStatusCancelled = "cancelled"
+
+	signalSimple = "SIMPLE"
+
+	responseRequiredAlways = "always"
+)
+
+// Payload is the oadrPayload envelope
+type Payload struct {
+	SignedObject struct {
+		DistributeEvent *DistributeEvent `xml:"oadrDistributeEvent"`
+		Response        *Response        `xml:"oadrResponse"`
+	} `xml:"oadrSignedObject"`
+}
+
+// Response is the oadrResponse message
+type Response struct {
+	EiResponse EiResponse `xml:"eiResponse"`
+}
+
+// EiResponse is the ei response status
+type EiResponse struct {
+	ResponseCode        string `xml:"responseCode"`
+	ResponseDescription string `xml:"responseDescription"`
+	RequestID           string `xml:"requestID"`
+}
+
+// DistributeEvent is the oadrDistributeEvent message
+type DistributeEvent struct {
+	EiResponse EiResponse `xml:"eiResponse"`
+	RequestID  string     `xml:"requestID"`
+	VtnID      string     `xml:"vtnID"`
+	Events     []Event    `xml:"oadrEvent"`
+}
+
+// Event is the oadrEvent
+type Event struct {
+	EiEvent struct {
+		EventDescriptor struct {
+			EventID            string `xml:"eventID"`
+			ModificationNumber int    `xml:"modificationNumber"`
+			EventStatus        string `xml:"eventStatus"`
+		} `xml:"eventDescriptor"`
+		ActivePeriod struct {
+			Properties struct {
+				Start    time.Time `xml:"dtstart>date-time"`
+				Duration string    `xml:"duration>duration"`
+			} `xml:"properties"`
+		} `xml:"eiActivePeriod"`
+		Signals []struct {
+			SignalName   string  `xml:"signalName"`
+			SignalType   string  `xml:"signalType"`
+			CurrentValue float64 `xml:"currentValue>payloadFloat>value"`
+		} `xml:"eiEventSignals>eiEventSignal"`
+	} `xml:"eiEvent"`
+	ResponseRequired string `xml:"oadrResponseRequired"`
+}
+
+// ID returns the event id
+func (e Event) ID() string {
+	return e.EiEvent.EventDescriptor.EventID
+}
+
+// Interval returns the event's active period
+func (e Event) Interval() (time.Time, time.Time, error) {
+	props := e.EiEvent.ActivePeriod.Properties
+
+	// zero duration denotes an open-ended event
+	if props.Duration == "" || props.Duration == "PT0S" {
+		return props.Start, time.Time{}, nil
+	}
+
+	d, err := iso8601.ParseDuration(props.Duration)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return props.Start, props.Start.Add(d), nil
+}
+
+// Active returns true if the event is active at given time
+func (e Event) Active(now time.Time) bool {
+	switch e.EiEvent.EventDescriptor.EventStatus {
+	case eventStatusCancelled, eventStatusCompleted:
+		return false
+	case eventStatusActive:
+		return true
+	}
+
+	start, end, err := e.Interval()
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(start) && (end.IsZero() || now.Before(end))
+}
+
+// Level returns the SIMPLE signal level
+func (e Event) Level() int {
+	for _, s := range e.EiEvent.Signals {
+		if s.SignalName == signalSimple {
+			return int(s.CurrentValue)
+		}
+	}
+
+	// events without simple signal are treated as moderate
+	return 1
+}
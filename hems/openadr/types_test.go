@@ -0,0 +1,64 @@
+package openadr
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistributeEvent(t *testing.T) {
+	payload := `<oadr:oadrPayload xmlns:oadr="http://openadr.org/oadr-2.0b/2012/07" xmlns:ei="http://docs.oasis-open.org/ns/energyinterop/201110" xmlns:pyld="http://docs.oasis-open.org/ns/energyinterop/201110/payloads" xmlns:xcal="urn:ietf:params:xml:ns:icalendar-2.0" xmlns:strm="urn:ietf:params:xml:ns:icalendar-2.0:stream">
+<oadr:oadrSignedObject>
+<oadr:oadrDistributeEvent ei:schemaVersion="2.0b">
+	<ei:eiResponse><ei:responseCode>200</ei:responseCode><pyld:requestID>req</pyld:requestID></ei:eiResponse>
+	<pyld:requestID>dist</pyld:requestID>
+	<ei:vtnID>vtn</ei:vtnID>
+	<oadr:oadrEvent>
+	<ei:eiEvent>
+		<ei:eventDescriptor>
+			<ei:eventID>event1</ei:eventID>
+			<ei:modificationNumber>2</ei:modificationNumber>
+			<ei:eventStatus>far</ei:eventStatus>
+		</ei:eventDescriptor>
+		<ei:eiActivePeriod>
+			<xcal:properties>
+				<xcal:dtstart><xcal:date-time>2023-06-01T12:00:00Z</xcal:date-time></xcal:dtstart>
+				<xcal:duration><xcal:duration>PT1H</xcal:duration></xcal:duration>
+			</xcal:properties>
+		</ei:eiActivePeriod>
+		<ei:eiEventSignals>
+			<ei:eiEventSignal>
+				<ei:signalName>SIMPLE</ei:signalName>
+				<ei:signalType>level</ei:signalType>
+				<ei:currentValue><ei:payloadFloat><ei:value>2.0</ei:value></ei:payloadFloat></ei:currentValue>
+			</ei:eiEventSignal>
+		</ei:eiEventSignals>
+	</ei:eiEvent>
+	<oadr:oadrResponseRequired>always</oadr:oadrResponseRequired>
+	</oadr:oadrEvent>
+</oadr:oadrDistributeEvent>
+</oadr:oadrSignedObject>
+</oadr:oadrPayload>`
+
+	var res Payload
+	require.NoError(t, xml.Unmarshal([]byte(payload), &res))
+	require.NotNil(t, res.SignedObject.DistributeEvent)
+
+	dist := res.SignedObject.DistributeEvent
+	assert.Equal(t, "dist", dist.RequestID)
+	require.Len(t, dist.Events, 1)
+
+	e := dist.Events[0]
+	assert.Equal(t, "event1", e.ID())
+	assert.Equal(t, 2, e.EiEvent.EventDescriptor.ModificationNumber)
+	assert.Equal(t, responseRequiredAlways, e.ResponseRequired)
+	assert.Equal(t, 2, e.Level())
+
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.False(t, e.Active(start.Add(-time.Minute)))
+	assert.True(t, e.Active(start))
+	assert.False(t, e.Active(start.Add(time.Hour)))
+}
@@ -42,6 +42,8 @@ type SEMP struct {
 	closeC       chan struct{}
 	doneC        chan struct{}
 	controllable bool
+	minOnTime    time.Duration
+	minOffTime   time.Duration
 	vid          string
 	did          []byte
 	uid          string
@@ -56,6 +58,8 @@ func New(conf map[string]interface{}, site site.API, httpd *server.HTTPd) (*SEMP
 		VendorID     string
 		DeviceID     string
 		AllowControl bool
+		MinOnTime    time.Duration
+		MinOffTime   time.Duration
 	}{
 		VendorID: "28081973",
 	}
@@ -96,6 +100,8 @@ func New(conf map[string]interface{}, site site.API, httpd *server.HTTPd) (*SEMP
 		vid:          cc.VendorID,
 		did:          did,
 		controllable: cc.AllowControl,
+		minOnTime:    cc.MinOnTime,
+		minOffTime:   cc.MinOffTime,
 	}
 
 	// find external port
@@ -390,6 +396,8 @@ func (s *SEMP) deviceInfo(id int, lp loadpoint.API) DeviceInfo {
 		Characteristics: Characteristics{
 			MinPowerConsumption: int(lp.GetMinPower()),
 			MaxPowerConsumption: int(lp.GetMaxPower()),
+			MinOnTime:           int(s.minOnTime / time.Second),
+			MinOffTime:          int(s.minOffTime / time.Second),
 		},
 	}
 
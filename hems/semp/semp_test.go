@@ -0,0 +1,64 @@
+package semp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().HasChargeMeter().Return(true)
+	lp.EXPECT().Title().Return("Garage")
+	lp.EXPECT().GetMinPower().Return(1380.0)
+	lp.EXPECT().GetMaxPower().Return(11040.0)
+
+	s := &SEMP{
+		vid:        "28081973",
+		did:        []byte{0, 0, 0, 0, 0, 1},
+		uid:        "00000000-0000-0000-0000-000000000000",
+		minOnTime:  5 * time.Minute,
+		minOffTime: time.Minute,
+	}
+
+	res := s.deviceInfo(1, lp)
+
+	assert.Equal(t, "F-28081973-000000000002-00", res.Identification.DeviceID)
+	assert.Equal(t, MethodMeasurement, res.Capabilities.CurrentPowerMethod)
+	assert.Equal(t, Characteristics{
+		MinPowerConsumption: 1380,
+		MaxPowerConsumption: 11040,
+		MinOnTime:           300,
+		MinOffTime:          60,
+	}, res.Characteristics)
+}
+
+func TestPlanningRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().GetStatus().Return(api.StatusB).AnyTimes()
+	lp.EXPECT().GetRemainingDuration().Return(time.Hour)
+	lp.EXPECT().GetRemainingEnergy().Return(5e3)
+	lp.EXPECT().GetMinPower().Return(1380.0)
+	lp.EXPECT().GetMaxPower().Return(11040.0)
+
+	s := &SEMP{vid: "28081973", did: make([]byte, 6)}
+
+	res := s.planningRequest(0, lp)
+	require.Len(t, res.Timeframe, 1)
+
+	tf := res.Timeframe[0]
+	assert.Equal(t, 24*3600, tf.LatestEnd)
+	assert.Equal(t, 0, *tf.MinEnergy)
+	assert.Equal(t, 5000, *tf.MaxEnergy)
+	assert.Equal(t, 1380, *tf.MinPowerConsumption)
+}
@@ -0,0 +1,175 @@
+package sunspec
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// SunSpec register map base address and model layout
+const (
+	baseAddr = 40000
+
+	modelCommon   = 1   // common
+	modelInverter = 103 // three phase inverter measurements
+	modelSettings = 121 // basic settings
+	modelControls = 123 // immediate controls
+
+	lenCommon   = 66
+	lenInverter = 50
+	lenSettings = 30
+	lenControls = 24
+
+	// model body offsets relative to base address
+	ofsCommon   = 2 + 2
+	ofsInverter = ofsCommon + lenCommon + 2
+	ofsSettings = ofsInverter + lenInverter + 2
+	ofsControls = ofsSettings + lenSettings + 2
+	ofsEnd      = ofsControls + lenControls
+
+	// total register count including end marker
+	regCount = ofsEnd + 2
+)
+
+// immediate controls register offsets
+const (
+	ctrlConn       = 2
+	ctrlWMaxLimPct = 3
+	ctrlWMaxLimEna = 7
+)
+
+// operating states
+const (
+	stOff       = 1
+	stSleeping  = 2
+	stMppt      = 4
+	stThrottled = 5
+	stStandby   = 8
+)
+
+// unimplemented values
+const (
+	nanInt16  = 0x8000
+	nanUint16 = 0xFFFF
+)
+
+// registers is the SunSpec register image
+type registers []uint16
+
+func newRegisters() registers {
+	return make(registers, regCount)
+}
+
+func (r registers) header(ofs int, id, length uint16) {
+	r[ofs-2] = id
+	r[ofs-1] = length
+}
+
+func (r registers) string(ofs, length int, s string) {
+	b := make([]byte, 2*length)
+	copy(b, s)
+	for i := 0; i < length; i++ {
+		r[ofs+i] = binary.BigEndian.Uint16(b[2*i:])
+	}
+}
+
+func (r registers) fill(ofs, length int, val uint16) {
+	for i := 0; i < length; i++ {
+		r[ofs+i] = val
+	}
+}
+
+func int16Value(f float64) uint16 {
+	return uint16(int16(math.Max(math.MinInt16+1, math.Min(math.MaxInt16, math.Round(f)))))
+}
+
+// status contains the loadpoint values exposed via SunSpec
+type status struct {
+	UnitID       uint8
+	Title        string
+	Version      string
+	Serial       string
+	Status       api.ChargeStatus
+	Power        float64
+	MaxPower     float64
+	Disconnected bool
+	Limit        uint16 // percent
+	LimitEnabled bool
+}
+
+// encode creates the register image for given loadpoint status
+func encode(s status) registers {
+	r := newRegisters()
+
+	// SunSpec marker
+	r.string(0, 2, "SunS")
+
+	// common
+	r.header(ofsCommon, modelCommon, lenCommon)
+	r.string(ofsCommon, 16, "evcc")
+	r.string(ofsCommon+16, 16, "EV Charger")
+	r.string(ofsCommon+32, 8, s.Title)
+	r.string(ofsCommon+40, 8, s.Version)
+	r.string(ofsCommon+48, 16, s.Serial)
+	r[ofsCommon+64] = uint16(s.UnitID)
+	r[ofsCommon+65] = nanInt16
+
+	// inverter
+	r.header(ofsInverter, modelInverter, lenInverter)
+	r.fill(ofsInverter, lenInverter, nanInt16)
+	r[ofsInverter+4] = 0                        // A_SF
+	r[ofsInverter+11] = 0                       // V_SF
+	r[ofsInverter+12] = int16Value(s.Power)     // W
+	r[ofsInverter+13] = 0                       // W_SF
+	r[ofsInverter+15] = 0                       // Hz_SF
+	r[ofsInverter+17] = 0                       // VA_SF
+	r[ofsInverter+19] = 0                       // VAr_SF
+	r[ofsInverter+21] = 0                       // PF_SF
+	r[ofsInverter+22], r[ofsInverter+23] = 0, 0 // WH (unimplemented)
+	r[ofsInverter+24] = 0                       // WH_SF
+	r[ofsInverter+36] = operatingState(s)       // St
+	r[ofsInverter+37] = nanUint16               // StVnd
+	r.fill(ofsInverter+38, lenInverter-38, 0)   // events
+
+	// settings
+	r.header(ofsSettings, modelSettings, lenSettings)
+	r.fill(ofsSettings, lenSettings, nanInt16)
+	r[ofsSettings] = int16Value(s.MaxPower) // WMax
+	r.fill(ofsSettings+20, 10, 0)           // scale factors
+
+	// controls
+	r.header(ofsControls, modelControls, lenControls)
+	r.fill(ofsControls, lenControls, nanUint16)
+	r[ofsControls+ctrlConn] = 1
+	if s.Disconnected {
+		r[ofsControls+ctrlConn] = 0
+	}
+	r[ofsControls+ctrlWMaxLimPct] = s.Limit
+	r[ofsControls+ctrlWMaxLimEna] = 0
+	if s.LimitEnabled {
+		r[ofsControls+ctrlWMaxLimEna] = 1
+	}
+	r.fill(ofsControls+21, 3, 0) // scale factors
+
+	// end marker
+	r[ofsEnd] = nanUint16
+	r[ofsEnd+1] = 0
+
+	return r
+}
+
+func operatingState(s status) uint16 {
+	switch {
+	case s.Disconnected:
+		return stOff
+	case s.Status == api.StatusC && s.LimitEnabled:
+		return stThrottled
+	case s.Status == api.StatusC:
+		return stMppt
+	case s.Status == api.StatusB:
+		return stStandby
+	default:
+		return stSleeping
+	}
+}
@@ -0,0 +1,205 @@
+package sunspec
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/machine"
+)
+
+const remoteSource = "sunspec"
+
+// SunSpec exposes loadpoints as SunSpec devices via Modbus TCP. Each loadpoint is available using its number as unit id.
+type SunSpec struct {
+	mbserver.RequestHandler
+	mu           sync.Mutex
+	log          *util.Logger
+	site         site.API
+	port         int
+	id           string
+	allowControl bool
+	controls     map[int]*control
+}
+
+// control is the immediate control state of a loadpoint
+type control struct {
+	disconnected bool
+	limit        uint16
+	limitEnabled bool
+	maxCurrent   float64 // configured max current before limiting
+}
+
+// New creates SunSpec Modbus TCP server
+func New(conf map[string]interface{}, site site.API) (*SunSpec, error) {
+	cc := struct {
+		Port         int
+		AllowControl bool
+	}{
+		Port: 502,
+	}
+
+	if err := util.DecodeOther(conf, &cc); err != nil {
+		return nil, err
+	}
+
+	id, err := machine.ProtectedID("evcc-sunspec")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SunSpec{
+		RequestHandler: new(mbserver.DummyHandler),
+		log:            util.NewLogger("sunspec"),
+		site:           site,
+		port:           cc.Port,
+		id:             id,
+		allowControl:   cc.AllowControl,
+		controls:       make(map[int]*control),
+	}
+
+	for id, lp := range site.Loadpoints() {
+		s.controls[id] = &control{limit: 100, maxCurrent: lp.GetMaxCurrent()}
+	}
+
+	return s, nil
+}
+
+// Run executes the SunSpec Modbus TCP server
+func (s *SunSpec) Run() {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		s.log.ERROR.Println(err)
+		return
+	}
+
+	srv, err := mbserver.New(s)
+	if err == nil {
+		err = srv.Start(l)
+	}
+
+	if err != nil {
+		s.log.ERROR.Println(err)
+		return
+	}
+
+	s.log.DEBUG.Printf("listening at :%d", s.port)
+}
+
+// loadpoint returns loadpoint and control state for given unit id
+func (s *SunSpec) loadpoint(unit uint8) (int, loadpoint.API, error) {
+	id := int(unit) - 1
+
+	lps := s.site.Loadpoints()
+	if id < 0 || id >= len(lps) {
+		return 0, nil, mbserver.ErrBadUnitId
+	}
+
+	return id, lps[id], nil
+}
+
+func (s *SunSpec) status(unit uint8, lp loadpoint.API, ctrl *control) status {
+	return status{
+		UnitID:       unit,
+		Title:        lp.Title(),
+		Version:      server.Version,
+		Serial:       fmt.Sprintf("%.12s-%d", s.id, unit),
+		Status:       lp.GetStatus(),
+		Power:        lp.GetChargePower(),
+		MaxPower:     lp.GetMaxPower(),
+		Disconnected: ctrl.disconnected,
+		Limit:        ctrl.limit,
+		LimitEnabled: ctrl.limitEnabled,
+	}
+}
+
+// HandleHoldingRegisters implements mbserver.RequestHandler
+func (s *SunSpec) HandleHoldingRegisters(req *mbserver.HoldingRegistersRequest) ([]uint16, error) {
+	id, lp, err := s.loadpoint(req.UnitId)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Addr < baseAddr || int(req.Addr-baseAddr)+int(req.Quantity) > regCount {
+		return nil, mbserver.ErrIllegalDataAddress
+	}
+	ofs := int(req.Addr - baseAddr)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctrl := s.controls[id]
+
+	if req.IsWrite {
+		if !s.allowControl {
+			return nil, mbserver.ErrIllegalFunction
+		}
+
+		if err := s.write(lp, ctrl, ofs, req.Args); err != nil {
+			return nil, err
+		}
+	}
+
+	regs := encode(s.status(req.UnitId, lp, ctrl))
+
+	return regs[ofs : ofs+int(req.Quantity)], nil
+}
+
+// write applies immediate controls
+func (s *SunSpec) write(lp loadpoint.API, ctrl *control, ofs int, args []uint16) error {
+	// only immediate controls are writable
+	if ofs < ofsControls || ofs+len(args) > ofsControls+lenControls {
+		return mbserver.ErrIllegalDataAddress
+	}
+
+	state := *ctrl
+
+	// track max current changes while not limited
+	if !ctrl.limitEnabled {
+		state.maxCurrent = lp.GetMaxCurrent()
+	}
+
+	for i, val := range args {
+		switch ofs + i - ofsControls {
+		case ctrlConn:
+			state.disconnected = val == 0
+		case ctrlWMaxLimPct:
+			if val > 100 {
+				return mbserver.ErrIllegalDataValue
+			}
+			state.limit = val
+		case ctrlWMaxLimEna:
+			state.limitEnabled = val == 1
+		}
+	}
+
+	if state.disconnected != ctrl.disconnected {
+		demand := loadpoint.RemoteEnable
+		if state.disconnected {
+			demand = loadpoint.RemoteHardDisable
+		}
+
+		s.log.DEBUG.Printf("%s: remote demand: %s", lp.Title(), demand)
+		lp.RemoteControl(remoteSource, demand)
+	}
+
+	if state.limit != ctrl.limit || state.limitEnabled != ctrl.limitEnabled {
+		current := state.maxCurrent
+		if state.limitEnabled {
+			current = math.Max(state.maxCurrent*float64(state.limit)/100, lp.GetMinCurrent())
+		}
+
+		s.log.DEBUG.Printf("%s: max current limit: %.3gA", lp.Title(), current)
+		lp.SetMaxCurrent(current)
+	}
+
+	*ctrl = state
+
+	return nil
+}
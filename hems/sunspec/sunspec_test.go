@@ -0,0 +1,127 @@
+package sunspec
+
+import (
+	"testing"
+
+	"github.com/andig/mbserver"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSite struct {
+	site.API
+	loadpoints []loadpoint.API
+}
+
+func (s *testSite) Loadpoints() []loadpoint.API {
+	return s.loadpoints
+}
+
+func TestEncode(t *testing.T) {
+	r := encode(status{
+		UnitID:   1,
+		Title:    "Garage",
+		Status:   api.StatusC,
+		Power:    7360,
+		MaxPower: 11040,
+		Limit:    100,
+	})
+
+	require.Len(t, r, regCount)
+
+	// marker and model headers
+	assert.Equal(t, []uint16{0x5375, 0x6e53}, []uint16(r[:2]))
+	assert.Equal(t, []uint16{modelCommon, lenCommon}, []uint16(r[ofsCommon-2:ofsCommon]))
+	assert.Equal(t, []uint16{modelInverter, lenInverter}, []uint16(r[ofsInverter-2:ofsInverter]))
+	assert.Equal(t, []uint16{modelSettings, lenSettings}, []uint16(r[ofsSettings-2:ofsSettings]))
+	assert.Equal(t, []uint16{modelControls, lenControls}, []uint16(r[ofsControls-2:ofsControls]))
+	assert.Equal(t, []uint16{nanUint16, 0}, []uint16(r[ofsEnd:]))
+
+	assert.Equal(t, uint16(7360), r[ofsInverter+12], "power")
+	assert.Equal(t, uint16(stMppt), r[ofsInverter+36], "operating state")
+	assert.Equal(t, uint16(11040), r[ofsSettings], "max power")
+	assert.Equal(t, uint16(1), r[ofsControls+ctrlConn], "connected")
+	assert.Equal(t, uint16(100), r[ofsControls+ctrlWMaxLimPct], "limit")
+}
+
+func TestOperatingState(t *testing.T) {
+	for _, tc := range []struct {
+		status status
+		state  uint16
+	}{
+		{status{Status: api.StatusA}, stSleeping},
+		{status{Status: api.StatusB}, stStandby},
+		{status{Status: api.StatusC}, stMppt},
+		{status{Status: api.StatusC, LimitEnabled: true}, stThrottled},
+		{status{Status: api.StatusC, Disconnected: true}, stOff},
+	} {
+		assert.Equal(t, tc.state, operatingState(tc.status), tc.status)
+	}
+}
+
+func TestControls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().Title().Return("Garage").AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusC).AnyTimes()
+	lp.EXPECT().GetChargePower().Return(0.0).AnyTimes()
+	lp.EXPECT().GetMaxPower().Return(11040.0).AnyTimes()
+	lp.EXPECT().GetMinCurrent().Return(6.0).AnyTimes()
+	lp.EXPECT().GetMaxCurrent().Return(16.0).AnyTimes()
+
+	s := &SunSpec{
+		log:          util.NewLogger("foo"),
+		site:         &testSite{loadpoints: []loadpoint.API{lp}},
+		allowControl: true,
+		controls:     map[int]*control{0: {limit: 100, maxCurrent: 16}},
+	}
+
+	write := func(unit uint8, reg int, val uint16) error {
+		_, err := s.HandleHoldingRegisters(&mbserver.HoldingRegistersRequest{
+			UnitId:   unit,
+			Addr:     uint16(baseAddr + ofsControls + reg),
+			Quantity: 1,
+			IsWrite:  true,
+			Args:     []uint16{val},
+		})
+		return err
+	}
+
+	// disconnect
+	lp.EXPECT().RemoteControl(remoteSource, loadpoint.RemoteHardDisable)
+	require.NoError(t, write(1, ctrlConn, 0))
+
+	// reconnect
+	lp.EXPECT().RemoteControl(remoteSource, loadpoint.RemoteEnable)
+	require.NoError(t, write(1, ctrlConn, 1))
+
+	// limit is applied once enabled, respecting min current
+	gomock.InOrder(
+		lp.EXPECT().SetMaxCurrent(16.0),
+		lp.EXPECT().SetMaxCurrent(6.0),
+	)
+	require.NoError(t, write(1, ctrlWMaxLimPct, 25))
+	require.NoError(t, write(1, ctrlWMaxLimEna, 1))
+
+	lp.EXPECT().SetMaxCurrent(8.0)
+	require.NoError(t, write(1, ctrlWMaxLimPct, 50))
+
+	// disabling the limit restores max current
+	lp.EXPECT().SetMaxCurrent(16.0)
+	require.NoError(t, write(1, ctrlWMaxLimEna, 0))
+
+	// invalid values and addresses
+	assert.Equal(t, mbserver.ErrIllegalDataValue, write(1, ctrlWMaxLimPct, 101))
+	assert.Equal(t, mbserver.ErrIllegalDataAddress, write(1, -ofsControls+ofsInverter, 0))
+	assert.Equal(t, mbserver.ErrBadUnitId, write(2, ctrlConn, 0))
+
+	// read only
+	s.allowControl = false
+	assert.Equal(t, mbserver.ErrIllegalFunction, write(1, ctrlConn, 0))
+}
@@ -13,6 +13,9 @@ func (r meterRegistry) Add(name string, factory func(map[string]interface{}) (ap
 	if _, exists := r[name]; exists {
 		panic(fmt.Sprintf("cannot register duplicate meter type: %s", name))
 	}
+	if name != strings.ToLower(name) {
+		panic(fmt.Sprintf("cannot register meter type with upper case characters: %s", name))
+	}
 	r[name] = factory
 }
 
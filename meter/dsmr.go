@@ -60,18 +60,21 @@ type Dsmr struct {
 	timeout time.Duration
 	frame   dsmr.Frame
 	updated time.Time
+
+	capabilities api.MeterCapabilities
 }
 
 var (
 	currentObis     = []string{"1-0:31.7.0", "1-0:51.7.0", "1-0:71.7.0"}
 	powerExportObis = []string{"1-0:22.7.0", "1-0:42.7.0", "1-0:62.7.0"}
+	tariffObis      = []string{"1-0:1.8.1", "1-0:1.8.2"}
 )
 
 func init() {
 	registry.Add("dsmr", NewDsmrFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateDsmr -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)"
+//go:generate go run ../cmd/tools/decorate.go -f decorateDsmr -b *Dsmr -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)"
 
 // NewDsmrFromConfig creates a DSMR meter from generic config
 func NewDsmrFromConfig(other map[string]interface{}) (api.Meter, error) {
@@ -133,6 +136,18 @@ func NewDsmr(uri, energy string, timeout time.Duration) (api.Meter, error) {
 		currents = m.currents
 	}
 
+	// tariff registers capability
+	for _, obis := range tariffObis {
+		_, err = m.get(obis)
+		if err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		m.capabilities.TariffEnergy = m.tariffEnergy
+	}
+
 	return decorateDsmr(m, totalEnergy, currents), nil
 }
 
@@ -286,3 +301,20 @@ func (m *Dsmr) currents() (float64, float64, float64, error) {
 
 	return res[0], res[1], res[2], nil
 }
+
+// MeterCapabilities implements the api.MeterCapabilityProvider interface
+func (m *Dsmr) MeterCapabilities() api.MeterCapabilities {
+	return m.capabilities
+}
+
+// tariffEnergy implements the api.MeterTariffEnergy interface
+func (m *Dsmr) tariffEnergy() (float64, float64, error) {
+	t1, err := m.get(tariffObis[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	t2, err := m.get(tariffObis[1])
+
+	return t1, t2, err
+}
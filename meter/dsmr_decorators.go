@@ -6,17 +6,17 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error)) api.Meter {
+func decorateDsmr(base *Dsmr, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error)) api.Meter {
 	switch {
 	case meterEnergy == nil && phaseCurrents == nil:
 		return base
 
 	case meterEnergy != nil && phaseCurrents == nil:
 		return &struct {
-			api.Meter
+			*Dsmr
 			api.MeterEnergy
 		}{
-			Meter: base,
+			Dsmr: base,
 			MeterEnergy: &decorateDsmrMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -24,10 +24,10 @@ func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurr
 
 	case meterEnergy == nil && phaseCurrents != nil:
 		return &struct {
-			api.Meter
+			*Dsmr
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Dsmr: base,
 			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
 				phaseCurrents: phaseCurrents,
 			},
@@ -35,11 +35,11 @@ func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurr
 
 	case meterEnergy != nil && phaseCurrents != nil:
 		return &struct {
-			api.Meter
+			*Dsmr
 			api.MeterEnergy
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Dsmr: base,
 			MeterEnergy: &decorateDsmrMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
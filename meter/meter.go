@@ -13,18 +13,28 @@ func init() {
 	registry.Add(api.Custom, NewConfigurableFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64"
+//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b *Meter -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64"
 
 // NewConfigurableFromConfig creates api.Meter from config
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error) {
 	var cc struct {
-		capacity `mapstructure:",squash"`
-		Power    provider.Config
-		Energy   *provider.Config  // optional
-		Soc      *provider.Config  // optional
-		Currents []provider.Config // optional
-		Voltages []provider.Config // optional
-		Powers   []provider.Config // optional
+		capacity  `mapstructure:",squash"`
+		Power     provider.Config
+		Energy    *provider.Config  // optional
+		Soc       *provider.Config  // optional
+		Currents  []provider.Config // optional
+		Voltages  []provider.Config // optional
+		Powers    []provider.Config // optional
+		Frequency *provider.Config  // optional
+		Tariffs   []provider.Config // optional
+		Curtailed *provider.Config  // optional
+
+		// battery control (optional)
+		BatteryMode *struct {
+			provider.Config `mapstructure:",squash"`
+			Normal, Hold    int64
+			HoldPower       *provider.Config // optional power setpoint, set to zero before holding
+		}
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -65,6 +75,14 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error)
 		return nil, fmt.Errorf("powers: %w", err)
 	}
 
+	// frequency capability
+	if cc.Frequency != nil {
+		m.capabilities.Frequency, err = provider.NewFloatGetterFromConfig(*cc.Frequency)
+		if err != nil {
+			return nil, fmt.Errorf("frequency: %w", err)
+		}
+	}
+
 	// decorate soc
 	var batterySocG func() (float64, error)
 	if cc.Soc != nil {
@@ -74,6 +92,48 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error)
 		}
 	}
 
+	// tariff registers capability
+	if len(cc.Tariffs) > 0 {
+		if len(cc.Tariffs) != 2 {
+			return nil, errors.New("tariffs: need one per tariff register, total two")
+		}
+
+		var registers [2]func() (float64, error)
+		for i, prov := range cc.Tariffs {
+			registers[i], err = provider.NewFloatGetterFromConfig(prov)
+			if err != nil {
+				return nil, fmt.Errorf("tariffs: [%d] %w", i, err)
+			}
+		}
+
+		m.capabilities.TariffEnergy = collectTariffProviders(registers)
+	}
+
+	// curtailment capability
+	if cc.Curtailed != nil {
+		m.capabilities.Curtailed, err = provider.NewBoolGetterFromConfig(*cc.Curtailed)
+		if err != nil {
+			return nil, fmt.Errorf("curtailed: %w", err)
+		}
+	}
+
+	// battery mode capability
+	if cc.BatteryMode != nil {
+		set, err := provider.NewIntSetterFromConfig("batterymode", cc.BatteryMode.Config)
+		if err != nil {
+			return nil, fmt.Errorf("batterymode: %w", err)
+		}
+
+		var holdPower func(int64) error
+		if cc.BatteryMode.HoldPower != nil {
+			if holdPower, err = provider.NewIntSetterFromConfig("holdpower", *cc.BatteryMode.HoldPower); err != nil {
+				return nil, fmt.Errorf("batterymode: holdpower: %w", err)
+			}
+		}
+
+		m.capabilities.SetBatteryMode = batteryModeSetter(set, holdPower, cc.BatteryMode.Normal, cc.BatteryMode.Hold)
+	}
+
 	res := m.Decorate(totalEnergyG, currentsG, voltagesG, powersG, batterySocG, cc.capacity.Decorator())
 
 	return res, nil
@@ -119,6 +179,39 @@ func collectPhaseProviders(g []func() (float64, error)) func() (float64, float64
 	}
 }
 
+// collectTariffProviders combines tariff register getters into tariff energy api function
+func collectTariffProviders(g [2]func() (float64, error)) func() (float64, float64, error) {
+	return func() (float64, float64, error) {
+		t1, err := g[0]()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		t2, err := g[1]()
+
+		return t1, t2, err
+	}
+}
+
+// batteryModeSetter maps battery modes to device specific values
+func batteryModeSetter(set, holdPower func(int64) error, normal, hold int64) func(api.BatteryMode) error {
+	return func(mode api.BatteryMode) error {
+		switch mode {
+		case api.BatteryNormal:
+			return set(normal)
+		case api.BatteryHold:
+			if holdPower != nil {
+				if err := holdPower(0); err != nil {
+					return err
+				}
+			}
+			return set(hold)
+		default:
+			return api.ErrNotAvailable
+		}
+	}
+}
+
 // NewConfigurable creates a new meter
 func NewConfigurable(currentPowerG func() (float64, error)) (*Meter, error) {
 	m := &Meter{
@@ -130,6 +223,7 @@ func NewConfigurable(currentPowerG func() (float64, error)) (*Meter, error) {
 // Meter is an api.Meter implementation with configurable getters and setters.
 type Meter struct {
 	currentPowerG func() (float64, error)
+	capabilities  api.MeterCapabilities
 }
 
 // Decorate attaches additional capabilities to the base meter
@@ -144,6 +238,11 @@ func (m *Meter) Decorate(
 	return decorateMeter(m, totalEnergy, currents, voltages, powers, batterySoc, capacity)
 }
 
+// MeterCapabilities implements the api.MeterCapabilityProvider interface
+func (m *Meter) MeterCapabilities() api.MeterCapabilities {
+	return m.capabilities
+}
+
 // CurrentPower implements the api.Meter interface
 func (m *Meter) CurrentPower() (float64, error) {
 	return m.currentPowerG()
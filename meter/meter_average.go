@@ -68,6 +68,9 @@ func NewMovingAverageFromConfig(other map[string]interface{}) (api.Meter, error)
 		powers = m.Powers
 	}
 
+	// pass through optional capabilities
+	meter.capabilities = api.GetMeterCapabilities(m)
+
 	return meter.Decorate(totalEnergy, currents, voltages, powers, batterySoc, cc.Meter.capacity.Decorator()), nil
 }
 
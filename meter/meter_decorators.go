@@ -6,14 +6,14 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error), battery func() (float64, error), batteryCapacity func() float64) api.Meter {
+func decorateMeter(base *Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error), battery func() (float64, error), batteryCapacity func() float64) api.Meter {
 	switch {
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return base
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 		}{
 			Meter: base,
@@ -24,7 +24,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 		}{
 			Meter: base,
@@ -35,7 +35,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 		}{
@@ -50,7 +50,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseVoltages
 		}{
 			Meter: base,
@@ -61,7 +61,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseVoltages
 		}{
@@ -76,7 +76,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
@@ -91,7 +91,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhaseVoltages
@@ -110,7 +110,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhasePowers
 		}{
 			Meter: base,
@@ -121,7 +121,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhasePowers
 		}{
@@ -136,7 +136,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
@@ -151,7 +151,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
@@ -170,7 +170,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
@@ -185,7 +185,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhasePowers
 			api.PhaseVoltages
@@ -204,7 +204,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
@@ -223,7 +223,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
@@ -246,7 +246,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 		}{
 			Meter: base,
@@ -257,7 +257,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 		}{
@@ -272,7 +272,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 		}{
@@ -287,7 +287,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -306,7 +306,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseVoltages
 		}{
@@ -321,7 +321,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseVoltages
@@ -340,7 +340,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 			api.PhaseVoltages
@@ -359,7 +359,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -382,7 +382,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhasePowers
 		}{
@@ -397,7 +397,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhasePowers
@@ -416,7 +416,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 			api.PhasePowers
@@ -435,7 +435,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -458,7 +458,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhasePowers
 			api.PhaseVoltages
@@ -477,7 +477,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhasePowers
@@ -500,7 +500,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.PhaseCurrents
 			api.PhasePowers
@@ -523,7 +523,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -550,7 +550,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 		}{
 			Meter: base,
@@ -561,7 +561,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 		}{
@@ -576,7 +576,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.PhaseCurrents
 		}{
@@ -591,7 +591,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -610,7 +610,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.PhaseVoltages
 		}{
@@ -625,7 +625,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseVoltages
@@ -644,7 +644,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhaseVoltages
@@ -663,7 +663,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -686,7 +686,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.PhasePowers
 		}{
@@ -701,7 +701,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhasePowers
@@ -720,7 +720,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhasePowers
@@ -739,7 +739,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -762,7 +762,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.PhasePowers
 			api.PhaseVoltages
@@ -781,7 +781,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhasePowers
@@ -804,7 +804,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhasePowers
@@ -827,7 +827,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
@@ -854,7 +854,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 		}{
@@ -869,7 +869,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -888,7 +888,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -907,7 +907,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -930,7 +930,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseVoltages
@@ -949,7 +949,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -972,7 +972,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -995,7 +995,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -1022,7 +1022,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhasePowers
@@ -1041,7 +1041,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -1064,7 +1064,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -1087,7 +1087,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -1114,7 +1114,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhasePowers
@@ -1137,7 +1137,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -1164,7 +1164,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
@@ -1191,7 +1191,7 @@ func decorateMeter(base api.Meter, meterEnergy func() (float64, error), phaseCur
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Meter
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
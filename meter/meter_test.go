@@ -0,0 +1,23 @@
+package meter
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeterCapabilities(t *testing.T) {
+	m, _ := NewConfigurable(func() (float64, error) { return 0, nil })
+	m.capabilities.Frequency = func() (float64, error) { return 50, nil }
+
+	// capabilities remain accessible through decorated meters
+	res := m.Decorate(func() (float64, error) { return 0, nil }, nil, nil, nil, nil, nil)
+
+	_, ok := res.(api.MeterEnergy)
+	assert.True(t, ok)
+
+	caps := api.GetMeterCapabilities(res)
+	assert.NotNil(t, caps.Frequency)
+	assert.Nil(t, caps.TariffEnergy)
+}
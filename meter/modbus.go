@@ -15,18 +15,23 @@ import (
 
 // Modbus is an api.Meter implementation with configurable getters and setters.
 type Modbus struct {
-	conn     *modbus.Connection
-	device   meters.Device
-	opPower  modbus.Operation
-	opEnergy modbus.Operation
-	opSoc    modbus.Operation
+	conn        *modbus.Connection
+	device      meters.Device
+	opPower     modbus.Operation
+	opEnergy    modbus.Operation
+	opFrequency modbus.Operation
+	opSoc       modbus.Operation
+	opTariffs   [2]modbus.Operation
+	opCurtailed modbus.Operation
+
+	capabilities api.MeterCapabilities
 }
 
 func init() {
 	registry.Add("modbus", NewModbusFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateModbus -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64"
+//go:generate go run ../cmd/tools/decorate.go -f decorateModbus -b *Modbus -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64"
 
 // NewModbusFromConfig creates api.Meter from config
 func NewModbusFromConfig(other map[string]interface{}) (api.Meter, error) {
@@ -35,9 +40,12 @@ func NewModbusFromConfig(other map[string]interface{}) (api.Meter, error) {
 		capacity           `mapstructure:",squash"`
 		modbus.Settings    `mapstructure:",squash"`
 		Power, Energy, Soc string
+		Frequency          string
+		Curtailed          string
 		Currents           []string
 		Voltages           []string
 		Powers             []string
+		Tariffs            []string
 		Delay              time.Duration
 		Timeout            time.Duration
 	}{
@@ -128,6 +136,15 @@ func NewModbusFromConfig(other map[string]interface{}) (api.Meter, error) {
 		return nil, fmt.Errorf("powers: %w", err)
 	}
 
+	// frequency capability
+	if cc.Frequency != "" {
+		if err := modbus.ParseOperation(device, cc.Frequency, &m.opFrequency); err != nil {
+			return nil, fmt.Errorf("invalid measurement for frequency: %s", cc.Frequency)
+		}
+
+		m.capabilities.Frequency = m.frequency
+	}
+
 	// decorate soc
 	var soc func() (float64, error)
 	if cc.Soc != "" {
@@ -138,6 +155,30 @@ func NewModbusFromConfig(other map[string]interface{}) (api.Meter, error) {
 		soc = m.soc
 	}
 
+	// tariff registers capability
+	if len(cc.Tariffs) > 0 {
+		if len(cc.Tariffs) != 2 {
+			return nil, errors.New("tariffs: need one per tariff register, total two")
+		}
+
+		for i, reading := range cc.Tariffs {
+			if err := modbus.ParseOperation(device, reading, &m.opTariffs[i]); err != nil {
+				return nil, fmt.Errorf("invalid measurement for tariff %d: %s", i+1, reading)
+			}
+		}
+
+		m.capabilities.TariffEnergy = m.tariffEnergy
+	}
+
+	// curtailment capability
+	if cc.Curtailed != "" {
+		if err := modbus.ParseOperation(device, cc.Curtailed, &m.opCurtailed); err != nil {
+			return nil, fmt.Errorf("invalid measurement for curtailed: %s", cc.Curtailed)
+		}
+
+		m.capabilities.Curtailed = m.curtailed
+	}
+
 	return decorateModbus(m, totalEnergy, currentsG, voltagesG, powersG, soc, cc.capacity.Decorator()), nil
 }
 
@@ -210,7 +251,35 @@ func (m *Modbus) totalEnergy() (float64, error) {
 	return m.floatGetter(m.opEnergy)
 }
 
+// MeterCapabilities implements the api.MeterCapabilityProvider interface
+func (m *Modbus) MeterCapabilities() api.MeterCapabilities {
+	return m.capabilities
+}
+
+// frequency implements the api.MeterFrequency interface
+func (m *Modbus) frequency() (float64, error) {
+	return m.floatGetter(m.opFrequency)
+}
+
 // soc implements the api.Battery interface
 func (m *Modbus) soc() (float64, error) {
 	return m.floatGetter(m.opSoc)
 }
+
+// curtailed implements the api.MeterCurtailment interface
+func (m *Modbus) curtailed() (bool, error) {
+	f, err := m.floatGetter(m.opCurtailed)
+	return f != 0, err
+}
+
+// tariffEnergy implements the api.MeterTariffEnergy interface
+func (m *Modbus) tariffEnergy() (float64, float64, error) {
+	t1, err := m.floatGetter(m.opTariffs[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	t2, err := m.floatGetter(m.opTariffs[1])
+
+	return t1, t2, err
+}
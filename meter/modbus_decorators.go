@@ -6,17 +6,17 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error), battery func() (float64, error), batteryCapacity func() float64) api.Meter {
+func decorateModbus(base *Modbus, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error), battery func() (float64, error), batteryCapacity func() float64) api.Meter {
 	switch {
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return base
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -24,10 +24,10 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			PhaseCurrents: &decorateModbusPhaseCurrentsImpl{
 				phaseCurrents: phaseCurrents,
 			},
@@ -35,11 +35,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -50,10 +50,10 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			PhaseVoltages: &decorateModbusPhaseVoltagesImpl{
 				phaseVoltages: phaseVoltages,
 			},
@@ -61,11 +61,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -76,11 +76,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			PhaseCurrents: &decorateModbusPhaseCurrentsImpl{
 				phaseCurrents: phaseCurrents,
 			},
@@ -91,12 +91,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -110,10 +110,10 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			PhasePowers: &decorateModbusPhasePowersImpl{
 				phasePowers: phasePowers,
 			},
@@ -121,11 +121,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -136,11 +136,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			PhaseCurrents: &decorateModbusPhaseCurrentsImpl{
 				phaseCurrents: phaseCurrents,
 			},
@@ -151,12 +151,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -170,11 +170,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			PhasePowers: &decorateModbusPhasePowersImpl{
 				phasePowers: phasePowers,
 			},
@@ -185,12 +185,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -204,12 +204,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			PhaseCurrents: &decorateModbusPhaseCurrentsImpl{
 				phaseCurrents: phaseCurrents,
 			},
@@ -223,13 +223,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			MeterEnergy: &decorateModbusMeterEnergyImpl{
 				meterEnergy: meterEnergy,
 			},
@@ -246,10 +246,10 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -257,11 +257,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -272,11 +272,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -287,12 +287,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -306,11 +306,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -321,12 +321,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -340,12 +340,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -359,13 +359,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -382,11 +382,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -397,12 +397,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -416,12 +416,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -435,13 +435,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -458,12 +458,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -477,13 +477,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -500,13 +500,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -523,14 +523,14 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity == nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -550,10 +550,10 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -561,11 +561,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -576,11 +576,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -591,12 +591,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -610,11 +610,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -625,12 +625,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -644,12 +644,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -663,13 +663,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -686,11 +686,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -701,12 +701,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -720,12 +720,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -739,13 +739,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -762,12 +762,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -781,13 +781,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -804,13 +804,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -827,14 +827,14 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery == nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			BatteryCapacity: &decorateModbusBatteryCapacityImpl{
 				batteryCapacity: batteryCapacity,
 			},
@@ -854,11 +854,11 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -869,12 +869,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -888,12 +888,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -907,13 +907,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -930,12 +930,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -949,13 +949,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -972,13 +972,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -995,14 +995,14 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1022,12 +1022,12 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1041,13 +1041,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1064,13 +1064,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1087,14 +1087,14 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhaseCurrents
 			api.PhasePowers
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1114,13 +1114,13 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1137,14 +1137,14 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents == nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1164,14 +1164,14 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.PhaseCurrents
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
@@ -1191,7 +1191,7 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 
 	case battery != nil && batteryCapacity != nil && meterEnergy != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
 		return &struct {
-			api.Meter
+			*Modbus
 			api.Battery
 			api.BatteryCapacity
 			api.MeterEnergy
@@ -1199,7 +1199,7 @@ func decorateModbus(base api.Meter, meterEnergy func() (float64, error), phaseCu
 			api.PhasePowers
 			api.PhaseVoltages
 		}{
-			Meter: base,
+			Modbus: base,
 			Battery: &decorateModbusBatteryImpl{
 				battery: battery,
 			},
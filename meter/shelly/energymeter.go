@@ -1,6 +1,11 @@
 package shelly
 
-import "github.com/evcc-io/evcc/api"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+)
 
 type EnergyMeter struct {
 	*Connection
@@ -14,8 +19,36 @@ func NewEnergyMeter(conn *Connection) *EnergyMeter {
 	return res
 }
 
+// gen1Status returns the Gen1 3EM emeter status
+func (sh *EnergyMeter) gen1Status() (Gen1StatusResponse, error) {
+	var res Gen1StatusResponse
+	if err := sh.GetJSON(fmt.Sprintf("%s/status", sh.uri), &res); err != nil {
+		return res, err
+	}
+
+	if len(res.EMeters) != 3 {
+		return res, errors.New("invalid emeter count")
+	}
+
+	return res, nil
+}
+
 // CurrentPower implements the api.Meter interface
 func (sh *EnergyMeter) CurrentPower() (float64, error) {
+	if sh.gen < 2 {
+		res, err := sh.gen1Status()
+		if err != nil {
+			return 0, err
+		}
+
+		var power float64
+		for _, m := range res.EMeters {
+			power += m.Power
+		}
+
+		return power, nil
+	}
+
 	var res Gen2EmStatusResponse
 	if err := sh.Connection.execGen2Cmd("EM.GetStatus", false, &res); err != nil {
 		return 0, err
@@ -26,6 +59,20 @@ func (sh *EnergyMeter) CurrentPower() (float64, error) {
 
 // TotalEnergy implements the api.Meter interface
 func (sh *EnergyMeter) TotalEnergy() (float64, error) {
+	if sh.gen < 2 {
+		res, err := sh.gen1Status()
+		if err != nil {
+			return 0, err
+		}
+
+		var energy float64
+		for _, m := range res.EMeters {
+			energy += m.Total
+		}
+
+		return energy / 1000, nil
+	}
+
 	var res Gen2EmDataStatusResponse
 	if err := sh.Connection.execGen2Cmd("EMData.GetStatus", false, &res); err != nil {
 		return 0, err
@@ -38,6 +85,15 @@ var _ api.PhaseCurrents = (*EnergyMeter)(nil)
 
 // Currents implements the api.PhaseCurrents interface
 func (sh *EnergyMeter) Currents() (float64, float64, float64, error) {
+	if sh.gen < 2 {
+		res, err := sh.gen1Status()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		return res.EMeters[0].Current, res.EMeters[1].Current, res.EMeters[2].Current, nil
+	}
+
 	var res Gen2EmStatusResponse
 	if err := sh.Connection.execGen2Cmd("EM.GetStatus", false, &res); err != nil {
 		return 0, 0, 0, err
@@ -50,6 +106,15 @@ var _ api.PhaseVoltages = (*EnergyMeter)(nil)
 
 // Voltages implements the api.PhaseVoltages interface
 func (sh *EnergyMeter) Voltages() (float64, float64, float64, error) {
+	if sh.gen < 2 {
+		res, err := sh.gen1Status()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		return res.EMeters[0].Voltage, res.EMeters[1].Voltage, res.EMeters[2].Voltage, nil
+	}
+
 	var res Gen2EmStatusResponse
 	if err := sh.Connection.execGen2Cmd("EM.GetStatus", false, &res); err != nil {
 		return 0, 0, 0, err
@@ -62,6 +127,15 @@ var _ api.PhasePowers = (*EnergyMeter)(nil)
 
 // Powers implements the api.PhasePowers interface
 func (sh *EnergyMeter) Powers() (float64, float64, float64, error) {
+	if sh.gen < 2 {
+		res, err := sh.gen1Status()
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		return res.EMeters[0].Power, res.EMeters[1].Power, res.EMeters[2].Power, nil
+	}
+
 	var res Gen2EmStatusResponse
 	if err := sh.Connection.execGen2Cmd("EM.GetStatus", false, &res); err != nil {
 		return 0, 0, 0, err
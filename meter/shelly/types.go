@@ -65,7 +65,9 @@ type Gen1StatusResponse struct {
 	}
 	// Shelly EM meter JSON response
 	EMeters []struct {
-		Power float64
-		Total float64
+		Power   float64
+		Total   float64
+		Current float64
+		Voltage float64
 	}
 }
@@ -41,6 +41,19 @@ func TestUnmarshalGen1StatusResponse(t *testing.T) {
 		assert.Equal(t, 401472.9, gen1Energy("SHEM", res.EMeters[0].Total))
 		assert.Equal(t, -620.34, res.EMeters[0].Power)
 	}
+
+	{
+		// Shelly 3EM
+		var res Gen1StatusResponse
+
+		jsonstr := `{"emeters":[{"power":1234.5,"pf":0.98,"current":5.37,"voltage":230.1,"is_valid":true,"total":1000.0,"total_returned":0.0},{"power":-100.0,"pf":-0.5,"current":0.87,"voltage":231.2,"is_valid":true,"total":2000.0,"total_returned":50.0},{"power":0.0,"pf":0.0,"current":0.0,"voltage":229.8,"is_valid":true,"total":3000.0,"total_returned":0.0}],"total_power":1134.5}`
+		assert.NoError(t, json.Unmarshal([]byte(jsonstr), &res))
+
+		assert.Len(t, res.EMeters, 3)
+		assert.Equal(t, 5.37, res.EMeters[0].Current)
+		assert.Equal(t, 231.2, res.EMeters[1].Voltage)
+		assert.Equal(t, -100.0, res.EMeters[1].Power)
+	}
 }
 
 // Test Gen2StatusResponse response
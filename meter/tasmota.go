@@ -8,8 +8,9 @@ import (
 
 // Tasmota meter implementation
 type Tasmota struct {
-	conn  *tasmota.Connection
-	usage string
+	conn         *tasmota.Connection
+	usage        string
+	capabilities api.MeterCapabilities
 }
 
 // Tasmota meter implementation
@@ -48,6 +49,13 @@ func NewTasmota(uri, user, password, usage string, channel int) (*Tasmota, error
 		usage: usage,
 	}
 
+	// tariff registers capability, only available on dual tariff meters
+	if usage == "grid" {
+		if _, _, err := conn.SmlTariffEnergy(); err == nil {
+			c.capabilities.TariffEnergy = conn.SmlTariffEnergy
+		}
+	}
+
 	return c, err
 }
 
@@ -70,3 +78,10 @@ func (c *Tasmota) TotalEnergy() (float64, error) {
 	}
 	return c.conn.TotalEnergy()
 }
+
+var _ api.MeterCapabilityProvider = (*Tasmota)(nil)
+
+// MeterCapabilities implements the api.MeterCapabilityProvider interface
+func (c *Tasmota) MeterCapabilities() api.MeterCapabilities {
+	return c.capabilities
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/transport"
@@ -78,3 +79,18 @@ func (d *Connection) SmlTotalEnergy() (float64, error) {
 	err := d.ExecCmd("Status 8", &res)
 	return res.StatusSNS.SML.TotalIn, err
 }
+
+// SmlTariffEnergy provides the sml sensor import energy of tariff registers 1 and 2
+func (d *Connection) SmlTariffEnergy() (float64, float64, error) {
+	var res StatusSNSResponse
+	if err := d.ExecCmd("Status 8", &res); err != nil {
+		return 0, 0, err
+	}
+
+	sml := res.StatusSNS.SML
+	if sml.TotalInT1 == nil || sml.TotalInT2 == nil {
+		return 0, 0, api.ErrNotAvailable
+	}
+
+	return *sml.TotalInT1, *sml.TotalInT2, nil
+}
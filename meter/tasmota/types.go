@@ -85,9 +85,11 @@ type StatusSNSResponse struct {
 
 		// SML sensor readings
 		SML struct {
-			TotalIn   float64 `json:"total_in"`
-			TotalOut  float64 `json:"total_out"`
-			PowerCurr int     `json:"power_curr"`
+			TotalIn   float64  `json:"total_in"`
+			TotalOut  float64  `json:"total_out"`
+			PowerCurr int      `json:"power_curr"`
+			TotalInT1 *float64 `json:"total_in_t1"` // dual tariff meters only
+			TotalInT2 *float64 `json:"total_in_t2"` // dual tariff meters only
 		}
 	}
 }
@@ -35,4 +35,20 @@ func TestUnmarshalStatusSNSResponse(t *testing.T) {
 	if res.StatusSNS.SML.PowerCurr != -894 {
 		t.Error("res.StatusSNS.SML.PowerCurr != -894")
 	}
+	if res.StatusSNS.SML.TotalInT1 != nil {
+		t.Error("res.StatusSNS.SML.TotalInT1 != nil")
+	}
+
+	// dual tariff sml meter
+	res = StatusSNSResponse{}
+	jsonstr = `{"StatusSNS":{"Time":"2022-07-07T13:01:11","SML":{"Total_in":34507.4761,"Total_in_T1":30000.1,"Total_in_T2":4507.3761,"Total_out":14737.1422,"Power_curr":-894}}}`
+	if err := json.Unmarshal([]byte(jsonstr), &res); err != nil {
+		t.Error(err)
+	}
+	if t1 := res.StatusSNS.SML.TotalInT1; t1 == nil || *t1 != 30000.1 {
+		t.Error("res.StatusSNS.SML.TotalInT1 != 30000.1")
+	}
+	if t2 := res.StatusSNS.SML.TotalInT2; t2 == nil || *t2 != 4507.3761 {
+		t.Error("res.StatusSNS.SML.TotalInT2 != 4507.3761")
+	}
 }
@@ -6,12 +6,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"time"
 
 	"github.com/evcc-io/evcc/util"
 )
 
+const (
+	timeout   = 5 * time.Second
+	maxLength = 64 * 1024 // max response size
+)
+
 // Connection is the TP-Link connection
 type Connection struct {
 	log *util.Logger
@@ -45,45 +51,69 @@ func (d *Connection) ExecCmd(cmd string, res interface{}) error {
 	binary.BigEndian.PutUint32(buf.Bytes(), uint32(buf.Len()-4))
 
 	// open connection via TP-Link Smart Home Protocol
-	conn, err := net.DialTimeout("tcp", d.uri, 5*time.Second)
+	conn, err := net.DialTimeout("tcp", d.uri, timeout)
 	if err != nil {
 		return err
 	}
 	defer conn.Close()
 
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
 	// send command
 	if _, err = buf.WriteTo(conn); err != nil {
 		return err
 	}
 
-	// read response
-	resp := make([]byte, 8192)
-	len, err := conn.Read(resp)
-	if err != nil {
+	// read 4 bytes response length
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+
+	length := binary.BigEndian.Uint32(header)
+	if length > maxLength {
+		return fmt.Errorf("invalid response length: %d", length)
+	}
+
+	// read full response which may span multiple packets
+	resp := make([]byte, length)
+	if _, err := io.ReadFull(conn, resp); err != nil {
 		return err
 	}
 
 	// decode response message
+	buf.Reset()
 	key = 171 // reset initialization vector
-	for i := 4; i < len; i++ {
-		dec := key ^ resp[i]
-		key = resp[i]
-		_ = buf.WriteByte(dec)
+	for _, b := range resp {
+		_ = buf.WriteByte(key ^ b)
+		key = b
 	}
 	d.log.TRACE.Printf("recv: %s", buf.String())
 
 	return json.Unmarshal(buf.Bytes(), res)
 }
 
-// CurrentPower implements the api.Meter interface
-func (d *Connection) CurrentPower() (float64, error) {
+// realtime returns the emeter realtime values
+func (d *Connection) realtime() (EmeterResponse, error) {
 	var res EmeterResponse
 	if err := d.ExecCmd(`{"emeter":{"get_realtime":null}}`, &res); err != nil {
-		return 0, err
+		return res, err
 	}
 
 	if err := res.Emeter.GetRealtime.ErrCode; err != 0 {
-		return 0, fmt.Errorf("get_realtime: %d", err)
+		return res, fmt.Errorf("get_realtime: %d", err)
+	}
+
+	return res, nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (d *Connection) CurrentPower() (float64, error) {
+	res, err := d.realtime()
+	if err != nil {
+		return 0, err
 	}
 
 	power := res.Emeter.GetRealtime.PowerMw / 1000
@@ -96,15 +126,11 @@ func (d *Connection) CurrentPower() (float64, error) {
 
 // TotalEnergy implements the api.MeterEnergy interface
 func (d *Connection) TotalEnergy() (float64, error) {
-	var res EmeterResponse
-	if err := d.ExecCmd(`{"emeter":{"get_realtime":null}}`, &res); err != nil {
+	res, err := d.realtime()
+	if err != nil {
 		return 0, err
 	}
 
-	if err := res.Emeter.GetRealtime.ErrCode; err != 0 {
-		return 0, fmt.Errorf("get_realtime: %d", err)
-	}
-
 	energy := res.Emeter.GetRealtime.TotalWh / 1000
 	if energy == 0 {
 		energy = res.Emeter.GetRealtime.Total
@@ -112,3 +138,33 @@ func (d *Connection) TotalEnergy() (float64, error) {
 
 	return energy, nil
 }
+
+// Currents implements the api.PhaseCurrents interface
+func (d *Connection) Currents() (float64, float64, float64, error) {
+	res, err := d.realtime()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	current := res.Emeter.GetRealtime.CurrentMa / 1000
+	if current == 0 {
+		current = res.Emeter.GetRealtime.Current
+	}
+
+	return current, 0, 0, nil
+}
+
+// Voltages implements the api.PhaseVoltages interface
+func (d *Connection) Voltages() (float64, float64, float64, error) {
+	res, err := d.realtime()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	voltage := res.Emeter.GetRealtime.VoltageMv / 1000
+	if voltage == 0 {
+		voltage = res.Emeter.GetRealtime.Voltage
+	}
+
+	return voltage, 0, 0, nil
+}
@@ -0,0 +1,32 @@
+package meter
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/meter/twc3"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Tesla Wall Connector Gen 3 local api:
+// /api/1/vitals and /api/1/lifetime
+
+func init() {
+	registry.Add("twc3", NewTwc3FromConfig)
+}
+
+// NewTwc3FromConfig creates a TWC3 charge meter from generic config
+func NewTwc3FromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		URI   string
+		Cache time.Duration
+	}{
+		Cache: time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	return twc3.NewConnection(cc.URI, cc.Cache), nil
+}
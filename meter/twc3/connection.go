@@ -0,0 +1,107 @@
+package twc3
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// Connection is the Tesla Wall Connector Gen 3 local api connection
+type Connection struct {
+	*request.Helper
+	uri       string
+	vitalsG   func() (Vitals, error)
+	lifetimeG func() (Lifetime, error)
+}
+
+// NewConnection creates a TWC3 connection
+func NewConnection(uri string, cache time.Duration) *Connection {
+	log := util.NewLogger("twc3")
+
+	c := &Connection{
+		Helper: request.NewHelper(log),
+		uri:    util.DefaultScheme(strings.TrimSuffix(uri, "/"), "http"),
+	}
+
+	c.vitalsG = provider.Cached(func() (Vitals, error) {
+		var res Vitals
+		uri := fmt.Sprintf("%s/api/1/vitals", c.uri)
+		err := c.GetJSON(uri, &res)
+		return res, err
+	}, cache)
+
+	c.lifetimeG = provider.Cached(func() (Lifetime, error) {
+		var res Lifetime
+		uri := fmt.Sprintf("%s/api/1/lifetime", c.uri)
+		err := c.GetJSON(uri, &res)
+		return res, err
+	}, cache)
+
+	return c
+}
+
+// Vitals returns the cached vitals api response
+func (c *Connection) Vitals() (Vitals, error) {
+	return c.vitalsG()
+}
+
+// Use workaround if voltageC_v is approximately half of grid_v
+//
+//	"voltageA_v": 241.5,
+//	"voltageB_v": 241.5,
+//	"voltageC_v": 118.7,
+//
+// Default state is ~2V on all phases unless charging
+func (c *Connection) isSplitPhase(res Vitals) bool {
+	return math.Abs(res.VoltageCV-res.GridV/2) < 25
+}
+
+var _ api.Meter = (*Connection)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (c *Connection) CurrentPower() (float64, error) {
+	res, err := c.vitalsG()
+	if res.ContactorClosed {
+		if c.isSplitPhase(res) {
+			return (res.CurrentAA * res.VoltageAV) + (res.CurrentBA * res.VoltageBV), err
+		}
+		return (res.CurrentAA * res.VoltageAV) + (res.CurrentBA * res.VoltageBV) + (res.CurrentCA * res.VoltageCV), err
+	}
+	return 0, err
+}
+
+var _ api.MeterEnergy = (*Connection)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (c *Connection) TotalEnergy() (float64, error) {
+	res, err := c.lifetimeG()
+	return res.EnergyWh / 1e3, err
+}
+
+var _ api.PhaseCurrents = (*Connection)(nil)
+
+// Currents implements the api.PhaseCurrents interface
+func (c *Connection) Currents() (float64, float64, float64, error) {
+	res, err := c.vitalsG()
+	if c.isSplitPhase(res) {
+		return res.CurrentAA + res.CurrentBA, 0, 0, err
+	}
+	return res.CurrentAA, res.CurrentBA, res.CurrentCA, err
+}
+
+var _ api.PhaseVoltages = (*Connection)(nil)
+
+// Voltages implements the api.PhaseVoltages interface
+func (c *Connection) Voltages() (float64, float64, float64, error) {
+	res, err := c.vitalsG()
+	if c.isSplitPhase(res) {
+		return (res.VoltageAV + res.VoltageBV) / 2, 0, 0, err
+	}
+	return res.VoltageAV, res.VoltageBV, res.VoltageCV, err
+}
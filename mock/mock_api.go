@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff)
+// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentGetter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff)
 
 // Package mock is a generated GoMock package.
 package mock
@@ -130,6 +130,44 @@ func (mr *MockChargeStateMockRecorder) Status() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockChargeState)(nil).Status))
 }
 
+// MockCurrentGetter is a mock of CurrentGetter interface.
+type MockCurrentGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrentGetterMockRecorder
+}
+
+// MockCurrentGetterMockRecorder is the mock recorder for MockCurrentGetter.
+type MockCurrentGetterMockRecorder struct {
+	mock *MockCurrentGetter
+}
+
+// NewMockCurrentGetter creates a new mock instance.
+func NewMockCurrentGetter(ctrl *gomock.Controller) *MockCurrentGetter {
+	mock := &MockCurrentGetter{ctrl: ctrl}
+	mock.recorder = &MockCurrentGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrentGetter) EXPECT() *MockCurrentGetterMockRecorder {
+	return m.recorder
+}
+
+// GetMaxCurrent mocks base method.
+func (m *MockCurrentGetter) GetMaxCurrent() (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxCurrent")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaxCurrent indicates an expected call of GetMaxCurrent.
+func (mr *MockCurrentGetterMockRecorder) GetMaxCurrent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxCurrent", reflect.TypeOf((*MockCurrentGetter)(nil).GetMaxCurrent))
+}
+
 // MockPhaseSwitcher is a mock of PhaseSwitcher interface.
 type MockPhaseSwitcher struct {
 	ctrl     *gomock.Controller
@@ -63,6 +63,7 @@ func ResettableCached[T any](g func() (T, error), cache time.Duration) *cached[T
 		g:     g,
 	}
 	_ = bus.Subscribe(reset, c.Reset)
+	addToScopes(c)
 	return c
 }
 
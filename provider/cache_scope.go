@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"reflect"
+	"sync"
+)
+
+// cacheScope collects the caches created while the scope is active
+type cacheScope struct {
+	caches []interface{ Reset() }
+}
+
+var (
+	scopeMux     sync.Mutex
+	activeScopes = make(map[*cacheScope]struct{})
+	scopes       = make(map[any]*cacheScope)
+)
+
+// addToScopes registers the cache with all active scopes
+func addToScopes(c interface{ Reset() }) {
+	scopeMux.Lock()
+	defer scopeMux.Unlock()
+
+	for s := range activeScopes {
+		s.caches = append(s.caches, c)
+	}
+}
+
+// CacheScope creates a device and associates all caches created meanwhile with it.
+// This allows resetting the device's caches using ResetCachedScope instead of all caches.
+// Caches created concurrently by other devices may be included in the scope, too.
+func CacheScope[T any](create func() (T, error)) (T, error) {
+	s := new(cacheScope)
+
+	scopeMux.Lock()
+	activeScopes[s] = struct{}{}
+	scopeMux.Unlock()
+
+	res, err := create()
+
+	scopeMux.Lock()
+	defer scopeMux.Unlock()
+
+	delete(activeScopes, s)
+
+	if err == nil && reflect.TypeOf(res) != nil && reflect.TypeOf(res).Comparable() {
+		scopes[res] = s
+	}
+
+	return res, err
+}
+
+// ResetCachedScope resets the caches associated with the device.
+// It returns false if the device has no cache scope.
+func ResetCachedScope(device any) bool {
+	if t := reflect.TypeOf(device); t == nil || !t.Comparable() {
+		return false
+	}
+
+	scopeMux.Lock()
+	s, ok := scopes[device]
+	scopeMux.Unlock()
+
+	if ok {
+		for _, c := range s.caches {
+			c.Reset()
+		}
+	}
+
+	return ok
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheScope(t *testing.T) {
+	type device struct{ get func() (int, error) }
+
+	newDevice := func(val *int) func() (*device, error) {
+		return func() (*device, error) {
+			return &device{Cached(func() (int, error) { return *val, nil }, time.Hour)}, nil
+		}
+	}
+
+	var a, b int
+	devA, _ := CacheScope(newDevice(&a))
+	devB, _ := CacheScope(newDevice(&b))
+
+	_, _ = devA.get()
+	_, _ = devB.get()
+
+	a, b = 1, 1
+
+	assert.True(t, ResetCachedScope(devA))
+
+	res, _ := devA.get()
+	assert.Equal(t, 1, res, "scope reset")
+
+	res, _ = devB.get()
+	assert.Equal(t, 0, res, "other scope still cached")
+
+	assert.False(t, ResetCachedScope(&device{}), "unknown device")
+}
@@ -253,6 +253,12 @@ func UintFromBytes(bytes []byte) (u uint64, err error) {
 // BoolGetter executes configured modbus read operation and implements IntProvider
 func (m *Modbus) BoolGetter() func() (bool, error) {
 	return func() (bool, error) {
+		// sunspec readings are evaluated as numbers
+		if m.op.MBMD.FuncCode == 0 {
+			f, err := m.floatGetter()
+			return f != 0, err
+		}
+
 		bytes, err := m.bytesGetter()
 		if err != nil {
 			return false, err
@@ -282,6 +288,8 @@ func (m *Modbus) IntSetter(param string) func(int64) error {
 					uval = 0xFF00
 				}
 				_, err = m.conn.WriteSingleCoil(op.OpCode, uval)
+			case gridx.FuncCodeWriteMultipleRegisters:
+				_, err = m.conn.WriteMultipleRegisters(op.OpCode, op.ReadLen, encodeRegisters(op.ReadLen, int64(m.scale)*val))
 			default:
 				err = fmt.Errorf("unknown function code %d", op.FuncCode)
 			}
@@ -293,6 +301,22 @@ func (m *Modbus) IntSetter(param string) func(int64) error {
 	}
 }
 
+// encodeRegisters encodes value as big endian byte sequence of given register length
+func encodeRegisters(length uint16, val int64) []byte {
+	b := make([]byte, 2*length)
+
+	switch length {
+	case 1:
+		binary.BigEndian.PutUint16(b, uint16(val))
+	case 2:
+		binary.BigEndian.PutUint32(b, uint32(val))
+	default:
+		binary.BigEndian.PutUint64(b[len(b)-8:], uint64(val))
+	}
+
+	return b
+}
+
 // BoolSetter executes configured modbus write operation and implements SetBoolProvider
 func (m *Modbus) BoolSetter(param string) func(bool) error {
 	set := m.IntSetter(param)
@@ -10,12 +10,20 @@ type Messenger interface {
 	Send(title, msg string)
 }
 
+// EventMessenger is a Messenger that receives the originating event alongside the message
+type EventMessenger interface {
+	SendEvent(ev Event, title, msg string)
+}
+
 type senderRegistry map[string]func(map[string]interface{}) (Messenger, error)
 
 func (r senderRegistry) Add(name string, factory func(map[string]interface{}) (Messenger, error)) {
 	if _, exists := r[name]; exists {
 		panic(fmt.Sprintf("cannot register duplicate messenger type: %s", name))
 	}
+	if name != strings.ToLower(name) {
+		panic(fmt.Sprintf("cannot register messenger type with upper case characters: %s", name))
+	}
 	r[name] = factory
 }
 
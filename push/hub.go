@@ -7,28 +7,64 @@ import (
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/locale"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/exp/slices"
 )
 
 // Event is a notification event
 type Event struct {
-	Loadpoint *int // optional loadpoint id
-	Event     string
+	Loadpoint  *int // optional loadpoint id
+	Event      string
+	Attributes map[string]interface{} // optional event values, take precedence over cached values
 }
 
+// defaultEvents are notified using translated messages if no events are configured
+var defaultEvents = []string{"start", "stop", "connect", "disconnect", "identified", "targetsoc", "reauth", "fault", "offline", "guest", "calibrated", "budget", "summary"}
+
 // EventTemplateConfig is the push message configuration for an event
 type EventTemplateConfig struct {
 	Title, Msg string
+	Services   []string // names of the services to send to, defaults to all services
+}
+
+// namedMessenger is a messenger with its configured service name
+type namedMessenger struct {
+	name string
+	Messenger
 }
 
 // Hub subscribes to event notifications and sends them to client devices
 type Hub struct {
 	definitions map[string]EventTemplateConfig
-	sender      []Messenger
+	sender      []namedMessenger
 	cache       *util.Cache
 }
 
-// NewHub creates push hub with definitions and receiver
-func NewHub(cc map[string]EventTemplateConfig, cache *util.Cache) (*Hub, error) {
+// NewHub creates push hub with definitions and receiver.
+// Event titles and messages not configured default to the translated messages of the given language.
+func NewHub(cc map[string]EventTemplateConfig, lang string, cache *util.Cache) (*Hub, error) {
+	if len(cc) == 0 {
+		cc = make(map[string]EventTemplateConfig)
+		for _, ev := range defaultEvents {
+			cc[ev] = EventTemplateConfig{}
+		}
+	}
+
+	if locale.Bundle != nil {
+		localizer := i18n.NewLocalizer(locale.Bundle, lang, locale.Language)
+
+		for k, v := range cc {
+			if v.Title == "" {
+				v.Title = localizeTemplate(localizer, k, "title")
+			}
+			if v.Msg == "" {
+				v.Msg = localizeTemplate(localizer, k, "msg")
+			}
+			cc[k] = v
+		}
+	}
+
 	// instantiate all event templates
 	for k, v := range cc {
 		if _, err := template.New("out").Funcs(sprig.TxtFuncMap()).Parse(v.Title); err != nil {
@@ -47,9 +83,33 @@ func NewHub(cc map[string]EventTemplateConfig, cache *util.Cache) (*Hub, error)
 	return h, nil
 }
 
-// Add adds a sender to the list of senders
-func (h *Hub) Add(sender Messenger) {
-	h.sender = append(h.sender, sender)
+// localizeTemplate returns the translated default template for the event or empty string if undefined.
+// Messages not translated to the requested language fall back to english.
+func localizeTemplate(localizer *i18n.Localizer, event, field string) string {
+	res, _ := localizer.Localize(&locale.Config{
+		MessageID: "push." + event + "." + field,
+	})
+	return res
+}
+
+// Add adds a sender to the list of senders. The name may be used to restrict events to the sender.
+func (h *Hub) Add(name string, sender Messenger) {
+	h.sender = append(h.sender, namedMessenger{name, sender})
+}
+
+// Validate checks that the services referenced by the event definitions exist
+func (h *Hub) Validate() error {
+	for ev, definition := range h.definitions {
+		for _, name := range definition.Services {
+			if !slices.ContainsFunc(h.sender, func(s namedMessenger) bool {
+				return s.name == name
+			}) {
+				return fmt.Errorf("event %s: service not found: %s", ev, name)
+			}
+		}
+	}
+
+	return nil
 }
 
 // apply applies the event template to the content to produce the actual message
@@ -68,6 +128,11 @@ func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 		}
 	}
 
+	// event values
+	for k, v := range ev.Attributes {
+		attr[k] = v
+	}
+
 	return util.ReplaceFormatted(tmpl, attr)
 }
 
@@ -103,8 +168,16 @@ func (h *Hub) Run(events <-chan Event, valueChan chan util.Param) {
 		}
 
 		for _, sender := range h.sender {
+			if len(definition.Services) > 0 && !slices.Contains(definition.Services, sender.name) {
+				continue
+			}
+
 			if strings.TrimSpace(msg) != "" {
-				go sender.Send(title, msg)
+				if em, ok := sender.Messenger.(EventMessenger); ok {
+					go em.SendEvent(ev, title, msg)
+				} else {
+					go sender.Send(title, msg)
+				}
 			} else {
 				log.DEBUG.Printf("did not send empty message template for %s: %v", ev.Event, err)
 			}
@@ -0,0 +1,133 @@
+package push
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+func init() {
+	registry.Add("webhook", NewWebhookFromConfig)
+}
+
+// Webhook implements a generic http messenger
+type Webhook struct {
+	*request.Helper
+	log     *util.Logger
+	uri     string
+	method  string
+	headers map[string]string
+	body    *template.Template
+}
+
+// NewWebhookFromConfig creates new webhook messenger.
+// The body is a Go template receiving event, loadpoint, title and msg and defaults to a json object of these values.
+func NewWebhookFromConfig(other map[string]interface{}) (Messenger, error) {
+	cc := struct {
+		URI, Method string
+		Headers     map[string]string
+		Body        string
+		Timeout     time.Duration
+	}{
+		Method:  http.MethodPost,
+		Headers: make(map[string]string),
+		Timeout: request.Timeout,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	log := util.NewLogger("webhook")
+
+	var contentType bool
+	for k, v := range cc.Headers {
+		switch http.CanonicalHeaderKey(k) {
+		case "Content-Type":
+			contentType = true
+		case "Authorization":
+			log.Redact(v)
+		}
+	}
+
+	var body *template.Template
+	if cc.Body != "" {
+		var err error
+		if body, err = template.New("body").Funcs(sprig.TxtFuncMap()).Parse(cc.Body); err != nil {
+			return nil, fmt.Errorf("invalid body: %w", err)
+		}
+	} else if !contentType {
+		cc.Headers["Content-Type"] = request.JSONContent
+	}
+
+	m := &Webhook{
+		Helper:  request.NewHelper(log),
+		log:     log,
+		uri:     cc.URI,
+		method:  strings.ToUpper(cc.Method),
+		headers: cc.Headers,
+		body:    body,
+	}
+
+	m.Client.Timeout = cc.Timeout
+
+	return m, nil
+}
+
+// Send implements the Messenger interface
+func (m *Webhook) Send(title, msg string) {
+	m.SendEvent(Event{}, title, msg)
+}
+
+// SendEvent implements the EventMessenger interface
+func (m *Webhook) SendEvent(ev Event, title, msg string) {
+	b, err := m.payload(ev, title, msg)
+	if err != nil {
+		m.log.ERROR.Printf("body: %v", err)
+		return
+	}
+
+	req, err := request.New(m.method, m.uri, bytes.NewReader(b), m.headers)
+	if err == nil {
+		_, err = m.DoBody(req)
+	}
+
+	if err != nil {
+		m.log.ERROR.Println(err)
+	}
+}
+
+// payload renders the request body
+func (m *Webhook) payload(ev Event, title, msg string) ([]byte, error) {
+	data := map[string]interface{}{
+		"event": ev.Event,
+		"title": title,
+		"msg":   msg,
+	}
+
+	if ev.Loadpoint != nil {
+		data["loadpoint"] = *ev.Loadpoint + 1
+	}
+
+	if m.body == nil {
+		return json.Marshal(data)
+	}
+
+	var b bytes.Buffer
+	err := m.body.Execute(&b, data)
+
+	return b.Bytes(), err
+}
@@ -0,0 +1,59 @@
+package push
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	type request struct {
+		method, contentType, auth, body string
+	}
+
+	reqC := make(chan request, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		reqC <- request{r.Method, r.Header.Get("Content-Type"), r.Header.Get("Authorization"), string(b)}
+	}))
+	defer srv.Close()
+
+	lp := 0
+	ev := Event{Loadpoint: &lp, Event: "start"}
+
+	// default json body
+	m, err := NewWebhookFromConfig(map[string]interface{}{"uri": srv.URL})
+	require.NoError(t, err)
+
+	m.(EventMessenger).SendEvent(ev, "title", "msg")
+
+	req := <-reqC
+	assert.Equal(t, http.MethodPost, req.method)
+	assert.Equal(t, "application/json", req.contentType)
+	assert.JSONEq(t, `{"event":"start","loadpoint":1,"title":"title","msg":"msg"}`, req.body)
+
+	// templated body
+	m, err = NewWebhookFromConfig(map[string]interface{}{
+		"uri":     srv.URL,
+		"method":  "put",
+		"headers": map[string]string{"Authorization": "Bearer foo"},
+		"body":    `{"text":{{ printf "%s: %s" .title .msg | toJson }},"lp":{{ .loadpoint }}}`,
+	})
+	require.NoError(t, err)
+
+	m.(EventMessenger).SendEvent(ev, "title", `"quoted"`)
+
+	req = <-reqC
+	assert.Equal(t, http.MethodPut, req.method)
+	assert.Equal(t, "Bearer foo", req.auth)
+	assert.JSONEq(t, `{"text":"title: \"quoted\"","lp":1}`, req.body)
+
+	// invalid template
+	_, err = NewWebhookFromConfig(map[string]interface{}{"uri": srv.URL, "body": "{{ .title"})
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/glebarez/sqlite"
 	"github.com/mitchellh/go-homedir"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -28,14 +29,13 @@ func New(driver, dsn string) (*gorm.DB, error) {
 		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
 			return nil, err
 		}
-		// avoid busy errors
-		dialect = sqlite.Open(file + "?_pragma=busy_timeout(5000)")
-	// case "postgres":
-	// 	dialect = postgres.Open(dsn)
-	// case "mysql":
-	// 	dialect = mysql.Open(dsn)
+		// avoid busy errors, use write-ahead log and reduce syncs to limit flash wear
+		dialect = sqlite.Open(file + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)")
+	case "postgres":
+		log.INFO.Println("using postgres database")
+		dialect = postgres.Open(dsn)
 	default:
-		return nil, fmt.Errorf("invalid database type: %s not in [sqlite]", driver)
+		return nil, fmt.Errorf("invalid database type: %s not in [sqlite postgres]", driver)
 	}
 
 	return gorm.Open(dialect, &gorm.Config{
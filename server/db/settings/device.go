@@ -0,0 +1,96 @@
+package settings
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// devicePrefix namespaces the persistent device ids
+const devicePrefix = "device."
+
+// deviceEntry is the persistent id of a configured device
+type deviceEntry struct {
+	ID          string `json:"id"`
+	Fingerprint string `json:"fingerprint"` // configuration excluding name and title
+}
+
+// DeviceIDs returns persistent unique ids for the devices of the given class, keyed by device name.
+// A device with unknown name adopts the id of a device that is no longer configured if their configuration fingerprints are identical,
+// i.e. renaming a device keeps its id. New devices are assigned a random id.
+func DeviceIDs(class string, fingerprints map[string]string) (map[string]string, error) {
+	prefix := devicePrefix + class + "."
+
+	// known devices by name
+	known := make(map[string]deviceEntry)
+
+	mu.RLock()
+	for _, s := range settings {
+		if name, ok := strings.CutPrefix(s.Key, prefix); ok {
+			var e deviceEntry
+			if err := json.Unmarshal([]byte(s.Value), &e); err == nil && e.ID != "" {
+				known[name] = e
+			}
+		}
+	}
+	mu.RUnlock()
+
+	names := maps.Keys(fingerprints)
+	slices.Sort(names)
+
+	res := make(map[string]string, len(names))
+
+	// devices configured with unchanged name
+	for _, name := range names {
+		if e, ok := known[name]; ok {
+			res[name] = e.ID
+			delete(known, name)
+
+			if e.Fingerprint != fingerprints[name] {
+				e.Fingerprint = fingerprints[name]
+				if err := SetJson(prefix+name, e); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// renamed or new devices
+	for _, name := range names {
+		if _, ok := res[name]; ok {
+			continue
+		}
+
+		e := deviceEntry{Fingerprint: fingerprints[name]}
+
+		var renamed []string
+		for prev, pe := range known {
+			if pe.Fingerprint == e.Fingerprint {
+				renamed = append(renamed, prev)
+			}
+		}
+
+		// ambiguous if multiple devices share the configuration
+		if len(renamed) == 1 {
+			e.ID = known[renamed[0]].ID
+			delete(known, renamed[0])
+
+			if err := Delete(prefix + renamed[0]); err != nil {
+				return nil, err
+			}
+		} else {
+			e.ID = uuid.NewString()
+		}
+
+		if err := SetJson(prefix+name, e); err != nil {
+			return nil, err
+		}
+
+		res[name] = e.ID
+	}
+
+	return res, nil
+}
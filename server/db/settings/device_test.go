@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceIDs(t *testing.T) {
+	ids, err := DeviceIDs("charger", map[string]string{"garage": "foo", "carport": "bar"})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.NotEqual(t, ids["garage"], ids["carport"])
+
+	// stable ids
+	res, err := DeviceIDs("charger", map[string]string{"garage": "foo", "carport": "bar"})
+	require.NoError(t, err)
+	assert.Equal(t, ids, res)
+
+	// renamed device with unchanged configuration keeps id
+	res, err = DeviceIDs("charger", map[string]string{"garage": "foo", "driveway": "bar"})
+	require.NoError(t, err)
+	assert.Equal(t, ids["garage"], res["garage"])
+	assert.Equal(t, ids["carport"], res["driveway"])
+
+	// changed configuration with unchanged name keeps id
+	res, err = DeviceIDs("charger", map[string]string{"garage": "baz", "driveway": "bar"})
+	require.NoError(t, err)
+	assert.Equal(t, ids["garage"], res["garage"])
+
+	// renamed device with changed configuration is new
+	res, err = DeviceIDs("charger", map[string]string{"garage": "baz", "carport": "qux"})
+	require.NoError(t, err)
+	assert.NotEqual(t, ids["carport"], res["carport"])
+
+	// classes are independent
+	res, err = DeviceIDs("vehicle", map[string]string{"garage": "baz"})
+	require.NoError(t, err)
+	assert.NotEqual(t, ids["garage"], res["garage"])
+}
@@ -0,0 +1,204 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/server/db"
+	"golang.org/x/exp/slices"
+)
+
+// extensionPrefix namespaces third-party extension settings from evcc's own settings
+const extensionPrefix = "ext."
+
+// maxExtensionSize limits the encoded size of a single extension setting
+const maxExtensionSize = 4096
+
+var (
+	extensionRE = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+	validatorsMu sync.RWMutex
+	validators   = make(map[string]Validator)
+)
+
+// ValueType is the type of an extension setting
+type ValueType string
+
+// extension setting types
+const (
+	TypeString ValueType = "string"
+	TypeNumber ValueType = "number"
+	TypeBool   ValueType = "bool"
+	TypeJson   ValueType = "json"
+)
+
+// Validator validates an extension setting before it is stored
+type Validator func(key string, val any) error
+
+// RegisterValidator registers a validation hook for the extension namespace
+func RegisterValidator(namespace string, fun Validator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	if _, exists := validators[namespace]; exists {
+		panic(fmt.Sprintf("cannot register duplicate validator: %s", namespace))
+	}
+
+	validators[namespace] = fun
+}
+
+// TypeOf returns the type of a decoded json value
+func TypeOf(val any) (ValueType, error) {
+	switch val.(type) {
+	case string:
+		return TypeString, nil
+	case float64, json.Number:
+		return TypeNumber, nil
+	case bool:
+		return TypeBool, nil
+	case map[string]any, []any:
+		return TypeJson, nil
+	default:
+		return "", fmt.Errorf("invalid type: %T", val)
+	}
+}
+
+func extensionKey(namespace, key string) (string, error) {
+	if !extensionRE.MatchString(namespace) {
+		return "", fmt.Errorf("invalid namespace: %s", namespace)
+	}
+	if !extensionRE.MatchString(key) {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+	return extensionPrefix + namespace + "." + key, nil
+}
+
+// SetExtension stores an extension setting. Once stored, the setting's type cannot change unless it is deleted.
+func SetExtension(namespace, key string, val any) error {
+	k, err := extensionKey(namespace, key)
+	if err != nil {
+		return err
+	}
+
+	typ, err := TypeOf(val)
+	if err != nil {
+		return err
+	}
+
+	if prev, err := Extension(namespace, key); err == nil {
+		if prevTyp, _ := TypeOf(prev); prevTyp != typ {
+			return fmt.Errorf("invalid type: %s, expected %s", typ, prevTyp)
+		}
+	}
+
+	validatorsMu.RLock()
+	fun, ok := validators[namespace]
+	validatorsMu.RUnlock()
+
+	if ok {
+		if err := fun(key, val); err != nil {
+			return err
+		}
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	if len(b) > maxExtensionSize {
+		return fmt.Errorf("value exceeds %d bytes", maxExtensionSize)
+	}
+
+	SetString(k, string(b))
+
+	return nil
+}
+
+// Extension returns an extension setting
+func Extension(namespace, key string) (any, error) {
+	k, err := extensionKey(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var res any
+	err = Json(k, &res)
+
+	return res, err
+}
+
+// Extensions returns all settings of the extension namespace
+func Extensions(namespace string) (map[string]any, error) {
+	if !extensionRE.MatchString(namespace) {
+		return nil, fmt.Errorf("invalid namespace: %s", namespace)
+	}
+
+	prefix := extensionPrefix + namespace + "."
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	res := make(map[string]any)
+
+	for _, s := range settings {
+		if key, ok := strings.CutPrefix(s.Key, prefix); ok {
+			var val any
+			if err := json.Unmarshal([]byte(s.Value), &val); err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			res[key] = val
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteExtension removes an extension setting
+func DeleteExtension(namespace, key string) error {
+	k, err := extensionKey(namespace, key)
+	if err != nil {
+		return err
+	}
+
+	return Delete(k)
+}
+
+// Delete removes a setting
+func Delete(key string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	idx := slices.IndexFunc(settings, func(s setting) bool {
+		return s.Key == key
+	})
+	if idx < 0 {
+		return ErrNotFound
+	}
+
+	settings = slices.Delete(settings, idx, idx+1)
+
+	if db.Instance == nil {
+		return nil
+	}
+
+	return db.Instance.Delete(&setting{Key: key}).Error
+}
+
+// Rename moves a setting to a new key. An existing setting with the new key takes precedence.
+func Rename(from, to string) error {
+	val, err := String(from)
+	if err != nil {
+		return err
+	}
+
+	if _, err := String(to); errors.Is(err, ErrNotFound) {
+		SetString(to, val)
+	}
+
+	return Delete(from)
+}
@@ -0,0 +1,46 @@
+package settings
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtension(t *testing.T) {
+	require.NoError(t, SetExtension("plugin", "count", 1.0))
+	require.NoError(t, SetExtension("plugin", "name", "foo"))
+
+	res, err := Extension("plugin", "count")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, res)
+
+	all, err := Extensions("plugin")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"count": 1.0, "name": "foo"}, all)
+
+	// type must not change
+	assert.Error(t, SetExtension("plugin", "count", "bar"))
+
+	// invalid keys and types
+	assert.Error(t, SetExtension("plugin", "foo.bar", 1.0))
+	assert.Error(t, SetExtension("plugin", "nil", nil))
+
+	// deleted settings may change type
+	require.NoError(t, DeleteExtension("plugin", "count"))
+	_, err = Extension("plugin", "count")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, SetExtension("plugin", "count", "bar"))
+
+	// validation hook
+	RegisterValidator("limited", func(key string, val any) error {
+		if f, ok := val.(float64); !ok || f > 10 {
+			return errors.New("out of range")
+		}
+		return nil
+	})
+
+	assert.NoError(t, SetExtension("limited", "value", 5.0))
+	assert.Error(t, SetExtension("limited", "value", 11.0))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -20,11 +21,15 @@ type setting struct {
 }
 
 var (
+	mu       sync.RWMutex
 	settings []setting
 	dirty    int32
 )
 
 func Init() error {
+	mu.Lock()
+	defer mu.Unlock()
+
 	err := db.Instance.AutoMigrate(new(setting))
 	if err == nil {
 		err = db.Instance.Find(&settings).Error
@@ -32,16 +37,32 @@ func Init() error {
 	return err
 }
 
+// Persist writes all settings to the database if any setting was changed since the last write
 func Persist() error {
-	dirty := atomic.CompareAndSwapInt32(&dirty, 1, 0)
-	if !dirty || len(settings) == 0 {
+	changed := atomic.CompareAndSwapInt32(&dirty, 1, 0)
+
+	mu.RLock()
+	res := slices.Clone(settings)
+	mu.RUnlock()
+
+	if !changed || len(res) == 0 {
 		// avoid "empty slice found"
 		return nil
 	}
-	return db.Instance.Save(settings).Error
+
+	err := db.Instance.Save(res).Error
+	if err != nil {
+		// retry on next persist
+		atomic.StoreInt32(&dirty, 1)
+	}
+
+	return err
 }
 
 func SetString(key string, val string) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	idx := slices.IndexFunc(settings, func(s setting) bool {
 		return s.Key == key
 	})
@@ -80,6 +101,9 @@ func SetBool(key string, val bool) {
 }
 
 func String(key string) (string, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
 	idx := slices.IndexFunc(settings, func(s setting) bool {
 		return s.Key == key
 	})
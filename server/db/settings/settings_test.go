@@ -30,3 +30,24 @@ func TestFloat(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, v, res)
 }
+
+func TestRename(t *testing.T) {
+	SetString("rename.from", "foo")
+	assert.Nil(t, Rename("rename.from", "rename.to"))
+
+	res, err := String("rename.to")
+	assert.Nil(t, err)
+	assert.Equal(t, "foo", res)
+
+	_, err = String("rename.from")
+	assert.Equal(t, ErrNotFound, err)
+
+	// existing setting is kept
+	SetString("rename.from", "bar")
+	assert.Nil(t, Rename("rename.from", "rename.to"))
+
+	res, _ = String("rename.to")
+	assert.Equal(t, "foo", res)
+
+	assert.Equal(t, ErrNotFound, Rename("rename.from", "rename.to"))
+}
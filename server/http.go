@@ -39,12 +39,16 @@ func routeLogger(inner http.Handler) http.HandlerFunc {
 // HTTPd wraps an http.Server and adds the root router
 type HTTPd struct {
 	*http.Server
+	installerPin string
 }
 
 // NewHTTPd creates HTTP server with configured routes for loadpoint
 func NewHTTPd(addr string, hub *SocketHub) *HTTPd {
 	router := mux.NewRouter().StrictSlash(true)
 
+	// home assistant ingress
+	router.Use(ingressHandler)
+
 	// websocket
 	router.HandleFunc("/ws", socketHandler(hub))
 
@@ -81,42 +85,66 @@ func (s *HTTPd) Router() *mux.Router {
 	return s.Handler.(*mux.Router)
 }
 
+// SetInstallerPin protects installer settings from being changed without the pin
+func (s *HTTPd) SetInstallerPin(pin string) {
+	s.installerPin = pin
+}
+
 // RegisterSiteHandlers connects the http handlers to the site
 func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 	router := s.Server.Handler.(*mux.Router)
 
-	// api
-	api := router.PathPrefix("/api").Subrouter()
+	// api, versioned at /api/v1 and unversioned for compatibility
+	api := router.PathPrefix("/api{version:(?:/v1)?}").Subrouter()
 	api.Use(jsonHandler)
 	api.Use(handlers.CompressHandler)
 	api.Use(handlers.CORS(
-		handlers.AllowedHeaders([]string{"Content-Type"}),
+		handlers.AllowedHeaders([]string{"Content-Type", installerPinHeader}),
 	))
 
+	spec := newOpenAPISpec("/api/v1")
+
 	// site api
 	routes := map[string]route{
 		"health":         {[]string{"GET"}, "/health", healthHandler(site)},
+		"version":        {[]string{"GET"}, "/version", versionHandler},
+		"spec":           {[]string{"GET"}, "/spec", specHandler(spec)},
 		"state":          {[]string{"GET"}, "/state", stateHandler(cache)},
+		"updated":        {[]string{"GET"}, "/state/updated", updatedHandler(cache)},
 		"config":         {[]string{"GET"}, "/config/templates/{class:[a-z]+}", templatesHandler},
 		"products":       {[]string{"GET"}, "/config/products/{class:[a-z]+}", productsHandler},
 		"test":           {[]string{"POST", "OPTIONS"}, "/config/test/{class:[a-z]+}", testHandler},
 		"buffersoc":      {[]string{"POST", "OPTIONS"}, "/buffersoc/{value:[0-9.]+}", floatHandler(site.SetBufferSoc, site.GetBufferSoc)},
 		"bufferstartsoc": {[]string{"POST", "OPTIONS"}, "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc)},
 		"prioritysoc":    {[]string{"POST", "OPTIONS"}, "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
+		"batteryprio":    {[]string{"POST", "OPTIONS"}, "/batterypriority/{value:[a-z]+}", stringHandler(site.SetBatteryPriority, site.GetBatteryPriority)},
 		"residualpower":  {[]string{"POST", "OPTIONS"}, "/residualpower/{value:[-0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
 		"smartcost":      {[]string{"POST", "OPTIONS"}, "/smartcostlimit/{value:[-0-9.]+}", floatHandler(site.SetSmartCostLimit, site.GetSmartCostLimit)},
+		"profile":        {[]string{"POST", "OPTIONS"}, "/profile/{value:[a-zA-Z0-9_-]+}", profileHandler(site)},
 		"tariff":         {[]string{"GET"}, "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
-		"sessions":       {[]string{"GET"}, "/sessions", sessionHandler},
+		"vehiclehealth":  {[]string{"GET"}, "/vehicles/health", vehicleHealthHandler(site)},
+		"vehiclerefresh": {[]string{"POST", "OPTIONS"}, "/vehicles/{vehicle:[1-9][0-9]*}/refresh", vehicleRefreshHandler(site)},
+		"auxloadmode":    {[]string{"POST", "OPTIONS"}, "/auxloads/{id:[1-9][0-9]*}/mode/{value:[a-z]+}", auxLoadModeHandler(site)},
+		"auxloadprio":    {[]string{"POST", "OPTIONS"}, "/auxloads/{id:[1-9][0-9]*}/priority/{value:[0-9]+}", auxLoadPriorityHandler(site)},
+		"sessions":       {[]string{"GET"}, "/sessions", sessionHandler(site)},
+		"summary":        {[]string{"GET"}, "/sessions/summary", sessionSummaryHandler(site)},
 		"session1":       {[]string{"PUT", "OPTIONS"}, "/session/{id:[0-9]+}", updateSessionHandler},
 		"session2":       {[]string{"DELETE", "OPTIONS"}, "/session/{id:[0-9]+}", deleteSessionHandler},
+		"schema":         {[]string{"GET"}, "/schema", schemaHandler},
 		"telemetry":      {[]string{"GET"}, "/settings/telemetry", boolGetHandler(telemetry.Enabled)},
 		"telemetry2":     {[]string{"POST", "OPTIONS"}, "/settings/telemetry/{value:[a-z]+}", boolHandler(telemetry.Enable, telemetry.Enabled)},
+		"extensions":     {[]string{"GET"}, "/settings/ext/{namespace:[a-zA-Z0-9_-]+}", extensionsHandler},
+		"extension":      {[]string{"GET"}, "/settings/ext/{namespace:[a-zA-Z0-9_-]+}/{key:[a-zA-Z0-9_-]+}", extensionHandler},
+		"extension2":     {[]string{"PUT", "OPTIONS"}, "/settings/ext/{namespace:[a-zA-Z0-9_-]+}/{key:[a-zA-Z0-9_-]+}", extensionUpdateHandler},
+		"extension3":     {[]string{"DELETE", "OPTIONS"}, "/settings/ext/{namespace:[a-zA-Z0-9_-]+}/{key:[a-zA-Z0-9_-]+}", extensionDeleteHandler},
 	}
 
 	for _, r := range routes {
 		api.Methods(r.Methods...).Path(r.Pattern).Handler(r.HandlerFunc)
 	}
 
+	spec.add("site", "", routes)
+
 	// loadpoint api
 	for id, lp := range site.Loadpoints() {
 		loadpoint := api.PathPrefix(fmt.Sprintf("/loadpoints/%d", id+1)).Subrouter()
@@ -124,9 +152,10 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 		routes := map[string]route{
 			"mode":             {[]string{"POST", "OPTIONS"}, "/mode/{value:[a-z]+}", chargeModeHandler(lp)},
 			"minsoc":           {[]string{"POST", "OPTIONS"}, "/minsoc/{value:[0-9]+}", intHandler(pass(lp.SetMinSoc), lp.GetMinSoc)},
-			"mincurrent":       {[]string{"POST", "OPTIONS"}, "/mincurrent/{value:[0-9.]+}", floatHandler(pass(lp.SetMinCurrent), lp.GetMinCurrent)},
-			"maxcurrent":       {[]string{"POST", "OPTIONS"}, "/maxcurrent/{value:[0-9.]+}", floatHandler(pass(lp.SetMaxCurrent), lp.GetMaxCurrent)},
-			"phases":           {[]string{"POST", "OPTIONS"}, "/phases/{value:[0-9]+}", phasesHandler(lp)},
+			"priority":         {[]string{"POST", "OPTIONS"}, "/priority/{value:[0-9]+}", intHandler(pass(lp.SetPriority), lp.Priority)},
+			"mincurrent":       {[]string{"POST", "OPTIONS"}, "/mincurrent/{value:[0-9.]+}", installerHandler(s.installerPin, floatHandler(pass(lp.SetMinCurrent), lp.GetMinCurrent))},
+			"maxcurrent":       {[]string{"POST", "OPTIONS"}, "/maxcurrent/{value:[0-9.]+}", installerHandler(s.installerPin, floatHandler(pass(lp.SetMaxCurrent), lp.GetMaxCurrent))},
+			"phases":           {[]string{"POST", "OPTIONS"}, "/phases/{value:[0-9]+}", installerHandler(s.installerPin, phasesHandler(lp))},
 			"targetenergy":     {[]string{"POST", "OPTIONS"}, "/target/energy/{value:[0-9.]+}", floatHandler(pass(lp.SetTargetEnergy), lp.GetTargetEnergy)},
 			"targetsoc":        {[]string{"POST", "OPTIONS"}, "/target/soc/{value:[0-9]+}", intHandler(pass(lp.SetTargetSoc), lp.GetTargetSoc)},
 			"targettime":       {[]string{"POST", "OPTIONS"}, "/target/time/{time:[0-9TZ:.-]+}", targetTimeHandler(lp)},
@@ -135,6 +164,14 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 			"vehicle":          {[]string{"POST", "OPTIONS"}, "/vehicle/{vehicle:[1-9][0-9]*}", vehicleHandler(site, lp)},
 			"vehicle2":         {[]string{"DELETE", "OPTIONS"}, "/vehicle", vehicleRemoveHandler(lp)},
 			"vehicleDetect":    {[]string{"PATCH", "OPTIONS"}, "/vehicle", vehicleDetectHandler(lp)},
+			"session":          {[]string{"PUT", "OPTIONS"}, "/session", sessionNotesHandler(lp)},
+			"schedules":        {[]string{"GET"}, "/schedules", schedulesHandler(lp)},
+			"schedules2":       {[]string{"POST", "OPTIONS"}, "/schedules", scheduleCreateHandler(lp)},
+			"schedules3":       {[]string{"PUT", "OPTIONS"}, "/schedules/{id:[1-9][0-9]*}", scheduleUpdateHandler(lp)},
+			"schedules4":       {[]string{"DELETE", "OPTIONS"}, "/schedules/{id:[1-9][0-9]*}", scheduleDeleteHandler(lp)},
+			"batch":            {[]string{"POST", "OPTIONS"}, "/batch", batchHandler(lp, s.installerPin)},
+			"budget":           {[]string{"GET"}, "/budget", budgetHandler(lp)},
+			"budget2":          {[]string{"POST", "OPTIONS"}, "/budget/{value:[0-9.]+}", installerHandler(s.installerPin, floatHandler(lp.SetBudgetEnergy, lp.GetBudgetEnergy))},
 			"remotedemand":     {[]string{"POST", "OPTIONS"}, "/remotedemand/{demand:[a-z]+}/{source::[0-9a-zA-Z_-]+}", remoteDemandHandler(lp)},
 			"enableThreshold":  {[]string{"POST", "OPTIONS"}, "/enable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetEnableThreshold), lp.GetEnableThreshold)},
 			"disableThreshold": {[]string{"POST", "OPTIONS"}, "/disable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetDisableThreshold), lp.GetDisableThreshold)},
@@ -143,6 +180,10 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 		for _, r := range routes {
 			loadpoint.Methods(r.Methods...).Path(r.Pattern).Handler(r.HandlerFunc)
 		}
+
+		if id == 0 {
+			spec.add("loadpoint", "/loadpoints/{id:[1-9][0-9]*}", routes)
+		}
 	}
 }
 
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/gorilla/mux"
+)
+
+// extensionsHandler returns all settings of an extension namespace
+func extensionsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	res, err := settings.Extensions(vars["namespace"])
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// extensionHandler returns a single extension setting
+func extensionHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	res, err := settings.Extension(vars["namespace"], vars["key"])
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, settings.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+
+		jsonError(w, status, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// extensionUpdateHandler stores an extension setting from the json request body
+func extensionUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var val any
+	if err := json.NewDecoder(r.Body).Decode(&val); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := settings.SetExtension(vars["namespace"], vars["key"], val); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, val)
+}
+
+// extensionDeleteHandler removes an extension setting
+func extensionDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if err := settings.DeleteExtension(vars["namespace"], vars["key"]); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, settings.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+
+		jsonError(w, status, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/util/locale"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// printer is a localized message printer
+type printer struct {
+	*message.Printer
+	lang language.Tag
+}
+
+// formatters convert raw SI values of well-known keys into localized display strings
+var formatters = map[string]func(p printer, v any) (string, bool){
+	"pvPower":       power,
+	"gridPower":     power,
+	"homePower":     power,
+	"batteryPower":  power,
+	"chargePower":   power,
+	"batterySoc":    percent,
+	"vehicleSoc":    percent,
+	"minSoc":        percent,
+	"targetSoc":     percent,
+	"greenShare":    share,
+	"chargedEnergy": energyWh,
+	"vehicleRange":  distance,
+	"chargeCurrent": current,
+	"minCurrent":    current,
+	"maxCurrent":    current,
+
+	"chargeDuration":          duration,
+	"connectedDuration":       duration,
+	"chargeRemainingDuration": duration,
+	"targetTime":              timestamp,
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func power(p printer, v any) (string, bool) {
+	f, ok := toFloat(v)
+	return p.Sprintf("%.1f kW", f/1e3), ok
+}
+
+func percent(p printer, v any) (string, bool) {
+	f, ok := toFloat(v)
+	return p.Sprintf("%.0f %%", f), ok
+}
+
+func share(p printer, v any) (string, bool) {
+	f, ok := toFloat(v)
+	return p.Sprintf("%.0f %%", 100*f), ok
+}
+
+func energyWh(p printer, v any) (string, bool) {
+	f, ok := toFloat(v)
+	return p.Sprintf("%.1f kWh", f/1e3), ok
+}
+
+func distance(p printer, v any) (string, bool) {
+	f, ok := toFloat(v)
+	return p.Sprintf("%.0f km", f), ok
+}
+
+func current(p printer, v any) (string, bool) {
+	f, ok := toFloat(v)
+	return p.Sprintf("%.1f A", f), ok
+}
+
+func duration(_ printer, v any) (string, bool) {
+	d, ok := v.(time.Duration)
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%d:%02d h", int(d.Hours()), int(d.Minutes())%60), ok
+}
+
+func timestamp(p printer, v any) (string, bool) {
+	ts, ok := v.(time.Time)
+	if !ok || ts.IsZero() {
+		return "", false
+	}
+
+	layout := "2006-01-02 15:04"
+	if base, _ := p.lang.Base(); base.String() == "de" {
+		layout = "02.01.2006 15:04"
+	}
+
+	return ts.Local().Format(layout), true
+}
+
+// formatLanguage determines the display language from the format query parameter,
+// the Accept-Language header or the system language
+func formatLanguage(r *http.Request) (language.Tag, bool) {
+	q := r.URL.Query()
+	if !q.Has("format") {
+		return language.Und, false
+	}
+
+	for _, s := range []string{q.Get("format"), r.Header.Get("Accept-Language"), locale.Language} {
+		if tags, _, err := language.ParseAcceptLanguage(s); err == nil && len(tags) > 0 {
+			return tags[0], true
+		}
+	}
+
+	return language.English, true
+}
+
+// formatValues returns localized display strings for all known keys
+func formatValues(p printer, data map[string]any) map[string]string {
+	res := make(map[string]string)
+
+	for k, v := range data {
+		if f, ok := formatters[k]; ok {
+			if s, ok := f(p, v); ok {
+				res[k] = s
+			}
+		}
+	}
+
+	return res
+}
+
+// formatState adds localized display strings to the site and loadpoint states
+func formatState(lang language.Tag, res map[string]any) {
+	p := printer{message.NewPrinter(lang), lang}
+
+	res["formatted"] = formatValues(p, res)
+
+	if lps, ok := res["loadpoints"].([]map[string]any); ok {
+		for _, lp := range lps {
+			lp["formatted"] = formatValues(p, lp)
+		}
+	}
+}
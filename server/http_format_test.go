@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatState(t *testing.T) {
+	res := map[string]any{
+		"pvPower": 12345.0,
+		"unknown": 1.0,
+		"loadpoints": []map[string]any{
+			{"vehicleSoc": 42.4, "connectedDuration": 65 * time.Minute},
+		},
+	}
+
+	formatState(language.German, res)
+
+	assert.Equal(t, map[string]string{"pvPower": "12,3 kW"}, res["formatted"])
+	assert.Equal(t, map[string]string{"vehicleSoc": "42 %", "connectedDuration": "1:05 h"}, res["loadpoints"].([]map[string]any)[0]["formatted"])
+}
+
+func TestFormatLanguage(t *testing.T) {
+	_, ok := formatLanguage(httptest.NewRequest("GET", "/api/state", nil))
+	assert.False(t, ok)
+
+	lang, ok := formatLanguage(httptest.NewRequest("GET", "/api/state?format=de", nil))
+	assert.True(t, ok)
+	assert.Equal(t, language.German, lang)
+
+	req := httptest.NewRequest("GET", "/api/state?format", nil)
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	lang, ok = formatLanguage(req)
+	assert.True(t, ok)
+	assert.Equal(t, language.AmericanEnglish, lang)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
 	"github.com/evcc-io/evcc/util"
@@ -97,6 +98,20 @@ func floatHandler(set func(float64) error, get func() float64) http.HandlerFunc
 	}
 }
 
+// stringHandler updates string-param api
+func stringHandler(set func(string) error, get func() string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		if err := set(vars["value"]); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, get())
+	}
+}
+
 // intHandler updates int-param api
 func intHandler(set func(int) error, get func() int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -157,7 +172,7 @@ func encodeFloats(data map[string]any) {
 	}
 }
 
-// stateHandler returns the combined state
+// stateHandler returns the combined state, optionally including localized display values
 func stateHandler(cache *util.Cache) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		res := cache.State()
@@ -165,6 +180,20 @@ func stateHandler(cache *util.Cache) http.HandlerFunc {
 			delete(res, k)
 		}
 		encodeFloats(res)
+		if lang, ok := formatLanguage(r); ok {
+			formatState(lang, res)
+		}
+		jsonResult(w, res)
+	}
+}
+
+// updatedHandler returns the update timestamps of the combined state
+func updatedHandler(cache *util.Cache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := cache.Updated()
+		for _, k := range ignoreState {
+			delete(res, k)
+		}
 		jsonResult(w, res)
 	}
 }
@@ -182,6 +211,64 @@ func healthHandler(site site.API) http.HandlerFunc {
 	}
 }
 
+// versionHandler returns the version of the executable
+func versionHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, struct {
+		Version string `json:"version"`
+		Commit  string `json:"commit,omitempty"`
+	}{
+		Version: Version,
+		Commit:  Commit,
+	})
+}
+
+// vehicleHealthHandler returns the vehicle api health status
+func vehicleHealthHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, site.GetVehicleHealth())
+	}
+}
+
+// vehicleRefreshHandler forces an immediate vehicle data update
+func vehicleRefreshHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		val, err := strconv.Atoi(vars["vehicle"])
+
+		vehicles := site.GetVehicles()
+		if err != nil || val < 1 || val > len(vehicles) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		v := vehicles[val-1]
+
+		if err := site.RefreshVehicle(v); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, api.ErrMustRetry) {
+				status = http.StatusTooManyRequests
+			}
+
+			jsonError(w, status, err)
+			return
+		}
+
+		res := struct {
+			Vehicle string `json:"vehicle"`
+		}{
+			Vehicle: v.Title(),
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// schemaHandler returns the influx and prometheus measurement schema
+func schemaHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, schema)
+}
+
 // tariffHandler returns the configured tariff
 func tariffHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -227,6 +314,70 @@ func chargeModeHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// auxLoadModeHandler updates the mode of an auxiliary load
+func auxLoadModeHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		id, err := strconv.Atoi(vars["id"])
+
+		var mode api.ChargeMode
+		if err == nil {
+			mode, err = api.ChargeModeString(vars["value"])
+		}
+
+		if err == nil {
+			err = site.SetAuxLoadMode(id-1, mode)
+		}
+
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, mode)
+	}
+}
+
+// auxLoadPriorityHandler updates the priority of an auxiliary load
+func auxLoadPriorityHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		id, err := strconv.Atoi(vars["id"])
+
+		var prio int
+		if err == nil {
+			prio, err = strconv.Atoi(vars["value"])
+		}
+
+		if err == nil {
+			err = site.SetAuxLoadPriority(id-1, prio)
+		}
+
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, prio)
+	}
+}
+
+// profileHandler applies the mode profile
+func profileHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		if err := site.SetProfile(vars["value"]); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, site.GetProfile())
+	}
+}
+
 // phasesHandler updates minimum soc
 func phasesHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -362,6 +513,139 @@ func vehicleDetectHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// budgetHandler returns the loadpoint's monthly energy budget
+func budgetHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := lp.GetBudget()
+		if res == nil {
+			jsonError(w, http.StatusNotFound, errors.New("budget not configured"))
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// batchHandler applies multiple loadpoint settings at once.
+// Safety-relevant settings require the installer pin.
+func batchHandler(lp loadpoint.API, pin string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var batch api.ActionConfig
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		installer := batch.MinCurrent != nil || batch.MaxCurrent != nil || batch.Phases != nil
+		if installer && !installerPinValid(pin, r) {
+			jsonError(w, http.StatusForbidden, errInstallerPin)
+			return
+		}
+
+		if err := lp.SetBatch(batch); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, batch)
+	}
+}
+
+// schedulesHandler returns the loadpoint's charging schedules
+func schedulesHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, lp.GetSchedules())
+	}
+}
+
+// scheduleIndex returns the zero-based index of the schedule referenced by the request
+func scheduleIndex(r *http.Request, schedules []loadpoint.Schedule) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	return id - 1, err == nil && id >= 1 && id <= len(schedules)
+}
+
+// scheduleCreateHandler adds a charging schedule
+func scheduleCreateHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var s loadpoint.Schedule
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.SetSchedules(append(lp.GetSchedules(), s)); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, lp.GetSchedules())
+	}
+}
+
+// scheduleUpdateHandler replaces a charging schedule
+func scheduleUpdateHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		schedules := lp.GetSchedules()
+
+		idx, ok := scheduleIndex(r, schedules)
+		if !ok {
+			jsonError(w, http.StatusNotFound, errors.New("schedule not found"))
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&schedules[idx]); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.SetSchedules(schedules); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, lp.GetSchedules())
+	}
+}
+
+// scheduleDeleteHandler removes a charging schedule
+func scheduleDeleteHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		schedules := lp.GetSchedules()
+
+		idx, ok := scheduleIndex(r, schedules)
+		if !ok {
+			jsonError(w, http.StatusNotFound, errors.New("schedule not found"))
+			return
+		}
+
+		if err := lp.SetSchedules(append(schedules[:idx], schedules[idx+1:]...)); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, lp.GetSchedules())
+	}
+}
+
+// sessionNotesHandler updates tags and notes of the current or next session
+func sessionNotesHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var res struct {
+			Tags  string `json:"tags"`
+			Notes string `json:"notes"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		lp.SetSessionNotes(res.Tags, res.Notes)
+
+		jsonResult(w, res)
+	}
+}
+
 // planHandler starts vehicle detection
 func planHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -389,10 +673,12 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 			Plan     api.Rates `json:"plan"`
 			Unit     string    `json:"unit"`
 			Power    float64   `json:"power"`
+			Cost     float64   `json:"cost"`
 		}{
 			Duration: int64(requiredDuration.Seconds()),
 			Plan:     plan,
 			Power:    power,
+			Cost:     planner.Cost(plan, power),
 		}
 		jsonResult(w, res)
 	}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/evcc-io/evcc/util/supervisor"
+)
+
+// ingress headers set by the Home Assistant ingress gateway
+const ingressPathHeader = "X-Ingress-Path"
+
+var ingressHeaders = []string{ingressPathHeader, "X-Remote-User-Id", "X-Remote-User-Name", "X-Remote-User-Display-Name"}
+
+// ingressHandler is a middleware that supports serving the ui behind the Home Assistant ingress gateway.
+// Ingress headers are only honoured for requests proxied by the supervisor, otherwise they are removed.
+func ingressHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !trustedIngress(r) {
+			for _, header := range ingressHeaders {
+				r.Header.Del(header)
+			}
+		} else if prefix := strings.TrimSuffix(r.Header.Get(ingressPathHeader), "/"); prefix != "" {
+			if user := r.Header.Get("X-Remote-User-Name"); user != "" {
+				log.TRACE.Printf("ingress: %s %s (%s)", r.Method, r.URL.Path, user)
+			}
+
+			w = &ingressWriter{ResponseWriter: w, prefix: prefix}
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// trustedIngress checks if the request has been proxied by the supervisor
+func trustedIngress(r *http.Request) bool {
+	if !supervisor.Enabled() {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	return err == nil && host == supervisor.Address
+}
+
+// ingressWriter rewrites absolute redirects to the dynamic ingress base path
+type ingressWriter struct {
+	http.ResponseWriter
+	prefix string
+}
+
+func (w *ingressWriter) WriteHeader(code int) {
+	if loc := w.Header().Get("Location"); strings.HasPrefix(loc, "/") && !strings.HasPrefix(loc, "//") && !strings.HasPrefix(loc, w.prefix+"/") {
+		w.Header().Set("Location", w.prefix+loc)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Hijack implements http.Hijacker for websocket connections
+func (w *ingressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hj, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hj.Hijack()
+	}
+	return nil, nil, errors.New("hijack not supported")
+}
+
+// Flush implements http.Flusher
+func (w *ingressWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
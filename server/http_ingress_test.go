@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evcc-io/evcc/util/supervisor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngress(t *testing.T) {
+	h := ingressHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/foo/", http.StatusMovedPermanently)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.RemoteAddr = supervisor.Address + ":1234"
+	req.Header.Set(ingressPathHeader, "/api/hassio_ingress/token")
+
+	// untrusted
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, "/foo/", w.Header().Get("Location"))
+	assert.Empty(t, req.Header.Get(ingressPathHeader))
+
+	// trusted
+	t.Setenv("SUPERVISOR_TOKEN", "token")
+	req.Header.Set(ingressPathHeader, "/api/hassio_ingress/token")
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, "/api/hassio_ingress/token/foo/", w.Header().Get("Location"))
+}
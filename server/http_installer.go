@@ -0,0 +1,33 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+)
+
+// installerPinHeader is the request header carrying the installer pin
+const installerPinHeader = "X-Installer-Pin"
+
+var errInstallerPin = errors.New("installer pin required")
+
+// installerHandler protects safety-relevant settings by requiring the installer pin
+func installerHandler(pin string, h http.HandlerFunc) http.HandlerFunc {
+	if pin == "" {
+		return h
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions && !installerPinValid(pin, r) {
+			jsonError(w, http.StatusForbidden, errInstallerPin)
+			return
+		}
+
+		h(w, r)
+	}
+}
+
+// installerPinValid checks if the request carries the installer pin
+func installerPinValid(pin string, r *http.Request) bool {
+	return pin == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get(installerPinHeader)), []byte(pin)) == 1
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallerHandler(t *testing.T) {
+	h := installerHandler("1234", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, tc := range []struct {
+		method, pin string
+		status      int
+	}{
+		{http.MethodPost, "", http.StatusForbidden},
+		{http.MethodPost, "4321", http.StatusForbidden},
+		{http.MethodPost, "1234", http.StatusOK},
+		{http.MethodOptions, "", http.StatusOK},
+	} {
+		req := httptest.NewRequest(tc.method, "/", nil)
+		if tc.pin != "" {
+			req.Header.Set(installerPinHeader, tc.pin)
+		}
+
+		w := httptest.NewRecorder()
+		h(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc)
+	}
+}
+
+func TestBatchHandlerInstallerPin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().SetBatch(gomock.Any()).Return(nil).Times(2)
+
+	h := batchHandler(lp, "1234")
+
+	for _, tc := range []struct {
+		body, pin string
+		status    int
+	}{
+		{`{"mode":"now","targetSoc":80}`, "", http.StatusOK},
+		{`{"mode":"now","maxCurrent":10}`, "", http.StatusForbidden},
+		{`{"mode":"now","maxCurrent":10}`, "1234", http.StatusOK},
+		{`{"mode":"foo"}`, "", http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+		if tc.pin != "" {
+			req.Header.Set(installerPinHeader, tc.pin)
+		}
+
+		w := httptest.NewRecorder()
+		h(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc)
+	}
+}
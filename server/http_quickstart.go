@@ -0,0 +1,261 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/detect/tasks"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util/templates"
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// QuickstartConfigKey is the settings key of the configuration created by quickstart
+const QuickstartConfigKey = "quickstart.config"
+
+// quickstartDevice is a template-based device confirmed by the user
+type quickstartDevice struct {
+	Title    string         `json:"title"`
+	Template string         `json:"template"`
+	Values   map[string]any `json:"values"`
+}
+
+// quickstartRequest is the set of devices confirmed by the user
+type quickstartRequest struct {
+	Title    string             `json:"title"`
+	Grid     *quickstartDevice  `json:"grid"`
+	PV       []quickstartDevice `json:"pv"`
+	Battery  []quickstartDevice `json:"battery"`
+	Chargers []quickstartDevice `json:"chargers"`
+}
+
+// quickstart holds the network discovery state
+type quickstart struct {
+	mu      sync.Mutex
+	detect  func() []tasks.Result
+	running bool
+	results []tasks.Result
+}
+
+// start runs network discovery unless already running
+func (q *quickstart) start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running {
+		return
+	}
+	q.running = true
+
+	go func() {
+		res := q.detect()
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		q.running = false
+		q.results = res
+	}()
+}
+
+// detectHandler returns the discovery state and starts discovery on POST
+func (q *quickstart) detectHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		q.start()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	res := struct {
+		Running bool           `json:"running"`
+		Results []tasks.Result `json:"results"`
+	}{
+		Running: q.running,
+		Results: q.results,
+	}
+
+	jsonResult(w, res)
+}
+
+// quickstartReserved are the device configuration keys that must not be set by the user
+var quickstartReserved = []string{"name", "type", "template", "usage"}
+
+// instance validates the device template and returns the device configuration
+func (d quickstartDevice) instance(class templates.Class, name, usage string) (map[string]any, error) {
+	other := map[string]any{
+		"template": d.Template,
+	}
+	for k, v := range d.Values {
+		if slices.Contains(quickstartReserved, strings.ToLower(k)) {
+			return nil, fmt.Errorf("%s: reserved key: %s", name, k)
+		}
+		other[k] = v
+	}
+	if usage != "" {
+		other["usage"] = usage
+	}
+
+	if _, err := templates.RenderInstance(class, other); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	res := map[string]any{
+		"name": name,
+		"type": "template",
+	}
+	for k, v := range other {
+		res[k] = v
+	}
+
+	return res, nil
+}
+
+// config creates the yaml configuration from the confirmed devices
+func (req quickstartRequest) config() ([]byte, error) {
+	if req.Grid == nil && len(req.PV) == 0 {
+		return nil, errors.New("missing either grid or pv meter")
+	}
+
+	if len(req.Chargers) == 0 {
+		return nil, errors.New("missing charger")
+	}
+
+	var meters, chargers, loadpoints []map[string]any
+	siteMeters := make(map[string]any)
+
+	if req.Grid != nil {
+		m, err := req.Grid.instance(templates.Meter, "grid", "grid")
+		if err != nil {
+			return nil, err
+		}
+		meters = append(meters, m)
+		siteMeters["grid"] = "grid"
+	}
+
+	for _, dev := range []struct {
+		usage   string
+		devices []quickstartDevice
+	}{
+		{"pv", req.PV},
+		{"battery", req.Battery},
+	} {
+		var refs []string
+		for i, d := range dev.devices {
+			name := fmt.Sprintf("%s%d", dev.usage, i+1)
+
+			m, err := d.instance(templates.Meter, name, dev.usage)
+			if err != nil {
+				return nil, err
+			}
+
+			meters = append(meters, m)
+			refs = append(refs, name)
+		}
+
+		if len(refs) > 0 {
+			siteMeters[dev.usage] = refs
+		}
+	}
+
+	for i, d := range req.Chargers {
+		name := fmt.Sprintf("wallbox%d", i+1)
+
+		c, err := d.instance(templates.Charger, name, "")
+		if err != nil {
+			return nil, err
+		}
+		chargers = append(chargers, c)
+
+		title := d.Title
+		if title == "" {
+			title = fmt.Sprintf("Loadpoint %d", i+1)
+		}
+
+		loadpoints = append(loadpoints, map[string]any{
+			"title":   title,
+			"charger": name,
+			"mode":    "pv",
+		})
+	}
+
+	title := req.Title
+	if title == "" {
+		title = "Home"
+	}
+
+	return yaml.Marshal(map[string]any{
+		"meters":     meters,
+		"chargers":   chargers,
+		"loadpoints": loadpoints,
+		"site": map[string]any{
+			"title":  title,
+			"meters": siteMeters,
+		},
+	})
+}
+
+// configHandler persists the configuration created from the confirmed devices
+func (q *quickstart) configHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database not configured"))
+		return
+	}
+
+	var req quickstartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	b, err := req.config()
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	settings.SetString(QuickstartConfigKey, string(b))
+	if err := settings.Persist(); err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, string(b))
+}
+
+// RegisterQuickstartHandlers provides network discovery and configuration creation when running without config file
+func (s *HTTPd) RegisterQuickstartHandlers(detect func() []tasks.Result) {
+	router := s.Server.Handler.(*mux.Router)
+
+	// api
+	api := router.PathPrefix("/api").Subrouter()
+	api.Use(jsonHandler)
+	api.Use(handlers.CompressHandler)
+	api.Use(handlers.CORS(
+		handlers.AllowedHeaders([]string{"Content-Type", installerPinHeader}),
+	))
+
+	if s.installerPin == "" {
+		log.WARN.Println("quickstart: api is not protected, consider setting an installer pin")
+	}
+
+	q := &quickstart{detect: detect}
+
+	// network scan and configuration changes require the installer pin
+	routes := map[string]route{
+		"detect": {[]string{"GET", "POST", "OPTIONS"}, "/quickstart/detect", installerHandler(s.installerPin, q.detectHandler)},
+		"config": {[]string{"POST", "OPTIONS"}, "/quickstart/config", installerHandler(s.installerPin, q.configHandler)},
+	}
+
+	for _, r := range routes {
+		api.Methods(r.Methods...).Path(r.Pattern).Handler(r.HandlerFunc)
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestQuickstartConfig(t *testing.T) {
+	_, err := quickstartRequest{}.config()
+	assert.Error(t, err, "missing meters")
+
+	req := quickstartRequest{
+		Grid: &quickstartDevice{Template: "sma-home-manager", Values: map[string]any{"host": "192.0.2.1"}},
+		Chargers: []quickstartDevice{
+			{Title: "Garage", Template: "go-e", Values: map[string]any{"host": "192.0.2.2"}},
+		},
+	}
+
+	b, err := req.config()
+	require.NoError(t, err)
+
+	var res struct {
+		Meters     []map[string]any
+		Chargers   []map[string]any
+		Loadpoints []map[string]any
+		Site       struct {
+			Title  string
+			Meters map[string]any
+		}
+	}
+	require.NoError(t, yaml.Unmarshal(b, &res))
+
+	require.Len(t, res.Meters, 1)
+	assert.Equal(t, "grid", res.Meters[0]["name"])
+	assert.Equal(t, "grid", res.Meters[0]["usage"])
+	assert.Equal(t, "template", res.Meters[0]["type"])
+
+	require.Len(t, res.Loadpoints, 1)
+	assert.Equal(t, "Garage", res.Loadpoints[0]["title"])
+	assert.Equal(t, "wallbox1", res.Loadpoints[0]["charger"])
+
+	assert.Equal(t, "Home", res.Site.Title)
+	assert.Equal(t, "grid", res.Site.Meters["grid"])
+
+	req.Chargers[0].Template = "invalid"
+	_, err = req.config()
+	assert.Error(t, err, "invalid template")
+}
+
+func TestQuickstartReservedKeys(t *testing.T) {
+	for _, key := range []string{"type", "Name", "template", "usage"} {
+		req := quickstartRequest{
+			Grid: &quickstartDevice{Template: "sma-home-manager", Values: map[string]any{"host": "192.0.2.1", key: "custom"}},
+			Chargers: []quickstartDevice{
+				{Template: "go-e", Values: map[string]any{"host": "192.0.2.2"}},
+			},
+		}
+
+		_, err := req.config()
+		assert.Error(t, err, key)
+	}
+}
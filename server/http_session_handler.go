@@ -8,13 +8,16 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/db"
+	"github.com/evcc-io/evcc/core/site"
 	dbserver "github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/gorilla/mux"
 	"golang.org/x/text/language"
+	"gorm.io/gorm"
 )
 
 func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename string) {
@@ -28,69 +31,144 @@ func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename str
 	}
 }
 
-// sessionHandler returns the list of charging sessions
-func sessionHandler(w http.ResponseWriter, r *http.Request) {
-	if dbserver.Instance == nil {
-		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
-		return
-	}
-
-	var res db.Sessions
+// sessionQuery returns the query for the charging sessions matching the request's filter and a matching filename
+func sessionQuery(site site.API, r *http.Request) (*gorm.DB, string, error) {
 	year := r.URL.Query().Get("year")
 	month := r.URL.Query().Get("month")
 
 	filename := "session"
 
-	fmtYear := "%"
-	fmtMonth := "%"
+	// include queued session updates
+	db.Flush()
+
+	txn := dbserver.Instance.Where("charged_kwh>=0.05")
 
+	// filter by time range instead of date functions to remain database agnostic
 	if year != "" {
-		fmtYear = year
-		filename += "-" + fmtYear
+		iYear, err := strconv.Atoi(year)
+		if err != nil {
+			return nil, "", err
+		}
+
+		from := time.Date(iYear, time.January, 1, 0, 0, 0, 0, time.Local)
+		to := from.AddDate(1, 0, 0)
+		filename += "-" + year
 
 		if month != "" {
 			iMonth, err := strconv.Atoi(month)
 			if err != nil {
-				jsonError(w, http.StatusBadRequest, err)
-				return
+				return nil, "", err
 			}
 
-			fmtMonth = fmt.Sprintf("%02d", iMonth)
-			filename += "." + fmtMonth
+			from = time.Date(iYear, time.Month(iMonth), 1, 0, 0, 0, 0, time.Local)
+			to = from.AddDate(0, 1, 0)
+			filename += "." + fmt.Sprintf("%02d", iMonth)
 		}
+
+		txn = txn.Where("created >= ? AND created < ?", from, to)
 	}
 
-	// TODO support other databases than Sqlite
-	whereQuery := "charged_kwh>=0.05 AND strftime('%Y', created) LIKE ? AND strftime('%m', created) LIKE ?"
-	if txn := dbserver.Instance.Where(whereQuery, fmtYear, fmtMonth).Order("created DESC").Find(&res); txn.Error != nil {
-		jsonError(w, http.StatusInternalServerError, txn.Error)
-		return
+	if vehicle := r.URL.Query().Get("vehicle"); vehicle != "" {
+		txn = txn.Where("vehicle = ?", vehicle)
+	}
+
+	if loadpoint := r.URL.Query().Get("loadpoint"); loadpoint != "" {
+		txn = txn.Where("loadpoint = ?", loadpoint)
+	}
+
+	if group := r.URL.Query().Get("group"); group != "" {
+		titles, ok := site.GetGroups()[group]
+		if !ok {
+			return nil, "", fmt.Errorf("invalid group: %s", group)
+		}
+
+		txn = txn.Where("loadpoint IN ?", titles)
+		filename += "-" + group
 	}
 
-	// prepare data
-	for i, s := range res {
-		if s.Odometer != nil {
-			odo := math.Round(*s.Odometer*10) / 10
-			res[i].Odometer = &odo
+	return txn.Order("created DESC"), filename, nil
+}
+
+// csvContext returns a context with the requested csv language
+func csvContext(r *http.Request) context.Context {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		// get request language
+		lang = r.Header.Get("Accept-Language")
+		if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
+			lang = tags[0].String()
 		}
 	}
 
-	if r.URL.Query().Get("format") == "csv" {
-		lang := r.URL.Query().Get("lang")
-		if lang == "" {
-			// get request language
-			lang = r.Header.Get("Accept-Language")
-			if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
-				lang = tags[0].String()
+	return context.WithValue(context.Background(), locale.Locale, lang)
+}
+
+// sessionHandler returns the list of charging sessions
+func sessionHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if dbserver.Instance == nil {
+			jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+			return
+		}
+
+		txn, filename, err := sessionQuery(site, r)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var res db.Sessions
+		if txn := txn.Find(&res); txn.Error != nil {
+			jsonError(w, http.StatusInternalServerError, txn.Error)
+			return
+		}
+
+		// prepare data
+		for i, s := range res {
+			if s.Odometer != nil {
+				odo := math.Round(*s.Odometer*10) / 10
+				res[i].Odometer = &odo
 			}
 		}
 
-		ctx := context.WithValue(context.Background(), locale.Locale, lang)
-		csvResult(ctx, w, &res, filename)
-		return
+		if r.URL.Query().Get("format") == "csv" {
+			csvResult(csvContext(r), w, &res, filename)
+			return
+		}
+
+		jsonResult(w, res)
 	}
+}
 
-	jsonResult(w, res)
+// sessionSummaryHandler returns the monthly summary of charging sessions
+func sessionSummaryHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if dbserver.Instance == nil {
+			jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+			return
+		}
+
+		txn, filename, err := sessionQuery(site, r)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var sessions db.Sessions
+		if txn := txn.Find(&sessions); txn.Error != nil {
+			jsonError(w, http.StatusInternalServerError, txn.Error)
+			return
+		}
+
+		res := sessions.Monthly()
+
+		if r.URL.Query().Get("format") == "csv" {
+			csvResult(csvContext(r), w, &res, filename+"-summary")
+			return
+		}
+
+		jsonResult(w, res)
+	}
 }
 
 // deleteSessionHandler removes session in sessions table with given id
@@ -106,7 +184,7 @@ func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 	id := vars["id"]
 
 	if txn := dbserver.Instance.Table("sessions").Delete(&res, id); txn.Error != nil {
-		jsonError(w, http.StatusBadRequest, txn.Error)
+		jsonError(w, http.StatusInternalServerError, txn.Error)
 		return
 	}
 
@@ -130,7 +208,7 @@ func updateSessionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if txn := dbserver.Instance.Table("sessions").Where("id = ?", id).Updates(&session); txn.Error != nil {
-		jsonError(w, http.StatusBadRequest, txn.Error)
+		jsonError(w, http.StatusInternalServerError, txn.Error)
 		return
 	}
 }
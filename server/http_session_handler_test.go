@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	dbserver "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionHandlerStatus(t *testing.T) {
+	db, err := dbserver.New("sqlite", filepath.Join(t.TempDir(), "evcc.db"))
+	require.NoError(t, err)
+
+	instance := dbserver.Instance
+	dbserver.Instance = db
+	t.Cleanup(func() { dbserver.Instance = instance })
+
+	for _, tc := range []struct {
+		query  string
+		status int
+	}{
+		{"?year=foo", http.StatusBadRequest},
+		{"", http.StatusInternalServerError}, // sessions table missing
+	} {
+		w := httptest.NewRecorder()
+		sessionHandler(nil)(w, httptest.NewRequest(http.MethodGet, "/api/sessions"+tc.query, nil))
+		assert.Equal(t, tc.status, w.Code, tc.query)
+	}
+}
@@ -14,17 +14,22 @@ import (
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
 	influxlog "github.com/influxdata/influxdb-client-go/v2/log"
+	"golang.org/x/exp/maps"
 )
 
 // InfluxConfig is the influx db configuration
 type InfluxConfig struct {
 	URL      string
 	Database string
+	Bucket   string // InfluxDB v2 alias for database
 	Token    string
 	Org      string
 	User     string
 	Password string
+	Tags     map[string]string // additional tags added to all points
+	SiteTag  bool              // add site title as site tag to all points
 	Interval time.Duration
+	Schema   int // measurement schema version, 0 for legacy
 }
 
 // Influx is a influx publisher
@@ -35,10 +40,13 @@ type Influx struct {
 	client   influxdb2.Client
 	org      string
 	database string
+	tags     map[string]string
+	siteTag  bool
+	schema   int
 }
 
 // NewInfluxClient creates new publisher for influx
-func NewInfluxClient(url, token, org, user, password, database string) *Influx {
+func NewInfluxClient(url, token, org, user, password, database string, tags map[string]string, siteTag bool, schema int) *Influx {
 	log := util.NewLogger("influx")
 
 	// InfluxDB v1 compatibility
@@ -58,6 +66,9 @@ func NewInfluxClient(url, token, org, user, password, database string) *Influx {
 		client:   client,
 		org:      org,
 		database: database,
+		tags:     tags,
+		siteTag:  siteTag,
+		schema:   schema,
 	}
 }
 
@@ -132,6 +143,38 @@ func (m *Influx) writeComplexPoint(writer pointWriter, param util.Param, tags ma
 	m.writePoint(writer, param.Key, fields, tags)
 }
 
+// writeSchemaPoint asynchronously writes a point to influx using the versioned measurement schema
+func (m *Influx) writeSchemaPoint(writer pointWriter, param util.Param, tags map[string]string) {
+	sm, ok := schemaMeasurement(param.Key)
+	if !ok || sm.Loadpoint != (param.Loadpoint != nil) {
+		return
+	}
+
+	for _, v := range schemaValues(sm, param.Val) {
+		tags := maps.Clone(tags)
+		if v.phase != "" {
+			tags[labelPhase] = v.phase
+		}
+
+		m.writePoint(writer, param.Key, map[string]any{"value": v.value}, tags)
+	}
+}
+
+// pointTags returns the tags added to all points
+func (m *Influx) pointTags(site site.API) map[string]string {
+	tags := make(map[string]string)
+	if m.siteTag {
+		if title := site.GetTitle(); title != "" {
+			tags["site"] = title
+		}
+	}
+	for k, v := range m.tags {
+		tags[k] = v
+	}
+
+	return tags
+}
+
 // Run Influx publisher
 func (m *Influx) Run(site site.API, in <-chan util.Param) {
 	writer := m.client.WriteAPI(m.org, m.database)
@@ -146,7 +189,13 @@ func (m *Influx) Run(site site.API, in <-chan util.Param) {
 
 	// add points to batch for async writing
 	for param := range in {
-		tags := make(map[string]string)
+		// aux load values are not recorded
+		if param.AuxLoad != nil {
+			continue
+		}
+
+		tags := m.pointTags(site)
+
 		if param.Loadpoint != nil {
 			lp := site.Loadpoints()[*param.Loadpoint]
 
@@ -156,6 +205,11 @@ func (m *Influx) Run(site site.API, in <-chan util.Param) {
 			}
 		}
 
+		if m.schema > 0 {
+			m.writeSchemaPoint(writer, param, tags)
+			continue
+		}
+
 		m.writeComplexPoint(writer, param, tags)
 	}
 
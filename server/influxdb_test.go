@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/util"
 	inf2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
@@ -88,3 +89,59 @@ func TestInfluxTypes(t *testing.T) {
 		w.finish()
 	}
 }
+
+func TestInfluxSchema(t *testing.T) {
+	m := &Influx{
+		log:    util.NewLogger("foo"),
+		clock:  clock.NewMock(),
+		schema: SchemaVersion,
+	}
+
+	{
+		// phases as tags
+		w := &influxWriter{
+			t: t, p: []*write.Point{
+				inf2.NewPoint("gridCurrents", map[string]string{"phase": "1"}, map[string]any{"value": 1.0}, m.clock.Now()),
+				inf2.NewPoint("gridCurrents", map[string]string{"phase": "2"}, map[string]any{"value": 2.0}, m.clock.Now()),
+				inf2.NewPoint("gridCurrents", map[string]string{"phase": "3"}, map[string]any{"value": 3.0}, m.clock.Now()),
+			},
+		}
+		m.writeSchemaPoint(w, util.Param{Key: "gridCurrents", Val: []float64{1, 2, 3}}, map[string]string{})
+		w.finish()
+	}
+
+	{
+		// loadpoint value
+		id := 0
+		w := &influxWriter{
+			t: t, p: []*write.Point{inf2.NewPoint("chargePower", map[string]string{"loadpoint": "foo"}, map[string]any{"value": 1.0}, m.clock.Now())},
+		}
+		m.writeSchemaPoint(w, util.Param{Loadpoint: &id, Key: "chargePower", Val: 1.0}, map[string]string{"loadpoint": "foo"})
+		w.finish()
+	}
+
+	{
+		// not part of schema
+		w := &influxWriter{
+			t: t, p: nil,
+		}
+		m.writeSchemaPoint(w, util.Param{Key: "foo", Val: 1.0}, map[string]string{})
+		w.finish()
+	}
+}
+
+type titleSite struct {
+	site.API
+}
+
+func (s *titleSite) GetTitle() string {
+	return "Home"
+}
+
+func TestInfluxPointTags(t *testing.T) {
+	m := &Influx{tags: map[string]string{"location": "garage"}}
+	assert.Equal(t, map[string]string{"location": "garage"}, m.pointTags(&titleSite{}), "site tag not opt-in")
+
+	m.siteTag = true
+	assert.Equal(t, map[string]string{"location": "garage", "site": "Home"}, m.pointTags(&titleSite{}))
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
@@ -23,9 +24,11 @@ var deprecatedTopics = []string{
 
 // MQTT is the MQTT server. It uses the MQTT client for publishing.
 type MQTT struct {
-	log     *util.Logger
-	Handler *mqtt.Client
-	root    string
+	log       *util.Logger
+	Handler   *mqtt.Client
+	root      string
+	Protected bool   // disable setters for installer settings
+	Discovery string // Home Assistant discovery prefix, empty to disable
 }
 
 // NewMQTT creates MQTT server
@@ -68,6 +71,17 @@ func (m *MQTT) publishSingleValue(topic string, retained bool, payload interface
 	go m.Handler.WaitForToken("send", topic, token)
 }
 
+// publishStruct publishes the struct's fields as sub topics
+func (m *MQTT) publishStruct(topic string, retained bool, val reflect.Value) {
+	typ := val.Type()
+
+	for j := 0; j < typ.NumField(); j++ {
+		n := typ.Field(j).Name
+		v := val.Field(j).Interface()
+		m.publishSingleValue(fmt.Sprintf("%s/%s", topic, strings.ToLower(n[:1])+n[1:]), retained, v)
+	}
+}
+
 func (m *MQTT) publish(topic string, retained bool, payload interface{}) {
 	// publish phase values
 	if slice, ok := payload.([]float64); ok && len(slice) == 3 {
@@ -88,15 +102,23 @@ func (m *MQTT) publish(topic string, retained bool, payload interface{}) {
 
 			// loop slice
 			for i := 0; i < val.Len(); i++ {
-				val := val.Index(i)
-				typ := val.Type()
-
-				// loop struct
-				for j := 0; j < typ.NumField(); j++ {
-					n := typ.Field(j).Name
-					v := val.Field(j).Interface()
-					m.publishSingleValue(fmt.Sprintf("%s/%d/%s", topic, i+1, strings.ToLower(n[:1])+n[1:]), retained, v)
-				}
+				m.publishStruct(fmt.Sprintf("%s/%d", topic, i+1), retained, val.Index(i))
+			}
+
+			// publish count
+			payload = val.Len()
+		}
+	}
+
+	// publish maps of structs as sub topics by key, e.g. loadpoint groups
+	if payload != nil {
+		if typ := reflect.TypeOf(payload); typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String && typ.Elem().Kind() == reflect.Struct {
+			val := reflect.ValueOf(payload)
+
+			// loop map
+			iter := val.MapRange()
+			for iter.Next() {
+				m.publishStruct(fmt.Sprintf("%s/%s", topic, iter.Key().String()), retained, iter.Value())
 			}
 
 			// publish count
@@ -155,27 +177,37 @@ func (m *MQTT) listenSetters(topic string, site site.API, lp loadpoint.API) {
 		}
 		return err
 	})
-	m.Handler.ListenSetter(topic+"/minCurrent", func(payload string) error {
-		current, err := parseFloat(payload)
+	m.Handler.ListenSetter(topic+"/priority", func(payload string) error {
+		prio, err := strconv.Atoi(payload)
 		if err == nil {
-			lp.SetMinCurrent(current)
-		}
-		return err
-	})
-	m.Handler.ListenSetter(topic+"/maxCurrent", func(payload string) error {
-		current, err := parseFloat(payload)
-		if err == nil {
-			lp.SetMaxCurrent(current)
-		}
-		return err
-	})
-	m.Handler.ListenSetter(topic+"/phases", func(payload string) error {
-		phases, err := strconv.Atoi(payload)
-		if err == nil {
-			err = lp.SetPhases(phases)
+			lp.SetPriority(prio)
 		}
 		return err
 	})
+	// installer settings are read-only if protected
+	if !m.Protected {
+		m.Handler.ListenSetter(topic+"/minCurrent", func(payload string) error {
+			current, err := parseFloat(payload)
+			if err == nil {
+				lp.SetMinCurrent(current)
+			}
+			return err
+		})
+		m.Handler.ListenSetter(topic+"/maxCurrent", func(payload string) error {
+			current, err := parseFloat(payload)
+			if err == nil {
+				lp.SetMaxCurrent(current)
+			}
+			return err
+		})
+		m.Handler.ListenSetter(topic+"/phases", func(payload string) error {
+			phases, err := strconv.Atoi(payload)
+			if err == nil {
+				err = lp.SetPhases(phases)
+			}
+			return err
+		})
+	}
 	m.Handler.ListenSetter(topic+"/vehicle", func(payload string) error {
 		vehicle, err := strconv.Atoi(payload)
 		if err == nil {
@@ -191,6 +223,29 @@ func (m *MQTT) listenSetters(topic string, site site.API, lp loadpoint.API) {
 		}
 		return err
 	})
+	m.Handler.ListenSetter(topic+"/vehicleDetect", func(payload string) error {
+		lp.StartVehicleDetection()
+		return nil
+	})
+	m.Handler.ListenSetter(topic+"/remoteDemand", func(payload string) error {
+		demand, err := loadpoint.RemoteDemandString(payload)
+		if err == nil {
+			lp.RemoteControl("mqtt", demand)
+		}
+		return err
+	})
+	m.Handler.ListenSetter(topic+"/session", func(payload string) error {
+		var res struct {
+			Tags  string `json:"tags"`
+			Notes string `json:"notes"`
+		}
+
+		err := json.Unmarshal([]byte(payload), &res)
+		if err == nil {
+			lp.SetSessionNotes(res.Tags, res.Notes)
+		}
+		return err
+	})
 	m.Handler.ListenSetter(topic+"/enableThreshold", func(payload string) error {
 		threshold, err := parseFloat(payload)
 		if err == nil {
@@ -207,6 +262,23 @@ func (m *MQTT) listenSetters(topic string, site site.API, lp loadpoint.API) {
 	})
 }
 
+func (m *MQTT) listenAuxLoadSetters(topic string, site site.API, id int) {
+	m.Handler.ListenSetter(topic+"/mode", func(payload string) error {
+		mode, err := api.ChargeModeString(payload)
+		if err == nil {
+			err = site.SetAuxLoadMode(id, mode)
+		}
+		return err
+	})
+	m.Handler.ListenSetter(topic+"/priority", func(payload string) error {
+		prio, err := strconv.Atoi(payload)
+		if err == nil {
+			err = site.SetAuxLoadPriority(id, prio)
+		}
+		return err
+	})
+}
+
 // Run starts the MQTT publisher for the MQTT API
 func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 	// alive
@@ -222,6 +294,8 @@ func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 		return err
 	})
 
+	m.Handler.ListenSetter(m.root+"/site/batteryPriority", site.SetBatteryPriority)
+
 	m.Handler.ListenSetter(m.root+"/site/bufferSoc", func(payload string) error {
 		val, err := parseFloat(payload)
 		if err == nil {
@@ -254,6 +328,8 @@ func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 		return err
 	})
 
+	m.Handler.ListenSetter(m.root+"/site/profile", site.SetProfile)
+
 	// number of loadpoints
 	topic = fmt.Sprintf("%s/loadpoints", m.root)
 	m.publish(topic, true, len(site.Loadpoints()))
@@ -264,6 +340,26 @@ func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 		m.listenSetters(topic, site, lp)
 	}
 
+	// number of aux loads
+	topic = fmt.Sprintf("%s/auxLoads", m.root)
+	m.publish(topic, true, len(site.GetAuxLoadTitles()))
+
+	// aux load setters
+	for id := range site.GetAuxLoadTitles() {
+		topic := fmt.Sprintf("%s/auxLoads/%d", m.root, id+1)
+		m.listenAuxLoadSetters(topic, site, id)
+	}
+
+	// home assistant discovery
+	if m.Discovery != "" {
+		var titles []string
+		for _, lp := range site.Loadpoints() {
+			titles = append(titles, lp.Title())
+		}
+
+		m.publishDiscovery(site.GetTitle(), titles)
+	}
+
 	// TODO remove deprecated topics
 	for _, dep := range deprecatedTopics {
 		m.publish(fmt.Sprintf("%s/site/%s", m.root, dep), true, nil)
@@ -292,6 +388,10 @@ func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 			id := *p.Loadpoint + 1
 			topic = fmt.Sprintf("%s/loadpoints/%d", m.root, id)
 		}
+		if p.AuxLoad != nil {
+			id := *p.AuxLoad + 1
+			topic = fmt.Sprintf("%s/auxLoads/%d", m.root, id)
+		}
 
 		// alive indicator
 		if time.Since(updated) > time.Second {
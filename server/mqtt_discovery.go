@@ -0,0 +1,186 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// discoveryDevice is the Home Assistant device the discovered entities belong to
+type discoveryDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model"`
+}
+
+// discoveryConfig is the Home Assistant MQTT discovery entity configuration
+type discoveryConfig struct {
+	Name              string          `json:"name"`
+	UniqueID          string          `json:"unique_id"`
+	StateTopic        string          `json:"state_topic"`
+	CommandTopic      string          `json:"command_topic,omitempty"`
+	AvailabilityTopic string          `json:"availability_topic"`
+	PayloadAvailable  string          `json:"payload_available"`
+	PayloadOn         string          `json:"payload_on,omitempty"`
+	PayloadOff        string          `json:"payload_off,omitempty"`
+	DeviceClass       string          `json:"device_class,omitempty"`
+	StateClass        string          `json:"state_class,omitempty"`
+	Unit              string          `json:"unit_of_measurement,omitempty"`
+	Options           []string        `json:"options,omitempty"`
+	Min               *float64        `json:"min,omitempty"`
+	Max               *float64        `json:"max,omitempty"`
+	Device            discoveryDevice `json:"device"`
+}
+
+// discoveryEntity describes a published value to be announced as Home Assistant entity
+type discoveryEntity struct {
+	component, key, name string
+	deviceClass, unit    string
+	options              []string
+	min, max             float64
+	setter, protected    bool
+}
+
+func sensor(key, name, deviceClass, unit string) discoveryEntity {
+	return discoveryEntity{component: "sensor", key: key, name: name, deviceClass: deviceClass, unit: unit}
+}
+
+func binarySensor(key, name, deviceClass string) discoveryEntity {
+	return discoveryEntity{component: "binary_sensor", key: key, name: name, deviceClass: deviceClass}
+}
+
+func number(key, name, unit string, min, max float64, protected bool) discoveryEntity {
+	return discoveryEntity{component: "number", key: key, name: name, unit: unit, min: min, max: max, setter: true, protected: protected}
+}
+
+var discoverySiteEntities = []discoveryEntity{
+	sensor("gridPower", "Grid power", "power", "W"),
+	sensor("pvPower", "PV power", "power", "W"),
+	sensor("homePower", "Home power", "power", "W"),
+	sensor("batteryPower", "Battery power", "power", "W"),
+	sensor("batterySoc", "Battery soc", "battery", "%"),
+	number("bufferSoc", "Battery buffer soc", "%", 0, 100, false),
+	number("prioritySoc", "Battery priority soc", "%", 0, 100, false),
+	{component: "select", key: "batteryPriority", name: "Battery priority", options: []string{"soc", "vehicle", "battery"}, setter: true},
+}
+
+var discoveryLoadpointEntities = []discoveryEntity{
+	sensor("chargePower", "Charge power", "power", "W"),
+	sensor("chargedEnergy", "Charged energy", "energy", "Wh"),
+	sensor("vehicleTitle", "Vehicle", "", ""),
+	sensor("vehicleSoc", "Vehicle soc", "battery", "%"),
+	sensor("vehicleRange", "Vehicle range", "distance", "km"),
+	binarySensor("connected", "Connected", "plug"),
+	binarySensor("charging", "Charging", "battery_charging"),
+	{component: "select", key: "mode", name: "Mode", options: []string{
+		string(api.ModeOff), string(api.ModeNow), string(api.ModeMinPV), string(api.ModePV),
+	}, setter: true},
+	number("minSoc", "Min soc", "%", 0, 100, false),
+	number("targetSoc", "Target soc", "%", 0, 100, false),
+	number("minCurrent", "Min current", "A", 0, 32, true),
+	number("maxCurrent", "Max current", "A", 0, 32, true),
+}
+
+// discoveryObjectID converts a title into a Home Assistant compatible object id
+func discoveryObjectID(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(s))
+}
+
+// discovery creates the Home Assistant discovery configs by config topic
+func (m *MQTT) discovery(siteTitle string, loadpoints []string) map[string]discoveryConfig {
+	res := make(map[string]discoveryConfig)
+	node := discoveryObjectID(m.root)
+
+	add := func(device discoveryDevice, topic, id string, entities []discoveryEntity) {
+		for _, e := range entities {
+			if e.protected && m.Protected {
+				continue
+			}
+
+			uid := fmt.Sprintf("%s_%s_%s", node, id, discoveryObjectID(e.key))
+
+			dc := discoveryConfig{
+				Name:              e.name,
+				UniqueID:          uid,
+				StateTopic:        topic + "/" + e.key,
+				AvailabilityTopic: m.root + "/status",
+				PayloadAvailable:  "online",
+				DeviceClass:       e.deviceClass,
+				Unit:              e.unit,
+				Options:           e.options,
+				Device:            device,
+			}
+
+			if e.setter {
+				dc.CommandTopic = dc.StateTopic + "/set"
+			}
+
+			switch e.component {
+			case "sensor":
+				if e.unit != "" {
+					dc.StateClass = "measurement"
+					if e.deviceClass == "energy" {
+						dc.StateClass = "total_increasing"
+					}
+				}
+			case "binary_sensor":
+				dc.PayloadOn, dc.PayloadOff = "true", "false"
+			case "number":
+				min, max := e.min, e.max
+				dc.Min, dc.Max = &min, &max
+			}
+
+			res[fmt.Sprintf("%s/%s/%s/%s/config", m.Discovery, e.component, node, uid)] = dc
+		}
+	}
+
+	if siteTitle == "" {
+		siteTitle = "Site"
+	}
+
+	site := discoveryDevice{
+		Identifiers:  []string{node + "_site"},
+		Name:         siteTitle,
+		Manufacturer: "evcc",
+		Model:        "Site",
+	}
+	add(site, m.root+"/site", "site", discoverySiteEntities)
+
+	for i, title := range loadpoints {
+		id := fmt.Sprintf("lp%d", i+1)
+		if title == "" {
+			title = fmt.Sprintf("Loadpoint %d", i+1)
+		}
+
+		lp := discoveryDevice{
+			Identifiers:  []string{node + "_" + id},
+			Name:         title,
+			Manufacturer: "evcc",
+			Model:        "Loadpoint",
+		}
+		add(lp, fmt.Sprintf("%s/loadpoints/%d", m.root, i+1), id, discoveryLoadpointEntities)
+	}
+
+	return res
+}
+
+// publishDiscovery publishes the Home Assistant discovery configs
+func (m *MQTT) publishDiscovery(siteTitle string, loadpoints []string) {
+	for topic, dc := range m.discovery(siteTitle, loadpoints) {
+		b, err := json.Marshal(dc)
+		if err != nil {
+			m.log.ERROR.Printf("discovery: %v", err)
+			continue
+		}
+
+		m.publishSingleValue(topic, true, string(b))
+	}
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMqttDiscovery(t *testing.T) {
+	m := &MQTT{root: "evcc", Discovery: "homeassistant"}
+
+	res := m.discovery("Home", []string{"Garage"})
+	assert.Len(t, res, len(discoverySiteEntities)+len(discoveryLoadpointEntities))
+
+	dc, ok := res["homeassistant/select/evcc/evcc_lp1_mode/config"]
+	require.True(t, ok)
+	assert.Equal(t, "evcc/loadpoints/1/mode", dc.StateTopic)
+	assert.Equal(t, "evcc/loadpoints/1/mode/set", dc.CommandTopic)
+	assert.Equal(t, "evcc/status", dc.AvailabilityTopic)
+	assert.Equal(t, "Garage", dc.Device.Name)
+	assert.Equal(t, []string{"off", "now", "minpv", "pv"}, dc.Options)
+
+	dc, ok = res["homeassistant/sensor/evcc/evcc_site_gridpower/config"]
+	require.True(t, ok)
+	assert.Equal(t, "evcc/site/gridPower", dc.StateTopic)
+	assert.Empty(t, dc.CommandTopic)
+	assert.Equal(t, "measurement", dc.StateClass)
+
+	// installer settings are not exposed if protected
+	m.Protected = true
+	_, ok = m.discovery("Home", []string{"Garage"})["homeassistant/number/evcc/evcc_lp1_maxcurrent/config"]
+	assert.False(t, ok)
+}
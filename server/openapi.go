@@ -0,0 +1,181 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// openAPIVersion is the version of the OpenAPI specification format
+const openAPIVersion = "3.0.3"
+
+// routeSummaries documents the api routes by route name
+var routeSummaries = map[string]string{
+	// site
+	"health":         "Health status of the site",
+	"version":        "Version and commit of the executable",
+	"spec":           "OpenAPI specification of this api",
+	"state":          "Complete system state",
+	"updated":        "Time of the last state update",
+	"config":         "Device templates for the given class",
+	"products":       "Device products for the given class",
+	"test":           "Test a device configuration",
+	"buffersoc":      "Set home battery buffer soc",
+	"bufferstartsoc": "Set home battery buffer start soc",
+	"prioritysoc":    "Set home battery priority soc",
+	"batteryprio":    "Set home battery priority",
+	"residualpower":  "Set residual grid power",
+	"smartcost":      "Set smart charging cost limit",
+	"profile":        "Apply settings profile",
+	"tariff":         "Rates of the given tariff",
+	"vehiclehealth":  "Health status of the vehicle apis",
+	"vehiclerefresh": "Refresh vehicle data",
+	"auxloadmode":    "Set auxiliary load mode",
+	"auxloadprio":    "Set auxiliary load priority",
+	"sessions":       "List charging sessions",
+	"summary":        "Summary of charging sessions",
+	"session1":       "Update charging session",
+	"session2":       "Delete charging session",
+	"schema":         "Measurement schema of influx and prometheus",
+	"telemetry":      "Telemetry status",
+	"telemetry2":     "Enable or disable telemetry",
+	"extensions":     "Settings of an extension namespace",
+	"extension":      "Get extension setting",
+	"extension2":     "Set extension setting, the type must not change",
+	"extension3":     "Delete extension setting",
+
+	// loadpoint
+	"mode":             "Set charge mode",
+	"minsoc":           "Set minimum soc",
+	"priority":         "Set priority",
+	"mincurrent":       "Set minimum current (installer)",
+	"maxcurrent":       "Set maximum current (installer)",
+	"phases":           "Set phases (installer)",
+	"targetenergy":     "Set target energy",
+	"targetsoc":        "Set target soc",
+	"targettime":       "Set target time",
+	"targettime2":      "Remove target time",
+	"plan":             "Charging plan for the current target",
+	"vehicle":          "Assign vehicle",
+	"vehicle2":         "Remove vehicle",
+	"vehicleDetect":    "Start vehicle detection",
+	"session":          "Update notes of the current session",
+	"schedules":        "List charging schedules",
+	"schedules2":       "Add charging schedule",
+	"schedules3":       "Update charging schedule",
+	"schedules4":       "Delete charging schedule",
+	"budget":           "Monthly energy budget and energy used",
+	"budget2":          "Raise monthly energy budget (installer)",
+	"remotedemand":     "Set remote demand",
+	"enableThreshold":  "Set enable threshold",
+	"disableThreshold": "Set disable threshold",
+}
+
+type openAPISpec struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    openAPIInfo                            `json:"info"`
+	Servers []openAPIServer                        `json:"servers"`
+	Paths   map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Tags        []string                   `json:"tags"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string        `json:"name"`
+	In       string        `json:"in"`
+	Required bool          `json:"required"`
+	Schema   openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Type    string `json:"type"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+type openAPIResponse struct {
+	Description string `json:"description"`
+}
+
+// newOpenAPISpec creates an empty api specification
+func newOpenAPISpec(server string) *openAPISpec {
+	return &openAPISpec{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: "evcc", Version: Version},
+		Servers: []openAPIServer{{URL: server}},
+		Paths:   make(map[string]map[string]openAPIOperation),
+	}
+}
+
+var routeVarRE = regexp.MustCompile(`{([^:}]+)(?::([^}]*))?}`)
+
+// add documents the routes below the given path prefix
+func (s *openAPISpec) add(tag, prefix string, routes map[string]route) {
+	for name, r := range routes {
+		var params []openAPIParameter
+
+		for _, m := range routeVarRE.FindAllStringSubmatch(prefix+r.Pattern, -1) {
+			p := openAPIParameter{
+				Name:     m[1],
+				In:       "path",
+				Required: true,
+				Schema:   openAPISchema{Type: "string"},
+			}
+
+			if m[2] != "" {
+				p.Schema.Pattern = "^" + m[2] + "$"
+			}
+
+			params = append(params, p)
+		}
+
+		path := routeVarRE.ReplaceAllString(prefix+r.Pattern, "{$1}")
+
+		summary := routeSummaries[name]
+		if summary == "" {
+			summary = name
+		}
+
+		for _, method := range r.Methods {
+			if method == http.MethodOptions {
+				continue
+			}
+
+			if s.Paths[path] == nil {
+				s.Paths[path] = make(map[string]openAPIOperation)
+			}
+
+			s.Paths[path][strings.ToLower(method)] = openAPIOperation{
+				OperationID: tag + strings.ToUpper(name[:1]) + name[1:],
+				Summary:     summary,
+				Tags:        []string{tag},
+				Parameters:  params,
+				Responses: map[string]openAPIResponse{
+					"200":     {Description: "Success"},
+					"default": {Description: "Error"},
+				},
+			}
+		}
+	}
+}
+
+// specHandler returns the api specification
+func specHandler(spec *openAPISpec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonWrite(w, spec)
+	}
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPISpec(t *testing.T) {
+	spec := newOpenAPISpec("/api/v1")
+	spec.add("loadpoint", "/loadpoints/{id:[1-9][0-9]*}", map[string]route{
+		"minsoc": {[]string{"POST", "OPTIONS"}, "/minsoc/{value:[0-9]+}", nil},
+		"plan":   {[]string{"GET"}, "/target/plan", nil},
+	})
+
+	require.Len(t, spec.Paths, 2)
+
+	op, ok := spec.Paths["/loadpoints/{id}/minsoc/{value}"]["post"]
+	require.True(t, ok)
+	assert.Len(t, spec.Paths["/loadpoints/{id}/minsoc/{value}"], 1, "options not documented")
+	assert.Equal(t, "loadpointMinsoc", op.OperationID)
+	assert.Equal(t, routeSummaries["minsoc"], op.Summary)
+	assert.Equal(t, []openAPIParameter{
+		{Name: "id", In: "path", Required: true, Schema: openAPISchema{Type: "string", Pattern: "^[1-9][0-9]*$"}},
+		{Name: "value", In: "path", Required: true, Schema: openAPISchema{Type: "string", Pattern: "^[0-9]+$"}},
+	}, op.Parameters)
+
+	_, ok = spec.Paths["/loadpoints/{id}/target/plan"][http.MethodGet]
+	assert.False(t, ok, "methods must be lower case")
+	_, ok = spec.Paths["/loadpoints/{id}/target/plan"]["get"]
+	assert.True(t, ok)
+}
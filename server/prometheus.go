@@ -0,0 +1,101 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prometheus is a prometheus publisher using the versioned measurement schema
+type Prometheus struct {
+	gauges   map[string]*prometheus.GaugeVec
+	vehicles map[string]string // active vehicle by loadpoint
+}
+
+// NewPrometheus creates new publisher for prometheus and registers the schema metrics
+func NewPrometheus(reg prometheus.Registerer) (*Prometheus, error) {
+	p := &Prometheus{
+		gauges:   make(map[string]*prometheus.GaugeVec),
+		vehicles: make(map[string]string),
+	}
+
+	for _, m := range schema.Measurements {
+		help := m.Help
+		if m.Unit != "" {
+			help = fmt.Sprintf("%s (%s)", m.Help, m.Unit)
+		}
+
+		g := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        m.Metric,
+			Help:        help,
+			ConstLabels: prometheus.Labels{"schema": fmt.Sprint(SchemaVersion)},
+		}, m.Labels)
+
+		if err := reg.Register(g); err != nil {
+			return nil, err
+		}
+
+		p.gauges[m.Name] = g
+	}
+
+	return p, nil
+}
+
+// update sets the param's gauge values, lp is nil for site params
+func (p *Prometheus) update(lp loadpoint.API, param util.Param) {
+	sm, ok := schemaMeasurement(param.Key)
+	if !ok || sm.Loadpoint != (lp != nil) {
+		return
+	}
+
+	g := p.gauges[sm.Name]
+	labels := make(prometheus.Labels)
+
+	if lp != nil {
+		title := lp.Title()
+
+		var vehicle string
+		if v := lp.GetVehicle(); v != nil {
+			vehicle = v.Title()
+		}
+
+		// remove series of previous vehicle
+		if prev, ok := p.vehicles[title]; ok && prev != vehicle {
+			for _, g := range p.gauges {
+				g.DeletePartialMatch(prometheus.Labels{labelLoadpoint: title, labelVehicle: prev})
+			}
+		}
+		p.vehicles[title] = vehicle
+
+		labels[labelLoadpoint] = title
+		labels[labelVehicle] = vehicle
+	}
+
+	for _, v := range schemaValues(sm, param.Val) {
+		if v.phase != "" {
+			labels[labelPhase] = v.phase
+		}
+
+		g.With(labels).Set(v.value)
+	}
+}
+
+// Run Prometheus publisher
+func (p *Prometheus) Run(site site.API, in <-chan util.Param) {
+	for param := range in {
+		// aux load values are not exported
+		if param.AuxLoad != nil {
+			continue
+		}
+
+		var lp loadpoint.API
+		if param.Loadpoint != nil {
+			lp = site.Loadpoints()[*param.Loadpoint]
+		}
+
+		p.update(lp, param)
+	}
+}
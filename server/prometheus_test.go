@@ -0,0 +1,44 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheus(t *testing.T) {
+	p, err := NewPrometheus(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	p.update(nil, util.Param{Key: "gridPower", Val: 1000.0})
+	assert.Equal(t, 1000.0, testutil.ToFloat64(p.gauges["gridPower"]))
+
+	p.update(nil, util.Param{Key: "gridCurrents", Val: []float64{1, 2, 3}})
+	assert.Equal(t, 2.0, testutil.ToFloat64(p.gauges["gridCurrents"].WithLabelValues("2")))
+
+	ctrl := gomock.NewController(t)
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("car").AnyTimes()
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().Title().Return("garage").AnyTimes()
+	lp.EXPECT().GetVehicle().Return(vehicle)
+
+	p.update(lp, util.Param{Key: "vehicleSoc", Val: 50.0})
+	assert.Equal(t, 50.0, testutil.ToFloat64(p.gauges["vehicleSoc"].WithLabelValues("garage", "car")))
+
+	// series of previous vehicle is removed
+	lp.EXPECT().GetVehicle().Return(nil)
+
+	p.update(lp, util.Param{Key: "chargePower", Val: 0.0})
+	assert.Equal(t, 0, testutil.CollectAndCount(p.gauges["vehicleSoc"]))
+	assert.Equal(t, 1, testutil.CollectAndCount(p.gauges["chargePower"]))
+}
@@ -0,0 +1,137 @@
+package server
+
+import "strconv"
+
+// SchemaVersion is the version of the measurement schema published to influx and prometheus.
+// Measurement names, units and labels are stable within a schema version.
+const SchemaVersion = 1
+
+// measurement labels
+const (
+	labelLoadpoint = "loadpoint"
+	labelVehicle   = "vehicle"
+	labelPhase     = "phase"
+)
+
+// SchemaMeasurement describes a single measurement of the schema
+type SchemaMeasurement struct {
+	Name      string   `json:"name"`      // influx measurement name
+	Metric    string   `json:"metric"`    // prometheus metric name
+	Unit      string   `json:"unit"`      // measurement unit
+	Help      string   `json:"help"`      // description
+	Loadpoint bool     `json:"loadpoint"` // loadpoint measurement
+	Phases    bool     `json:"phases"`    // per-phase measurement
+	Labels    []string `json:"labels"`    // labels (influx tags) in addition to site and configured tags
+}
+
+// Schema is the versioned measurement schema
+type Schema struct {
+	Version      int                 `json:"version"`
+	Measurements []SchemaMeasurement `json:"measurements"`
+}
+
+var schema = func() Schema {
+	res := Schema{
+		Version: SchemaVersion,
+		Measurements: []SchemaMeasurement{
+			// site
+			{Name: "gridPower", Metric: "evcc_grid_power_watts", Unit: "W", Help: "Grid power, positive for import"},
+			{Name: "gridPowers", Metric: "evcc_grid_phase_power_watts", Unit: "W", Help: "Grid power per phase", Phases: true},
+			{Name: "gridCurrents", Metric: "evcc_grid_phase_current_amperes", Unit: "A", Help: "Grid current per phase", Phases: true},
+			{Name: "gridEnergy", Metric: "evcc_grid_energy_kwh", Unit: "kWh", Help: "Grid import meter reading"},
+			{Name: "gridFrequency", Metric: "evcc_grid_frequency_hertz", Unit: "Hz", Help: "Grid frequency"},
+			{Name: "pvPower", Metric: "evcc_pv_power_watts", Unit: "W", Help: "PV production"},
+			{Name: "batteryPower", Metric: "evcc_battery_power_watts", Unit: "W", Help: "Battery power, positive for discharge"},
+			{Name: "batterySoc", Metric: "evcc_battery_soc_percent", Unit: "%", Help: "Battery state of charge"},
+			{Name: "homePower", Metric: "evcc_home_power_watts", Unit: "W", Help: "Household consumption excluding loadpoints"},
+			{Name: "auxPower", Metric: "evcc_aux_power_watts", Unit: "W", Help: "Auxiliary meter power"},
+			{Name: "greenShare", Metric: "evcc_green_share_ratio", Unit: "", Help: "Share of self-produced energy"},
+			{Name: "tariffGrid", Metric: "evcc_tariff_grid_price", Unit: "currency/kWh", Help: "Grid price"},
+			{Name: "tariffFeedIn", Metric: "evcc_tariff_feedin_price", Unit: "currency/kWh", Help: "Feed-in price"},
+			{Name: "tariffCo2", Metric: "evcc_tariff_co2_grams_per_kwh", Unit: "g/kWh", Help: "Grid co2 emissions"},
+			{Name: "tariffEffectivePrice", Metric: "evcc_tariff_effective_price", Unit: "currency/kWh", Help: "Effective price taking self-produced energy into account"},
+
+			// loadpoint
+			{Name: "chargePower", Metric: "evcc_loadpoint_charge_power_watts", Unit: "W", Help: "Charge power", Loadpoint: true},
+			{Name: "chargeCurrent", Metric: "evcc_loadpoint_charge_current_amperes", Unit: "A", Help: "Charge current limit", Loadpoint: true},
+			{Name: "chargeCurrents", Metric: "evcc_loadpoint_phase_current_amperes", Unit: "A", Help: "Charge current per phase", Loadpoint: true, Phases: true},
+			{Name: "chargeVoltages", Metric: "evcc_loadpoint_phase_voltage_volts", Unit: "V", Help: "Charge voltage per phase", Loadpoint: true, Phases: true},
+			{Name: "chargedEnergy", Metric: "evcc_loadpoint_session_energy_wh", Unit: "Wh", Help: "Energy charged in current session", Loadpoint: true},
+			{Name: "chargeTotalImport", Metric: "evcc_loadpoint_energy_kwh", Unit: "kWh", Help: "Charge meter reading", Loadpoint: true},
+			{Name: "phasesActive", Metric: "evcc_loadpoint_phases_active", Unit: "", Help: "Phases used for charging", Loadpoint: true},
+			{Name: "chargeGreenShare", Metric: "evcc_loadpoint_green_share_ratio", Unit: "", Help: "Share of self-produced energy in charge power", Loadpoint: true},
+			{Name: "chargePrice", Metric: "evcc_loadpoint_charge_price", Unit: "currency/kWh", Help: "Effective price of charged energy", Loadpoint: true},
+			{Name: "chargeCo2", Metric: "evcc_loadpoint_charge_co2_grams_per_kwh", Unit: "g/kWh", Help: "Effective co2 emissions of charged energy", Loadpoint: true},
+			{Name: "vehicleSoc", Metric: "evcc_vehicle_soc_percent", Unit: "%", Help: "Vehicle state of charge", Loadpoint: true},
+			{Name: "vehicleRange", Metric: "evcc_vehicle_range_km", Unit: "km", Help: "Vehicle range", Loadpoint: true},
+			{Name: "vehicleOdometer", Metric: "evcc_vehicle_odometer_km", Unit: "km", Help: "Vehicle odometer", Loadpoint: true},
+		},
+	}
+
+	for i, m := range res.Measurements {
+		if m.Loadpoint {
+			res.Measurements[i].Labels = append(res.Measurements[i].Labels, labelLoadpoint, labelVehicle)
+		}
+		if m.Phases {
+			res.Measurements[i].Labels = append(res.Measurements[i].Labels, labelPhase)
+		}
+	}
+
+	return res
+}()
+
+// schemaMeasurement returns the schema measurement for the given key
+func schemaMeasurement(key string) (SchemaMeasurement, bool) {
+	for _, m := range schema.Measurements {
+		if m.Name == key {
+			return m, true
+		}
+	}
+	return SchemaMeasurement{}, false
+}
+
+// schemaValue is a measurement value with its phase label, empty for non-phase measurements
+type schemaValue struct {
+	phase string
+	value float64
+}
+
+// schemaValues converts the param value to the measurement's float values
+func schemaValues(m SchemaMeasurement, val any) []schemaValue {
+	var values []float64
+
+	switch v := val.(type) {
+	case int:
+		values = []float64{float64(v)}
+	case int64:
+		values = []float64{float64(v)}
+	case float64:
+		values = []float64{v}
+	case *float64:
+		if v == nil {
+			return nil
+		}
+		values = []float64{*v}
+	case []float64:
+		values = v
+	case [3]float64:
+		values = v[:]
+	default:
+		return nil
+	}
+
+	if m.Phases != (len(values) == 3) {
+		return nil
+	}
+
+	if !m.Phases {
+		return []schemaValue{{value: values[0]}}
+	}
+
+	res := make([]schemaValue, 0, len(values))
+	for i, v := range values {
+		res = append(res, schemaValue{phase: strconv.Itoa(i + 1), value: v})
+	}
+
+	return res
+}
@@ -9,20 +9,33 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/util"
+	"golang.org/x/exp/slices"
 	"nhooyr.io/websocket"
 )
 
 const (
 	// Time allowed to write a message to the peer
 	socketWriteTimeout = 10 * time.Second
+
+	// Interval for pinging the peer. Idle connections receive an empty message for the client to detect stale connections.
+	socketKeepalive = 30 * time.Second
 )
 
+// socketEvents are sent even if unchanged
+var socketEvents = []string{"warn", "error"}
+
 // socketSubscriber is a middleman between the websocket connection and the hub.
 type socketSubscriber struct {
 	send      chan []byte
 	closeSlow func()
 }
 
+func ping(ctx context.Context, timeout time.Duration, c *websocket.Conn) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.Ping(ctx)
+}
+
 func writeTimeout(ctx context.Context, timeout time.Duration, c *websocket.Conn, msg []byte) error {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -35,6 +48,7 @@ type SocketHub struct {
 	mu          sync.RWMutex
 	register    chan *socketSubscriber
 	subscribers map[*socketSubscriber]struct{}
+	sent        map[string]string // last broadcasted value per key
 }
 
 // NewSocketHub creates a web socket hub that distributes meter status and
@@ -43,6 +57,7 @@ func NewSocketHub() *SocketHub {
 	return &SocketHub{
 		register:    make(chan *socketSubscriber, 1),
 		subscribers: make(map[*socketSubscriber]struct{}),
+		sent:        make(map[string]string),
 	}
 }
 
@@ -95,12 +110,31 @@ func (h *SocketHub) subscribe(ctx context.Context, conn *websocket.Conn) error {
 	// send welcome message
 	h.register <- s
 
+	keepalive := time.NewTicker(socketKeepalive)
+	defer keepalive.Stop()
+
+	var idle bool
+
 	for {
 		select {
 		case msg := <-s.send:
 			if err := writeTimeout(ctx, socketWriteTimeout, conn, msg); err != nil {
 				return err
 			}
+			idle = false
+
+		case <-keepalive.C:
+			if err := ping(ctx, socketWriteTimeout, conn); err != nil {
+				return err
+			}
+
+			if idle {
+				if err := writeTimeout(ctx, socketWriteTimeout, conn, []byte("{}")); err != nil {
+					return err
+				}
+			}
+			idle = true
+
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -136,12 +170,33 @@ func (h *SocketHub) welcome(subscriber *socketSubscriber, params []util.Param) {
 	subscriber.send <- []byte(msg.String())
 }
 
+// changed returns true if the encoded key/value differs from the last broadcast
+func (h *SocketHub) changed(p util.Param, msg string) bool {
+	if slices.Contains(socketEvents, p.Key) {
+		return true
+	}
+
+	key := p.UniqueID()
+	if h.sent[key] == msg {
+		return false
+	}
+
+	h.sent[key] = msg
+	return true
+}
+
+// broadcast sends the changed value to all subscribers. Unchanged values are not sent
+// since subscribers receive the complete state when connecting.
 func (h *SocketHub) broadcast(p util.Param) {
+	msg := "{" + kv(p) + "}"
+	if !h.changed(p, msg) {
+		return
+	}
+
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
 	if len(h.subscribers) > 0 {
-		msg := "{" + kv(p) + "}"
 
 		for s := range h.subscribers {
 			select {
@@ -55,6 +55,9 @@ func kv(p util.Param) string {
 	if p.Loadpoint != nil {
 		msg.WriteString(fmt.Sprintf("loadpoints.%d.", *p.Loadpoint))
 	}
+	if p.AuxLoad != nil {
+		msg.WriteString(fmt.Sprintf("auxLoads.%d.", *p.AuxLoad))
+	}
 	msg.WriteString(p.Key)
 	msg.WriteString("\":")
 	msg.WriteString(val)
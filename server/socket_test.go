@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,3 +32,23 @@ func TestEncode(t *testing.T) {
 		assert.Equal(t, tc.out, out)
 	}
 }
+
+func TestBroadcastDelta(t *testing.T) {
+	h := NewSocketHub()
+	s := &socketSubscriber{send: make(chan []byte, 10)}
+	h.addSubscriber(s)
+
+	lp := 0
+	h.broadcast(util.Param{Key: "gridPower", Val: 100.0})
+	h.broadcast(util.Param{Key: "gridPower", Val: 100.0})
+	h.broadcast(util.Param{Loadpoint: &lp, Key: "gridPower", Val: 100.0})
+	assert.Len(t, s.send, 2)
+
+	h.broadcast(util.Param{Key: "gridPower", Val: 200.0})
+	assert.Len(t, s.send, 3)
+
+	// events are always sent
+	h.broadcast(util.Param{Key: "warn", Val: "foo"})
+	h.broadcast(util.Param{Key: "warn", Val: "foo"})
+	assert.Len(t, s.send, 5)
+}
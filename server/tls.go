@@ -0,0 +1,103 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// selfSignedValidity is the validity of generated certificates
+const selfSignedValidity = 10 * 365 * 24 * time.Hour
+
+// SelfSignedCertificate creates a self-signed certificate and private key for the given hosts.
+// Existing files are kept, i.e. the certificate is only generated on first start.
+func SelfSignedCertificate(certFile, keyFile string, hosts []string) error {
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+
+	if certErr == nil && keyErr == nil {
+		return nil
+	}
+
+	if !errors.Is(certErr, fs.ErrNotExist) && certErr != nil {
+		return certErr
+	}
+	if !errors.Is(keyErr, fs.ErrNotExist) && keyErr != nil {
+		return keyErr
+	}
+
+	log.INFO.Println("creating self-signed certificate:", certFile)
+
+	cert, key, err := createSelfSigned(hosts, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.MkdirAll(filepath.Dir(f), 0o700); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(keyFile, key, 0o600); err != nil {
+		return err
+	}
+
+	return os.WriteFile(certFile, cert, 0o644)
+}
+
+// createSelfSigned creates pem encoded certificate and private key
+func createSelfSigned(hosts []string, now time.Time) ([]byte, []byte, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"evcc"}, CommonName: hosts[0]},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+
+	return cert, key, nil
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfSignedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls", "cert.pem")
+	keyFile := filepath.Join(dir, "tls", "key.pem")
+
+	require.NoError(t, SelfSignedCertificate(certFile, keyFile, []string{"evcc.local", "127.0.0.1"}))
+
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evcc.local"}, cert.DNSNames)
+	assert.Len(t, cert.IPAddresses, 1)
+	assert.NoError(t, cert.VerifyHostname("evcc.local"))
+
+	// existing certificate is kept
+	before, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	require.NoError(t, SelfSignedCertificate(certFile, keyFile, []string{"evcc.local"}))
+	after, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+}
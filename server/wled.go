@@ -0,0 +1,216 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// WLEDConfig is the WLED led output configuration
+type WLEDConfig struct {
+	URI        string
+	Loadpoint  int // loadpoint visualized, default first
+	Brightness int // 0..255
+	Colors     struct {
+		Grid, Solar, Idle string // hex colors
+	}
+}
+
+type rgb [3]int
+
+// WLED visualizes solar share and charging state on WLED controlled leds
+type WLED struct {
+	*request.Helper
+	log        *util.Logger
+	uri        string
+	loadpoint  int
+	brightness int
+	grid       rgb
+	solar      rgb
+	idle       rgb
+	interval   time.Duration // minimum time between updates
+	current    *rgb
+}
+
+// wledInterval limits the led update rate
+const wledInterval = time.Second
+
+// NewWLED creates WLED led output
+func NewWLED(conf WLEDConfig) (*WLED, error) {
+	if conf.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	if conf.Loadpoint == 0 {
+		conf.Loadpoint = 1
+	}
+	if conf.Brightness == 0 {
+		conf.Brightness = 128
+	}
+
+	log := util.NewLogger("wled")
+
+	m := &WLED{
+		Helper:     request.NewHelper(log),
+		log:        log,
+		uri:        util.DefaultScheme(strings.TrimRight(conf.URI, "/"), "http"),
+		loadpoint:  conf.Loadpoint - 1,
+		brightness: conf.Brightness,
+		interval:   wledInterval,
+	}
+
+	for _, c := range []struct {
+		val string
+		def string
+		res *rgb
+	}{
+		{conf.Colors.Grid, "#ff0000", &m.grid},
+		{conf.Colors.Solar, "#00ff00", &m.solar},
+		{conf.Colors.Idle, "#000000", &m.idle},
+	} {
+		if c.val == "" {
+			c.val = c.def
+		}
+
+		col, err := parseColor(c.val)
+		if err != nil {
+			return nil, err
+		}
+
+		*c.res = col
+	}
+
+	return m, nil
+}
+
+// parseColor parses #rrggbb hex colors
+func parseColor(s string) (rgb, error) {
+	var res rgb
+
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return res, fmt.Errorf("invalid color: %s", s)
+	}
+
+	for i := range res {
+		v, err := strconv.ParseUint(hex[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return res, fmt.Errorf("invalid color: %s", s)
+		}
+		res[i] = int(v)
+	}
+
+	return res, nil
+}
+
+// blend mixes grid and solar color according to solar share
+func blend(grid, solar rgb, share float64) rgb {
+	share = math.Max(0, math.Min(1, share))
+
+	var res rgb
+	for i := range res {
+		res[i] = int(math.Round(float64(grid[i])*(1-share) + float64(solar[i])*share))
+	}
+
+	return res
+}
+
+// color returns the led color for given solar share and charging state
+func (m *WLED) color(greenShare float64, charging bool) rgb {
+	if !charging {
+		return m.idle
+	}
+	return blend(m.grid, m.solar, greenShare)
+}
+
+func (m *WLED) update(col rgb) error {
+	data := map[string]any{
+		"on":  col != rgb{},
+		"bri": m.brightness,
+		"seg": []map[string]any{
+			{"col": [][3]int{col}},
+		},
+	}
+
+	req, err := request.New(http.MethodPost, m.uri+"/json/state", request.MarshalJSON(data), request.JSONEncoding)
+	if err == nil {
+		_, err = m.DoBody(req)
+	}
+
+	return err
+}
+
+// Run starts the WLED output.
+// Leds are updated asynchronously to not block value distribution when WLED is slow or unreachable.
+func (m *WLED) Run(in <-chan util.Param) {
+	var (
+		greenShare float64
+		charging   bool
+	)
+
+	latest := make(chan rgb, 1)
+	defer close(latest)
+
+	go m.send(latest)
+
+	for p := range in {
+		switch {
+		case p.Loadpoint == nil && p.Key == "greenShare":
+			greenShare, _ = p.Val.(float64)
+		case p.Loadpoint != nil && *p.Loadpoint == m.loadpoint && p.Key == "charging":
+			charging, _ = p.Val.(bool)
+		default:
+			continue
+		}
+
+		// replace pending color
+		select {
+		case <-latest:
+		default:
+		}
+
+		latest <- m.color(greenShare, charging)
+	}
+}
+
+// send updates the leds with the latest color, skipping unchanged colors and limiting the update rate
+func (m *WLED) send(latest <-chan rgb) {
+	var updated time.Time
+
+	for col := range latest {
+		if d := m.interval - time.Since(updated); d > 0 {
+			time.Sleep(d)
+
+			// use newer color if available
+			select {
+			case c, ok := <-latest:
+				if !ok {
+					return
+				}
+				col = c
+			default:
+			}
+		}
+
+		if m.current != nil && *m.current == col {
+			continue
+		}
+
+		updated = time.Now()
+
+		if err := m.update(col); err != nil {
+			m.log.ERROR.Println(err)
+			continue
+		}
+
+		current := col
+		m.current = &current
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWLEDColor(t *testing.T) {
+	col, err := parseColor("#ff8000")
+	require.NoError(t, err)
+	assert.Equal(t, rgb{255, 128, 0}, col)
+
+	_, err = parseColor("red")
+	assert.Error(t, err)
+
+	m, err := NewWLED(WLEDConfig{URI: "wled.local"})
+	require.NoError(t, err)
+
+	assert.Equal(t, rgb{}, m.color(1, false))
+	assert.Equal(t, rgb{255, 0, 0}, m.color(0, true))
+	assert.Equal(t, rgb{0, 255, 0}, m.color(1, true))
+	assert.Equal(t, rgb{128, 128, 0}, m.color(0.5, true))
+	assert.Equal(t, rgb{0, 255, 0}, m.color(1.5, true))
+}
+
+func TestWLEDRun(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+
+	entered := make(chan struct{}, 10)
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release // slow WLED
+
+		b, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, string(b))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	m, err := NewWLED(WLEDConfig{URI: srv.URL})
+	require.NoError(t, err)
+	m.interval = 0
+
+	in := make(chan util.Param)
+	done := make(chan struct{})
+
+	go func() {
+		m.Run(in)
+		close(done)
+	}()
+
+	lp := 0
+
+	in <- util.Param{Loadpoint: &lp, Key: "charging", Val: true}
+	<-entered
+
+	// value distribution is not blocked by pending update
+	for i := 1; i <= 10; i++ {
+		select {
+		case in <- util.Param{Loadpoint: &lp, Key: "charging", Val: i%2 == 1}:
+		case <-time.After(time.Second):
+			t.Fatal("blocked")
+		}
+	}
+
+	close(release)
+
+	// only latest color is sent after pending update completes
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// unchanged color is skipped
+	in <- util.Param{Loadpoint: &lp, Key: "charging", Val: false}
+	close(in)
+	<-done
+
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 2)
+	assert.Contains(t, requests[0], `"on":true`)
+	assert.Contains(t, requests[1], `"on":false`)
+}
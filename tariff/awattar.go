@@ -64,9 +64,13 @@ func (t *Awattar) run(done chan error) {
 	var once sync.Once
 	client := request.NewHelper(t.log)
 
-	for ; true; <-time.Tick(time.Hour) {
+	for ; true; waitUpdate(time.Hour) {
+		// request full horizon including tomorrow's prices once published
+		start := time.Now().Truncate(time.Hour)
+		uri := fmt.Sprintf("%s?start=%d&end=%d", t.uri, start.UnixMilli(), start.Add(48*time.Hour).UnixMilli())
+
 		var res awattar.Prices
-		if err := client.GetJSON(t.uri, &res); err != nil {
+		if err := client.GetJSON(uri, &res); err != nil {
 			once.Do(func() { done <- err })
 
 			t.log.ERROR.Println(err)
@@ -13,6 +13,9 @@ func (r tariffRegistry) Add(name string, factory func(map[string]interface{}) (a
 	if _, exists := r[name]; exists {
 		panic(fmt.Sprintf("cannot register duplicate tariff type: %s", name))
 	}
+	if name != strings.ToLower(name) {
+		panic(fmt.Sprintf("cannot register tariff type with upper case characters: %s", name))
+	}
 	r[name] = factory
 }
 
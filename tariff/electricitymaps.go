@@ -80,7 +80,7 @@ func (t *ElectricityMaps) run(done chan error) {
 	var once sync.Once
 	uri := fmt.Sprintf("%s/carbon-intensity/forecast?zone=%s", t.uri, t.zone)
 
-	for ; true; <-time.Tick(time.Hour) {
+	for ; true; waitUpdate(time.Hour) {
 		var res CarbonIntensity
 		if err := t.GetJSON(uri, &res); err != nil {
 			if res.Error != "" {
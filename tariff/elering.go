@@ -64,7 +64,7 @@ func (t *Elering) run(done chan error) {
 	var once sync.Once
 	client := request.NewHelper(t.log)
 
-	for ; true; <-time.Tick(time.Hour) {
+	for ; true; waitUpdate(time.Hour) {
 		var res elering.NpsPrice
 
 		ts := time.Now().Truncate(time.Hour)
@@ -99,7 +99,8 @@ func (t *Fixed) Rates() (api.Rates, error) {
 		markers := zones.TimeTableMarkers()
 
 		for i, m := range markers {
-			ts := dayStart.AddMinutes(m.Minutes())
+			// use wall clock time to account for DST transitions
+			ts := dayStart.SetTime(m.Hour, m.Min, 0)
 
 			var zone *fixed.Zone
 			for j := len(zones) - 1; j >= 0; j-- {
@@ -116,7 +117,7 @@ func (t *Fixed) Rates() (api.Rates, error) {
 			// end rate at end of day or next marker
 			end := dayStart.AddDay()
 			if i+1 < len(markers) {
-				end = dayStart.AddMinutes(markers[i+1].Minutes())
+				end = dayStart.SetTime(markers[i+1].Hour, markers[i+1].Min, 0)
 			}
 
 			rate := api.Rate{
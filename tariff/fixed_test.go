@@ -2,6 +2,8 @@ package tariff
 
 import (
 	"testing"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
@@ -101,3 +103,52 @@ func TestFixedSplitZones(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expect, rates)
 }
+
+func TestFixedDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	local := time.Local
+	time.Local = loc
+	defer func() { time.Local = local }()
+
+	at, err := NewFixedFromConfig(map[string]interface{}{
+		"price": 0.5,
+		"zones": []struct {
+			Price float64
+			Hours string
+		}{
+			{0.1, "3-5"},
+		},
+	})
+	assert.NoError(t, err)
+
+	// spring forward: day has 23 hours
+	clck := clock.NewMock()
+	clck.Set(time.Date(2023, 3, 26, 12, 0, 0, 0, loc))
+
+	tf := at.(*Fixed)
+	tf.clock = clck
+
+	rates, err := tf.Rates()
+	assert.NoError(t, err)
+
+	var day api.Rates
+	for _, r := range rates {
+		if r.Start.Day() == 26 {
+			day = append(day, r)
+		}
+	}
+
+	var total time.Duration
+	for _, r := range day {
+		total += r.End.Sub(r.Start)
+
+		// cheap zone must start and end at wall clock hours
+		if r.Price == 0.1 {
+			assert.True(t, r.Start.Hour() >= 3 && r.End.Hour() <= 5, "%v-%v", r.Start, r.End)
+		}
+	}
+
+	assert.Equal(t, 23*time.Hour, total)
+}
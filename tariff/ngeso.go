@@ -0,0 +1,116 @@
+package tariff
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/ngeso"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"golang.org/x/exp/slices"
+)
+
+// NgEso provides the UK grid carbon intensity forecast by National Grid ESO
+type NgEso struct {
+	*request.Helper
+	log      *util.Logger
+	mux      sync.Mutex
+	postcode string
+	data     api.Rates
+	updated  time.Time
+}
+
+var _ api.Tariff = (*NgEso)(nil)
+
+func init() {
+	registry.Add("ngeso", NewNgEsoFromConfig)
+}
+
+func NewNgEsoFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		Postcode string // outward code like RG41, national forecast if empty
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	log := util.NewLogger("ngeso")
+
+	t := &NgEso{
+		log:      log,
+		Helper:   request.NewHelper(log),
+		postcode: cc.Postcode,
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *NgEso) forecast() ([]ngeso.Interval, error) {
+	from := time.Now().UTC().Truncate(30 * time.Minute).Format(ngeso.TimeFormat)
+
+	if t.postcode == "" {
+		var res ngeso.NationalIntensity
+		uri := fmt.Sprintf("%s/intensity/%s/fw48h", ngeso.URI, from)
+		err := t.GetJSON(uri, &res)
+		return res.Data, err
+	}
+
+	var res ngeso.RegionalIntensity
+	uri := fmt.Sprintf("%s/regional/intensity/%s/fw48h/postcode/%s", ngeso.URI, from, url.PathEscape(t.postcode))
+	err := t.GetJSON(uri, &res)
+	return res.Data.Data, err
+}
+
+func (t *NgEso) run(done chan error) {
+	var once sync.Once
+
+	for ; true; waitUpdate(time.Hour) {
+		res, err := t.forecast()
+		if err == nil && len(res) == 0 {
+			err = api.ErrNotAvailable
+		}
+
+		if err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		once.Do(func() { close(done) })
+
+		t.mux.Lock()
+		t.updated = time.Now()
+
+		t.data = make(api.Rates, 0, len(res))
+		for _, r := range res {
+			t.data = append(t.data, api.Rate{
+				Price: r.Intensity.Forecast,
+				Start: r.From.Local(),
+				End:   r.To.Local(),
+			})
+		}
+
+		t.mux.Unlock()
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *NgEso) Rates() (api.Rates, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return slices.Clone(t.data), outdatedError(t.updated, time.Hour)
+}
+
+// Type returns the tariff type
+func (t *NgEso) Type() api.TariffType {
+	return api.TariffTypeCo2
+}
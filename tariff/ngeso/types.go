@@ -0,0 +1,58 @@
+package ngeso
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// URI is the National Grid ESO carbon intensity api
+	URI = "https://api.carbonintensity.org.uk"
+
+	// TimeFormat is the api's ISO8601 minute format
+	TimeFormat = "2006-01-02T15:04Z"
+)
+
+// NationalIntensity is the national forecast response
+type NationalIntensity struct {
+	Data []Interval
+}
+
+// RegionalIntensity is the postcode or region forecast response
+type RegionalIntensity struct {
+	Data struct {
+		RegionID  int
+		ShortName string
+		Postcode  string
+		Data      []Interval
+	}
+}
+
+// Interval is a half-hourly intensity forecast
+type Interval struct {
+	From, To  Time
+	Intensity struct {
+		Forecast float64 // gCO2/kWh
+		Actual   float64
+		Index    string
+	}
+}
+
+// Time parses the api time format
+type Time struct {
+	time.Time
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	ts, err := time.Parse(TimeFormat, s)
+	if err == nil {
+		t.Time = ts
+	}
+
+	return err
+}
@@ -0,0 +1,23 @@
+package ngeso
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshal(t *testing.T) {
+	var res RegionalIntensity
+	err := json.Unmarshal([]byte(`{"data":{"regionid":12,"shortname":"South England","postcode":"RG10","data":[
+		{"from":"2023-05-15T11:30Z","to":"2023-05-15T12:00Z","intensity":{"forecast":143,"index":"moderate"}}
+	]}}`), &res)
+	require.NoError(t, err)
+
+	require.Len(t, res.Data.Data, 1)
+	r := res.Data.Data[0]
+	require.Equal(t, time.Date(2023, 5, 15, 11, 30, 0, 0, time.UTC), r.From.Time)
+	require.Equal(t, time.Date(2023, 5, 15, 12, 0, 0, 0, time.UTC), r.To.Time)
+	require.Equal(t, 143.0, r.Intensity.Forecast)
+}
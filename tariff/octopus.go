@@ -61,7 +61,7 @@ func (t *Octopus) run(done chan error) {
 	var once sync.Once
 	client := request.NewHelper(t.log)
 
-	for ; true; <-time.Tick(time.Hour) {
+	for ; true; waitUpdate(time.Hour) {
 		var res octopus.UnitRates
 		if err := client.GetJSON(t.uri, &res); err != nil {
 			once.Do(func() { done <- err })
@@ -1,6 +1,7 @@
 package tariff
 
 import (
+	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -58,3 +59,29 @@ func outdatedError(t time.Time, d time.Duration) error {
 	}
 	return nil
 }
+
+var (
+	refreshMu sync.Mutex
+	refreshC  = make(chan struct{})
+)
+
+// Refresh makes all tariffs update their rates immediately, e.g. after the system clock has changed
+func Refresh() {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	close(refreshC)
+	refreshC = make(chan struct{})
+}
+
+// waitUpdate blocks until the next rates update is due after d or a refresh is requested
+func waitUpdate(d time.Duration) {
+	refreshMu.Lock()
+	c := refreshC
+	refreshMu.Unlock()
+
+	select {
+	case <-time.After(d):
+	case <-c:
+	}
+}
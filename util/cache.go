@@ -3,12 +3,14 @@ package util
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Cache is a data store
 type Cache struct {
 	sync.Mutex
-	val map[string]Param
+	val     map[string]Param
+	updated map[string]time.Time
 }
 
 // flush is the value type used as parameter for flushing the cache.
@@ -24,7 +26,8 @@ func Flusher() flush {
 // NewCache creates cache
 func NewCache() *Cache {
 	return &Cache{
-		val: make(map[string]Param),
+		val:     make(map[string]Param),
+		updated: make(map[string]time.Time),
 	}
 }
 
@@ -42,6 +45,9 @@ func (c *Cache) Run(in <-chan Param) {
 		if p.Loadpoint != nil {
 			key = fmt.Sprintf("lp-%d/%s", *p.Loadpoint+1, key)
 		}
+		if p.AuxLoad != nil {
+			key = fmt.Sprintf("aux-%d/%s", *p.AuxLoad+1, key)
+		}
 
 		log.TRACE.Printf("%s: %v", key, p.Val)
 		c.Add(p.UniqueID(), p)
@@ -49,24 +55,47 @@ func (c *Cache) Run(in <-chan Param) {
 }
 
 // State provides a structured copy of the cached values
-// Loadpoints are aggregated as loadpoints array
+// Loadpoints and auxiliary loads are aggregated as loadpoints and auxLoads arrays
 func (c *Cache) State() map[string]interface{} {
+	return c.state(func(_ string, param Param) interface{} {
+		return param.Val
+	})
+}
+
+// Updated provides a structured copy of the cached values' update timestamps
+// Loadpoints and auxiliary loads are aggregated as loadpoints and auxLoads arrays
+func (c *Cache) Updated() map[string]interface{} {
+	return c.state(func(key string, _ Param) interface{} {
+		return c.updated[key]
+	})
+}
+
+func (c *Cache) state(value func(string, Param) interface{}) map[string]interface{} {
 	c.Lock()
 	defer c.Unlock()
 
 	res := map[string]interface{}{}
 	lps := make(map[int]map[string]interface{})
+	aux := make(map[int]map[string]interface{})
 
-	for _, param := range c.val {
-		if param.Loadpoint == nil {
-			res[param.Key] = param.Val
-		} else {
+	for key, param := range c.val {
+		switch {
+		case param.Loadpoint != nil:
 			lp, ok := lps[*param.Loadpoint]
 			if !ok {
 				lp = make(map[string]interface{})
 				lps[*param.Loadpoint] = lp
 			}
-			lp[param.Key] = param.Val
+			lp[param.Key] = value(key, param)
+		case param.AuxLoad != nil:
+			a, ok := aux[*param.AuxLoad]
+			if !ok {
+				a = make(map[string]interface{})
+				aux[*param.AuxLoad] = a
+			}
+			a[param.Key] = value(key, param)
+		default:
+			res[param.Key] = value(key, param)
 		}
 	}
 
@@ -77,6 +106,14 @@ func (c *Cache) State() map[string]interface{} {
 	}
 	res["loadpoints"] = loadpoints
 
+	if len(aux) > 0 {
+		auxLoads := make([]map[string]interface{}, len(aux))
+		for id, a := range aux {
+			auxLoads[id] = a
+		}
+		res["auxLoads"] = auxLoads
+	}
+
 	return res
 }
 
@@ -99,6 +136,7 @@ func (c *Cache) Add(key string, param Param) {
 	defer c.Unlock()
 
 	c.val[key] = param
+	c.updated[key] = time.Now()
 }
 
 // Get entry from cache
@@ -2,6 +2,9 @@ package util
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCache(t *testing.T) {
@@ -9,3 +12,31 @@ func TestCache(t *testing.T) {
 
 	c.Add("foo", Param{})
 }
+
+func TestCacheUpdated(t *testing.T) {
+	c := NewCache()
+
+	lp := 0
+	for _, p := range []Param{{Key: "foo", Val: 1}, {Loadpoint: &lp, Key: "bar", Val: 2}} {
+		c.Add(p.UniqueID(), p)
+	}
+
+	res := c.Updated()
+	assert.WithinDuration(t, time.Now(), res["foo"].(time.Time), time.Second)
+
+	lps := res["loadpoints"].([]map[string]interface{})
+	assert.WithinDuration(t, time.Now(), lps[0]["bar"].(time.Time), time.Second)
+}
+
+func TestCacheAuxLoads(t *testing.T) {
+	c := NewCache()
+
+	lp, aux := 0, 0
+	for _, p := range []Param{{Loadpoint: &lp, Key: "mode", Val: "pv"}, {AuxLoad: &aux, Key: "mode", Val: "now"}} {
+		c.Add(p.UniqueID(), p)
+	}
+
+	res := c.State()
+	assert.Equal(t, "pv", res["loadpoints"].([]map[string]interface{})[0]["mode"])
+	assert.Equal(t, "now", res["auxLoads"].([]map[string]interface{})[0]["mode"])
+}
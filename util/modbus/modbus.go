@@ -1,6 +1,7 @@
 package modbus
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
@@ -227,6 +228,46 @@ func (mb *Connection) ReadFIFOQueue(address uint16) (results []byte, err error)
 	return mb.ReadFIFOQueueWithSlave(mb.slaveID, address)
 }
 
+// ErrWriteVerify indicates that a written register value could not be read back
+var ErrWriteVerify = errors.New("write verification failed")
+
+// WriteSingleRegisterVerified writes a holding register and reads it back to verify the value.
+// The write is repeated up to retries times if the register does not hold the written value.
+func (mb *Connection) WriteSingleRegisterVerified(address, value uint16, retries int) error {
+	return verifyWrite(func() error {
+		_, err := mb.WriteSingleRegister(address, value)
+		return err
+	}, func() (uint16, error) {
+		b, err := mb.ReadHoldingRegisters(address, 1)
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint16(b), nil
+	}, value, retries)
+}
+
+// verifyWrite executes write and read until read returns the expected value
+func verifyWrite(write func() error, read func() (uint16, error), value uint16, retries int) error {
+	var err error
+
+	for i := 0; i <= retries; i++ {
+		if err = write(); err != nil {
+			continue
+		}
+
+		var u uint16
+		if u, err = read(); err == nil && u != value {
+			err = fmt.Errorf("%w: expected %d, got %d", ErrWriteVerify, value, u)
+		}
+
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
 var (
 	connections = make(map[string]meters.Connection)
 	mu          sync.Mutex
@@ -380,6 +421,8 @@ func RegisterOperation(r Register) (rs485.Operation, error) {
 		r.Decode = "bool8"
 	case "writesingle", "writeholding":
 		op.FuncCode = modbus.FuncCodeWriteSingleRegister
+	case "writemultiple":
+		op.FuncCode = modbus.FuncCodeWriteMultipleRegisters
 	case "writecoil":
 		op.FuncCode = modbus.FuncCodeWriteSingleCoil
 		r.Decode = "bool8"
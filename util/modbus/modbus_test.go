@@ -1,6 +1,9 @@
 package modbus
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestParsePoint(t *testing.T) {
 	tc := []struct {
@@ -28,3 +31,31 @@ func TestParsePoint(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyWrite(t *testing.T) {
+	var writes int
+	write := func() error {
+		writes++
+		return nil
+	}
+
+	// register clamps first write
+	reads := []uint16{6, 16}
+	read := func() (uint16, error) {
+		u := reads[0]
+		reads = reads[1:]
+		return u, nil
+	}
+
+	if err := verifyWrite(write, read, 16, 2); err != nil || writes != 2 {
+		t.Errorf("unexpected result: %d writes, %v", writes, err)
+	}
+
+	// retries exhausted
+	writes = 0
+	reads = []uint16{6, 6}
+
+	if err := verifyWrite(write, read, 16, 1); !errors.Is(err, ErrWriteVerify) || writes != 2 {
+		t.Errorf("unexpected result: %d writes, %v", writes, err)
+	}
+}
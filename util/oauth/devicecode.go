@@ -0,0 +1,147 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util/request"
+	"golang.org/x/oauth2"
+)
+
+// DeviceAuth is the device authorization response, see https://www.rfc-editor.org/rfc/rfc8628#section-3.2
+type DeviceAuth struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval,omitempty"`
+}
+
+// deviceTokenError is the device access token error response, see https://www.rfc-editor.org/rfc/rfc8628#section-3.5
+type deviceTokenError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// DeviceCodeFlow implements the OAuth device authorization grant for providers that don't support password login
+type DeviceCodeFlow struct {
+	*request.Helper
+	config   *oauth2.Config
+	authURL  string
+	interval time.Duration // default polling interval
+}
+
+// NewDeviceCodeFlow creates a device authorization grant flow using the given device authorization endpoint
+func NewDeviceCodeFlow(helper *request.Helper, config *oauth2.Config, authURL string) *DeviceCodeFlow {
+	return &DeviceCodeFlow{
+		Helper:   helper,
+		config:   config,
+		authURL:  authURL,
+		interval: 5 * time.Second,
+	}
+}
+
+func (f *DeviceCodeFlow) post(uri string, data url.Values, res any) error {
+	req, err := request.New(http.MethodPost, uri, strings.NewReader(data.Encode()), request.URLEncoding)
+	if err == nil {
+		err = f.DoJSON(req, res)
+	}
+	return err
+}
+
+// DeviceAuth requests device and user codes. The user must visit the verification uri and enter the user code.
+func (f *DeviceCodeFlow) DeviceAuth() (*DeviceAuth, error) {
+	data := url.Values{
+		"client_id": {f.config.ClientID},
+	}
+
+	if len(f.config.Scopes) > 0 {
+		data.Set("scope", strings.Join(f.config.Scopes, " "))
+	}
+
+	var res DeviceAuth
+	if err := f.post(f.authURL, data, &res); err != nil {
+		return nil, err
+	}
+
+	if res.DeviceCode == "" || res.UserCode == "" {
+		return nil, errors.New("missing device or user code")
+	}
+
+	return &res, nil
+}
+
+// Token polls the token endpoint until the user has completed the login, the codes have expired or the context is cancelled
+func (f *DeviceCodeFlow) Token(ctx context.Context, da *DeviceAuth) (*oauth2.Token, error) {
+	data := url.Values{
+		"client_id":   {f.config.ClientID},
+		"device_code": {da.DeviceCode},
+		"grant_type":  {deviceCodeGrantType},
+	}
+
+	if f.config.ClientSecret != "" {
+		data.Set("client_secret", f.config.ClientSecret)
+	}
+
+	interval := f.interval
+	if da.Interval > 0 {
+		interval = time.Duration(da.Interval) * time.Second
+	}
+
+	if da.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(da.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var raw json.RawMessage
+		err := f.post(f.config.Endpoint.TokenURL, data, &raw)
+
+		var res deviceTokenError
+		_ = json.Unmarshal(raw, &res)
+
+		switch res.Error {
+		case "":
+			var token Token
+			if err == nil {
+				err = json.Unmarshal(raw, &token)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if token.AccessToken == "" {
+				return nil, errors.New("missing access token")
+			}
+
+			return (*oauth2.Token)(&token), nil
+
+		case "authorization_pending":
+			continue
+
+		case "slow_down":
+			interval += 5 * time.Second
+
+		default:
+			if res.ErrorDescription != "" {
+				return nil, fmt.Errorf("%s: %s", res.Error, res.ErrorDescription)
+			}
+			return nil, errors.New(res.Error)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestDeviceCodeFlow(t *testing.T) {
+	var polls int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "client", r.FormValue("client_id"))
+		assert.Equal(t, "openid offline_access", r.FormValue("scope"))
+
+		_ = json.NewEncoder(w).Encode(DeviceAuth{
+			DeviceCode:      "device",
+			UserCode:        "USER-CODE",
+			VerificationURI: "https://example.com/device",
+			ExpiresIn:       60,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "device", r.FormValue("device_code"))
+		assert.Equal(t, deviceCodeGrantType, r.FormValue("grant_type"))
+
+		if polls++; polls < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_in":3600}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	oc := &oauth2.Config{
+		ClientID: "client",
+		Endpoint: oauth2.Endpoint{TokenURL: srv.URL + "/token"},
+		Scopes:   []string{"openid", "offline_access"},
+	}
+
+	f := NewDeviceCodeFlow(request.NewHelper(util.NewLogger("foo")), oc, srv.URL+"/device")
+	f.interval = time.Millisecond
+
+	da, err := f.DeviceAuth()
+	require.NoError(t, err)
+	assert.Equal(t, "USER-CODE", da.UserCode)
+
+	token, err := f.Token(context.Background(), da)
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+	assert.False(t, token.Expiry.IsZero())
+}
+
+func TestDeviceCodeFlowDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"access_denied"}`))
+	}))
+	defer srv.Close()
+
+	oc := &oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: srv.URL}}
+
+	f := NewDeviceCodeFlow(request.NewHelper(util.NewLogger("foo")), oc, "")
+	f.interval = time.Millisecond
+
+	_, err := f.Token(context.Background(), &DeviceAuth{DeviceCode: "device"})
+	assert.EqualError(t, err, "access_denied")
+}
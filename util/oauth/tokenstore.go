@@ -0,0 +1,81 @@
+package oauth
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/evcc-io/evcc/api/store"
+	"golang.org/x/oauth2"
+)
+
+// TokenStore persists OAuth tokens, e.g. refresh tokens obtained by interactive login
+type TokenStore struct {
+	mu    sync.Mutex
+	store store.Store
+	token oauth2.Token
+}
+
+// NewTokenStore creates a token store using the given persistent store
+func NewTokenStore(store store.Store) *TokenStore {
+	return &TokenStore{store: store}
+}
+
+// Load returns the persisted token. It fails if no refresh token has been stored.
+func (s *TokenStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res oauth2.Token
+	if err := s.store.Load(&res); err != nil {
+		return nil, err
+	}
+
+	if res.RefreshToken == "" {
+		return nil, errors.New("missing refresh token")
+	}
+
+	s.token = res
+
+	return &res, nil
+}
+
+// Save persists the token if it has changed. A nil token clears the store.
+func (s *TokenStore) Save(token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res oauth2.Token
+	if token != nil {
+		res = *token
+	}
+
+	if res.AccessToken == s.token.AccessToken && res.RefreshToken == s.token.RefreshToken {
+		return nil
+	}
+
+	if err := s.store.Save(res); err != nil {
+		return err
+	}
+
+	s.token = res
+
+	return nil
+}
+
+// TokenSource returns a token source persisting the tokens obtained from ts
+func (s *TokenStore) TokenSource(ts oauth2.TokenSource) oauth2.TokenSource {
+	return &storeTokenSource{ts: ts, store: s}
+}
+
+type storeTokenSource struct {
+	ts    oauth2.TokenSource
+	store *TokenStore
+}
+
+func (ts *storeTokenSource) Token() (*oauth2.Token, error) {
+	token, err := ts.ts.Token()
+	if err == nil {
+		err = ts.store.Save(token)
+	}
+	return token, err
+}
@@ -0,0 +1,51 @@
+package oauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type memStore struct {
+	saves int
+	val   oauth2.Token
+}
+
+func (s *memStore) Load(res any) error {
+	*res.(*oauth2.Token) = s.val
+	return nil
+}
+
+func (s *memStore) Save(val any) error {
+	s.saves++
+	s.val = val.(oauth2.Token)
+	return nil
+}
+
+func TestTokenStore(t *testing.T) {
+	ms := new(memStore)
+	s := NewTokenStore(ms)
+
+	_, err := s.Load()
+	assert.Error(t, err, "empty store")
+
+	token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}
+	ts := s.TokenSource(oauth2.StaticTokenSource(token))
+
+	for i := 0; i < 2; i++ {
+		res, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, token, res)
+	}
+	assert.Equal(t, 1, ms.saves, "unchanged token saved")
+
+	res, err := s.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", res.RefreshToken)
+
+	require.NoError(t, s.Save(nil))
+	_, err = s.Load()
+	assert.Error(t, err, "cleared store")
+}
@@ -8,11 +8,12 @@ import (
 // Param is the broadcast channel data type
 type Param struct {
 	Loadpoint *int
+	AuxLoad   *int
 	Key       string
 	Val       interface{}
 }
 
-// UniqueID returns unique identifier for parameter Loadpoint/Key combination
+// UniqueID returns unique identifier for parameter Loadpoint/AuxLoad/Key combination
 func (p Param) UniqueID() string {
 	var b strings.Builder
 
@@ -20,6 +21,10 @@ func (p Param) UniqueID() string {
 		b.WriteString(strconv.Itoa(*p.Loadpoint) + ".")
 	}
 
+	if p.AuxLoad != nil {
+		b.WriteString("aux" + strconv.Itoa(*p.AuxLoad) + ".")
+	}
+
 	b.WriteString(p.Key)
 
 	return b.String()
@@ -16,4 +16,8 @@ func TestParam(t *testing.T) {
 
 	p.Loadpoint = &lp
 	assert.Equal(t, "2.power", p.UniqueID())
+
+	p.Loadpoint = nil
+	p.AuxLoad = &lp
+	assert.Equal(t, "aux2.power", p.UniqueID())
 }
@@ -0,0 +1,73 @@
+// Package supervisor provides access to the Home Assistant supervisor when evcc is running as add-on
+package supervisor
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+const (
+	// URI is the supervisor api endpoint
+	URI = "http://supervisor"
+
+	// Address is the source address of requests proxied by the ingress gateway
+	Address = "172.30.32.2"
+)
+
+// Token returns the supervisor api token provided to the add-on
+func Token() string {
+	return os.Getenv("SUPERVISOR_TOKEN")
+}
+
+// Enabled returns true if running as Home Assistant add-on
+func Enabled() bool {
+	return Token() != ""
+}
+
+type response[T any] struct {
+	Result  string
+	Message string
+	Data    T
+}
+
+// MqttService is the mqtt service discovery information
+type MqttService struct {
+	Host     string
+	Port     int
+	SSL      bool
+	Username string
+	Password string
+	Protocol string
+}
+
+// Broker returns the broker address including tls schema if required
+func (s MqttService) Broker() string {
+	broker := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	if s.SSL {
+		broker = "tls://" + broker
+	}
+	return broker
+}
+
+// Service retrieves the discovery information for the given service
+func Service[T any](service string) (T, error) {
+	helper := request.NewHelper(util.NewLogger("supervisor").Redact(Token()))
+	helper.Client.Transport = transport.BearerAuth(Token(), helper.Client.Transport)
+
+	var res response[T]
+	err := helper.GetJSON(fmt.Sprintf("%s/services/%s", URI, service), &res)
+	if err == nil && res.Result != "ok" {
+		err = fmt.Errorf("%s: %s", service, res.Message)
+	}
+
+	return res.Data, err
+}
+
+// Mqtt retrieves the mqtt service discovery information
+func Mqtt() (MqttService, error) {
+	return Service[MqttService]("mqtt")
+}
@@ -93,6 +93,10 @@ func loadTemplates(class Class) {
 			return fmt.Errorf("invalid template class: '%s'", err)
 		}
 
+		if err := unique(templates[class], tmpl); err != nil {
+			return fmt.Errorf("processing template '%s' failed: %w", filepath, err)
+		}
+
 		templates[class] = append(templates[class], tmpl)
 
 		return nil
@@ -103,6 +107,29 @@ func loadTemplates(class Class) {
 	}
 }
 
+// unique verifies that the template's names and products don't overlap with existing templates
+func unique(existing []Template, tmpl Template) error {
+	names := append([]string{tmpl.Template}, tmpl.Covers...)
+
+	for _, t := range existing {
+		for _, name := range names {
+			if t.Template == name || slices.Contains(t.Covers, name) {
+				return fmt.Errorf("duplicate template name: %s", name)
+			}
+		}
+
+		for _, p := range tmpl.Products {
+			for _, tp := range t.Products {
+				if title := p.Title("en"); title == tp.Title("en") {
+					return fmt.Errorf("duplicate product: %s already defined by template %s", title, t.Template)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // EncoderLanguage sets the template language for encoding json
 func EncoderLanguage(lang string) {
 	mu.Lock()
@@ -0,0 +1,29 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnique(t *testing.T) {
+	amtron := Template{TemplateDefinition: TemplateDefinition{
+		Template: "amtron",
+		Covers:   []string{"mennekes"},
+		Products: []Product{{Brand: "Mennekes", Description: TextLanguage{Generic: "AMTRON XTRA"}}},
+	}}
+
+	existing := []Template{amtron}
+
+	assert.NoError(t, unique(existing, Template{TemplateDefinition: TemplateDefinition{
+		Template: "bender",
+		Products: []Product{{Brand: "Mennekes", Description: TextLanguage{Generic: "Amtron Professional"}}},
+	}}))
+
+	assert.Error(t, unique(existing, Template{TemplateDefinition: TemplateDefinition{Template: "amtron"}}), "duplicate name")
+	assert.Error(t, unique(existing, Template{TemplateDefinition: TemplateDefinition{Template: "mennekes"}}), "name covered")
+	assert.Error(t, unique(existing, Template{TemplateDefinition: TemplateDefinition{
+		Template: "amtron-xtra",
+		Products: amtron.Products,
+	}}), "duplicate product")
+}
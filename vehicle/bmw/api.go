@@ -86,3 +86,24 @@ func (v *API) Status(vin string) (VehicleStatus, error) {
 
 	return VehicleStatus{}, err
 }
+
+// Action executes a remote service command
+func (v *API) Action(vin, service, action string) error {
+	uri := fmt.Sprintf("%s/eadrax-vrccs/v2/presentation/remote-commands/%s/%s", CocoApiURI, vin, service)
+	if action != "" {
+		uri += "?action=" + action
+	}
+
+	req, err := request.New(http.MethodPost, uri, nil, map[string]string{
+		"Content-Type": request.JSONContent,
+		"X-User-Agent": v.xUserAgent,
+	})
+	if err == nil {
+		var res struct {
+			EventID string
+		}
+		err = v.DoJSON(req, &res)
+	}
+
+	return err
+}
@@ -12,6 +12,7 @@ const kmPerMile = 1.609344
 // Provider implements the vehicle api
 type Provider struct {
 	statusG func() (VehicleStatus, error)
+	actionS func(service, action string) error
 }
 
 // NewProvider creates a vehicle api provider
@@ -20,6 +21,9 @@ func NewProvider(api *API, vin string, cache time.Duration) *Provider {
 		statusG: provider.Cached(func() (VehicleStatus, error) {
 			return api.Status(vin)
 		}, cache),
+		actionS: func(service, action string) error {
+			return api.Action(vin, service, action)
+		},
 	}
 	return impl
 }
@@ -109,3 +113,81 @@ func (v *Provider) Odometer() (float64, error) {
 
 	return 0, err
 }
+
+var _ api.VehiclePosition = (*Provider)(nil)
+
+// Position implements the api.VehiclePosition interface
+func (v *Provider) Position() (float64, float64, error) {
+	res, err := v.statusG()
+	if err == nil {
+		if loc := res.Properties.VehicleLocation; loc != nil {
+			return loc.Coordinates.Latitude, loc.Coordinates.Longitude, nil
+		}
+
+		err = api.ErrNotAvailable
+	}
+
+	return 0, 0, err
+}
+
+var _ api.VehicleCableLock = (*Provider)(nil)
+
+// CableLocked implements the api.VehicleCableLock interface.
+// BMW locks the charge cable while it is plugged into the vehicle.
+func (v *Provider) CableLocked() (bool, error) {
+	res, err := v.statusG()
+	if err == nil {
+		if cs := res.Properties.ChargingState; cs != nil {
+			return cs.IsChargerConnected, nil
+		}
+
+		err = api.ErrNotAvailable
+	}
+
+	return false, err
+}
+
+var _ api.VehicleClimater = (*Provider)(nil)
+
+// Climater implements the api.VehicleClimater interface
+func (v *Provider) Climater() (bool, error) {
+	res, err := v.statusG()
+	if err == nil {
+		if cc := res.Properties.ClimateControl; cc != nil {
+			return cc.IsClimateOn, nil
+		}
+
+		err = api.ErrNotAvailable
+	}
+
+	return false, err
+}
+
+var _ api.VehicleClimateTemperature = (*Provider)(nil)
+
+// ClimateTemperature implements the api.VehicleClimateTemperature interface
+func (v *Provider) ClimateTemperature() (float64, float64, error) {
+	res, err := v.statusG()
+	if err == nil {
+		cc, outside := res.Properties.ClimateControl, res.Properties.OutsideTemperature
+		if cc != nil && cc.TargetTemperature != nil && outside != nil {
+			return cc.TargetTemperature.Value, outside.Value, nil
+		}
+
+		err = api.ErrNotAvailable
+	}
+
+	return 0, 0, err
+}
+
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// StartClimate implements the api.VehicleClimateController interface
+func (v *Provider) StartClimate() error {
+	return v.actionS("climate-now", "START")
+}
+
+// StopClimate implements the api.VehicleClimateController interface
+func (v *Provider) StopClimate() error {
+	return v.actionS("climate-now", "STOP")
+}
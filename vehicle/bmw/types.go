@@ -25,6 +25,16 @@ type VehicleStatus struct {
 				Value int
 			}
 		}
+		ClimateControl *struct {
+			IsClimateOn       bool
+			TargetTemperature *Temperature
+		}
+		OutsideTemperature *Temperature
+		VehicleLocation    *struct {
+			Coordinates struct {
+				Latitude, Longitude float64
+			}
+		}
 	}
 	Status struct {
 		CurrentMileage *struct {
@@ -32,3 +42,8 @@ type VehicleStatus struct {
 		}
 	}
 }
+
+type Temperature struct {
+	Value float64
+	Units string // CELSIUS
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/util"
 )
 
@@ -20,6 +21,9 @@ func (r vehicleRegistry) Add(name string, factory func(map[string]interface{}) (
 	if _, exists := r[name]; exists {
 		panic(fmt.Sprintf("cannot register duplicate vehicle type: %s", name))
 	}
+	if name != strings.ToLower(name) {
+		panic(fmt.Sprintf("cannot register vehicle type with upper case characters: %s", name))
+	}
 	r[name] = factory
 }
 
@@ -60,7 +64,11 @@ func NewFromConfig(typ string, other map[string]interface{}) (v api.Vehicle, err
 
 	factory, err := registry.Get(strings.ToLower(typ))
 	if err == nil {
-		if v, err = factory(cc.Other); err != nil {
+		// associate vehicle caches with the vehicle to allow refreshing it individually
+		v, err = provider.CacheScope(func() (api.Vehicle, error) {
+			return factory(cc.Other)
+		})
+		if err != nil {
 			err = fmt.Errorf("cannot create vehicle '%s': %w", typ, err)
 		}
 	} else {
@@ -1,17 +1,21 @@
 package vehicle
 
 import (
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 )
 
 type embed struct {
-	Title_       string           `mapstructure:"title"`
-	Icon_        string           `mapstructure:"icon"`
-	Capacity_    float64          `mapstructure:"capacity"`
-	Phases_      int              `mapstructure:"phases"`
-	Identifiers_ []string         `mapstructure:"identifiers"`
-	Features_    []api.Feature    `mapstructure:"features"`
-	OnIdentify   api.ActionConfig `mapstructure:"onIdentify"`
+	Title_       string            `mapstructure:"title"`
+	Icon_        string            `mapstructure:"icon"`
+	Capacity_    float64           `mapstructure:"capacity"`
+	Phases_      int               `mapstructure:"phases"`
+	Identifiers_ []string          `mapstructure:"identifiers"`
+	Features_    []api.Feature     `mapstructure:"features"`
+	OnIdentify   api.ActionConfig  `mapstructure:"onIdentify"`
+	Calibration  time.Duration     `mapstructure:"calibration"`
+	Boost        api.PriorityBoost `mapstructure:"priorityBoost"`
 }
 
 // Title implements the api.Vehicle interface
@@ -57,3 +61,17 @@ var _ api.FeatureDescriber = (*embed)(nil)
 func (v *embed) Features() []api.Feature {
 	return v.Features_
 }
+
+var _ api.VehicleCalibration = (*embed)(nil)
+
+// CalibrationInterval implements the api.VehicleCalibration interface
+func (v *embed) CalibrationInterval() time.Duration {
+	return v.Calibration
+}
+
+var _ api.VehiclePriorityBoost = (*embed)(nil)
+
+// PriorityBoost implements the api.VehiclePriorityBoost interface
+func (v *embed) PriorityBoost() api.PriorityBoost {
+	return v.Boost
+}
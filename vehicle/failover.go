@@ -0,0 +1,171 @@
+package vehicle
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Failover is an api.Vehicle implementation querying multiple vehicle data sources in order of priority
+type Failover struct {
+	*embed
+	mu      sync.Mutex
+	log     *util.Logger
+	clock   clock.Clock
+	retry   time.Duration
+	sources []*failoverSource
+	socG    func() (float64, error)
+}
+
+// failoverSource is a vehicle data source with its last failure
+type failoverSource struct {
+	typ     string
+	vehicle api.Vehicle
+	failed  time.Time
+}
+
+func init() {
+	registry.Add("failover", NewFailoverFromConfig)
+}
+
+// NewFailoverFromConfig creates a new vehicle
+func NewFailoverFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	cc := struct {
+		embed   `mapstructure:",squash"`
+		Retry   time.Duration
+		Sources []struct {
+			Type  string
+			Other map[string]interface{} `mapstructure:",remain"`
+		}
+	}{
+		Retry: 5 * time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if len(cc.Sources) < 2 {
+		return nil, errors.New("need at least two sources")
+	}
+
+	v := &Failover{
+		embed: &cc.embed,
+		log:   util.NewLogger("failover"),
+		clock: clock.New(),
+		retry: cc.Retry,
+	}
+
+	for i, s := range cc.Sources {
+		vehicle, err := NewFromConfig(s.Type, s.Other)
+		if err != nil {
+			return nil, fmt.Errorf("source %d: %w", i+1, err)
+		}
+
+		v.sources = append(v.sources, &failoverSource{
+			typ:     s.Type,
+			vehicle: vehicle,
+		})
+	}
+
+	v.socG = failover(v, func(v api.Vehicle) (func() (float64, error), bool) {
+		return v.Soc, true
+	})
+
+	// decorate status
+	status := failover(v, func(v api.Vehicle) (func() (api.ChargeStatus, error), bool) {
+		if vv, ok := v.(api.ChargeState); ok {
+			return vv.Status, true
+		}
+		return nil, false
+	})
+
+	// decorate range
+	rng := failover(v, func(v api.Vehicle) (func() (int64, error), bool) {
+		if vv, ok := v.(api.VehicleRange); ok {
+			return vv.Range, true
+		}
+		return nil, false
+	})
+
+	// decorate odometer
+	odo := failover(v, func(v api.Vehicle) (func() (float64, error), bool) {
+		if vv, ok := v.(api.VehicleOdometer); ok {
+			return vv.Odometer, true
+		}
+		return nil, false
+	})
+
+	return decorateVehicle(v, status, rng, odo, nil, nil), nil
+}
+
+// failover returns a getter querying all sources supporting it in order of priority or nil if none does.
+// Failed sources are skipped until the retry interval has elapsed, the last supporting source is always queried.
+func failover[T any](v *Failover, get func(api.Vehicle) (func() (T, error), bool)) func() (T, error) {
+	active := -1
+	for i, s := range v.sources {
+		if _, ok := get(s.vehicle); ok {
+			active = i
+			break
+		}
+	}
+
+	if active < 0 {
+		return nil
+	}
+
+	return func() (T, error) {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+
+		var res T
+		err := api.ErrNotAvailable
+
+		last := len(v.sources) - 1
+		for last > 0 {
+			if _, ok := get(v.sources[last].vehicle); ok {
+				break
+			}
+			last--
+		}
+
+		for i, s := range v.sources {
+			g, ok := get(s.vehicle)
+			if !ok {
+				continue
+			}
+
+			if i < last && v.clock.Since(s.failed) < v.retry {
+				continue
+			}
+
+			if res, err = g(); err == nil {
+				if i != active {
+					v.log.WARN.Printf("%s: switching to source %d (%s)", v.Title(), i+1, s.typ)
+					active = i
+				}
+
+				return res, nil
+			}
+
+			v.log.DEBUG.Printf("%s: source %d (%s): %v", v.Title(), i+1, s.typ, err)
+
+			// vehicle is waking up, don't penalize source
+			if !errors.Is(err, api.ErrMustRetry) {
+				s.failed = v.clock.Now()
+			}
+		}
+
+		return res, err
+	}
+}
+
+// Soc implements the api.Vehicle interface
+func (v *Failover) Soc() (float64, error) {
+	return v.socG()
+}
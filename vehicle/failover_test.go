@@ -0,0 +1,77 @@
+package vehicle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clock := clock.NewMock()
+
+	obd := mock.NewMockVehicle(ctrl)
+	cloud := mock.NewMockVehicle(ctrl)
+
+	v := &Failover{
+		embed: new(embed),
+		log:   util.NewLogger("foo"),
+		clock: clock,
+		retry: time.Minute,
+		sources: []*failoverSource{
+			{typ: "obd", vehicle: obd},
+			{typ: "cloud", vehicle: cloud},
+		},
+	}
+
+	soc := failover(v, func(v api.Vehicle) (func() (float64, error), bool) {
+		return v.Soc, true
+	})
+
+	// preferred source
+	obd.EXPECT().Soc().Return(50.0, nil)
+	res, err := soc()
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, res)
+
+	// failover on error
+	obd.EXPECT().Soc().Return(0.0, errors.New("stale"))
+	cloud.EXPECT().Soc().Return(51.0, nil)
+	res, err = soc()
+	assert.NoError(t, err)
+	assert.Equal(t, 51.0, res)
+
+	// failed source is skipped until retry
+	cloud.EXPECT().Soc().Return(52.0, nil)
+	res, err = soc()
+	assert.NoError(t, err)
+	assert.Equal(t, 52.0, res)
+
+	// last source is always queried
+	cloud.EXPECT().Soc().Return(0.0, api.ErrTimeout)
+	_, err = soc()
+	assert.ErrorIs(t, err, api.ErrTimeout)
+
+	// preferred source recovered
+	clock.Add(time.Minute)
+	obd.EXPECT().Soc().Return(53.0, nil)
+	res, err = soc()
+	assert.NoError(t, err)
+	assert.Equal(t, 53.0, res)
+
+	// unsupported interface
+	assert.Nil(t, failover(v, func(v api.Vehicle) (func() (int64, error), bool) {
+		vv, ok := v.(api.VehicleRange)
+		if !ok {
+			return nil, false
+		}
+		return vv.Range, true
+	}))
+}
@@ -45,17 +45,10 @@ func NewMercedesFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, errors.New("missing vin")
 	}
 
-	var options []mercedes.IdentityOption
-
-	// TODO Load tokens from a persistence storage and use those during startup
-	// e.g. persistence.Load("key")
-	// if tokens != nil {
-	// 	options = append(options, mercedes.WithToken(&oauth2.Token{
-	// 		AccessToken:  tokens.Access,
-	// 		RefreshToken: tokens.Refresh,
-	// 		Expiry:       tokens.Expiry,
-	// 	}))
-	// }
+	// restore tokens from login or `evcc token`
+	options := []mercedes.IdentityOption{
+		mercedes.WithStore(TokenStore("mercedes", cc.VIN)),
+	}
 
 	log := util.NewLogger("mercedes")
 
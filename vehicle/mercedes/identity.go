@@ -3,6 +3,7 @@ package mercedes
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -12,6 +13,8 @@ import (
 	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/server/oauth2redirect"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
+	"github.com/evcc-io/evcc/util/request"
 	"golang.org/x/oauth2"
 )
 
@@ -28,12 +31,24 @@ func WithToken(t *oauth2.Token) IdentityOption {
 	}
 }
 
+// WithStore persists the tokens obtained by login or refresh and restores them on startup.
+func WithStore(store *oauth.TokenStore) IdentityOption {
+	return func(v *Identity) error {
+		v.ReuseTokenSource.store = store
+		if t, err := store.Load(); err == nil {
+			v.ReuseTokenSource.Apply(t)
+		}
+		return nil
+	}
+}
+
 type Identity struct {
 	log *util.Logger
 	*ReuseTokenSource
-	oc      *oauth2.Config
-	baseURL string
-	authC   chan<- bool
+	oc            *oauth2.Config
+	deviceAuthURL string
+	baseURL       string
+	authC         chan<- bool
 }
 
 // TODO SessionSecret from config/persistence
@@ -54,9 +69,16 @@ func NewIdentity(log *util.Logger, id, secret string, options ...IdentityOption)
 		},
 	}
 
+	// device authorization endpoint is optional, see https://www.rfc-editor.org/rfc/rfc8628#section-4
+	var claims struct {
+		DeviceAuthURL string `json:"device_authorization_endpoint"`
+	}
+	_ = provider.Claims(&claims)
+
 	v := &Identity{
-		log: log,
-		oc:  oc,
+		log:           log,
+		oc:            oc,
+		deviceAuthURL: claims.DeviceAuthURL,
 	}
 
 	ts := &ReuseTokenSource{
@@ -83,6 +105,15 @@ func (v *Identity) invalidToken() {
 	}
 }
 
+// DeviceCodeFlow returns the device authorization flow for logging in without browser redirect
+func (v *Identity) DeviceCodeFlow() (*oauth.DeviceCodeFlow, error) {
+	if v.deviceAuthURL == "" {
+		return nil, errors.New("device authorization not supported")
+	}
+
+	return oauth.NewDeviceCodeFlow(request.NewHelper(v.log), v.oc, v.deviceAuthURL), nil
+}
+
 var _ api.AuthProvider = (*Identity)(nil)
 
 func (v *Identity) SetCallbackParams(baseURL, redirectURL string, authC chan<- bool) {
@@ -4,14 +4,16 @@ import (
 	"context"
 	"sync"
 
+	"github.com/evcc-io/evcc/util/oauth"
 	"golang.org/x/oauth2"
 )
 
 type ReuseTokenSource struct {
-	mu sync.Mutex
-	oc *oauth2.Config
-	ts oauth2.TokenSource
-	cb func()
+	mu    sync.Mutex
+	oc    *oauth2.Config
+	ts    oauth2.TokenSource
+	cb    func()
+	store *oauth.TokenStore // optional token persistence
 }
 
 func (ts *ReuseTokenSource) Token() (*oauth2.Token, error) {
@@ -29,6 +31,12 @@ func (ts *ReuseTokenSource) Token() (*oauth2.Token, error) {
 
 func (ts *ReuseTokenSource) Apply(t *oauth2.Token) {
 	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
 	ts.ts = ts.oc.TokenSource(context.Background(), t)
-	ts.mu.Unlock()
+
+	if ts.store != nil {
+		_ = ts.store.Save(t)
+		ts.ts = ts.store.TokenSource(ts.ts)
+	}
 }
@@ -69,6 +69,36 @@ func (v *API) BatteryStatus(vin string) (StatusResponse, error) {
 	return res, err
 }
 
+// Cockpit provides cockpit api response
+func (v *API) Cockpit(vin string) (CockpitResponse, error) {
+	uri := fmt.Sprintf("%s/v1/cars/%s/cockpit", CarAdapterBaseURL, vin)
+
+	var res CockpitResponse
+	err := v.GetJSON(uri, &res)
+
+	return res, err
+}
+
+// Location provides location api response
+func (v *API) Location(vin string) (LocationResponse, error) {
+	uri := fmt.Sprintf("%s/v1/cars/%s/location", CarAdapterBaseURL, vin)
+
+	var res LocationResponse
+	err := v.GetJSON(uri, &res)
+
+	return res, err
+}
+
+// HvacStatus provides hvac status api response
+func (v *API) HvacStatus(vin string) (HvacResponse, error) {
+	uri := fmt.Sprintf("%s/v1/cars/%s/hvac-status", CarAdapterBaseURL, vin)
+
+	var res HvacResponse
+	err := v.GetJSON(uri, &res)
+
+	return res, err
+}
+
 // RefreshRequest requests  battery status refresh
 func (v *API) RefreshRequest(vin, typ string) (ActionResponse, error) {
 	var res ActionResponse
@@ -100,6 +130,8 @@ type Action string
 const (
 	ActionChargeStart Action = "start"
 	ActionChargeStop  Action = "stop"
+	ActionHvacStart   Action = "start"
+	ActionHvacStop    Action = "cancel"
 )
 
 // ChargingAction provides actions/charging-start api response
@@ -126,3 +158,33 @@ func (v *API) ChargingAction(vin string, action Action) (ActionResponse, error)
 
 	return res, err
 }
+
+// HvacAction provides actions/hvac-start api response
+func (v *API) HvacAction(vin string, action Action, temp float64) (ActionResponse, error) {
+	uri := fmt.Sprintf("%s/v1/cars/%s/actions/hvac-start", CarAdapterBaseURL, vin)
+
+	attributes := map[string]interface{}{
+		"action": action,
+	}
+	if action == ActionHvacStart {
+		attributes["targetTemperature"] = temp
+	}
+
+	data := Request{
+		Data: Payload{
+			Type:       "HvacStart",
+			Attributes: attributes,
+		},
+	}
+
+	req, err := request.New(http.MethodPost, uri, request.MarshalJSON(data), map[string]string{
+		"Content-Type": "application/vnd.api+json",
+	})
+
+	var res ActionResponse
+	if err == nil {
+		err = v.DoJSON(req, &res)
+	}
+
+	return res, err
+}
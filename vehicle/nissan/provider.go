@@ -13,19 +13,29 @@ const refreshTimeout = 2 * time.Minute
 // Provider is a kamereon provider
 type Provider struct {
 	statusG     func() (StatusResponse, error)
+	cockpitG    func() (CockpitResponse, error)
+	locationG   func() (LocationResponse, error)
+	hvacG       func() (HvacResponse, error)
 	action      func(value Action) error
+	hvacAction  func(value Action) error
+	temperature float64
 	expiry      time.Duration
 	refreshTime time.Time
 }
 
 // NewProvider returns a kamereon provider
-func NewProvider(api *API, vin string, expiry, cache time.Duration) *Provider {
+func NewProvider(api *API, vin string, temperature float64, expiry, cache time.Duration) *Provider {
 	impl := &Provider{
 		action: func(value Action) error {
 			_, err := api.ChargingAction(vin, value)
 			return err
 		},
-		expiry: expiry,
+		hvacAction: func(value Action) error {
+			_, err := api.HvacAction(vin, value, temperature)
+			return err
+		},
+		temperature: temperature,
+		expiry:      expiry,
 	}
 
 	impl.statusG = provider.Cached(func() (StatusResponse, error) {
@@ -35,6 +45,18 @@ func NewProvider(api *API, vin string, expiry, cache time.Duration) *Provider {
 		)
 	}, cache)
 
+	impl.cockpitG = provider.Cached(func() (CockpitResponse, error) {
+		return api.Cockpit(vin)
+	}, cache)
+
+	impl.locationG = provider.Cached(func() (LocationResponse, error) {
+		return api.Location(vin)
+	}, cache)
+
+	impl.hvacG = provider.Cached(func() (HvacResponse, error) {
+		return api.HvacStatus(vin)
+	}, cache)
+
 	return impl
 }
 
@@ -141,6 +163,32 @@ func (v *Provider) FinishTime() (time.Time, error) {
 	return time.Time{}, err
 }
 
+var _ api.VehicleOdometer = (*Provider)(nil)
+
+// Odometer implements the api.VehicleOdometer interface
+func (v *Provider) Odometer() (float64, error) {
+	res, err := v.cockpitG()
+
+	if err == nil {
+		return res.TotalMileage, nil
+	}
+
+	return 0, err
+}
+
+var _ api.VehiclePosition = (*Provider)(nil)
+
+// Position implements the api.VehiclePosition interface
+func (v *Provider) Position() (float64, float64, error) {
+	res, err := v.locationG()
+
+	if err == nil {
+		return res.GpsLatitude, res.GpsLongitude, nil
+	}
+
+	return 0, 0, err
+}
+
 var _ api.VehicleChargeController = (*Provider)(nil)
 
 // StartCharge implements the api.VehicleChargeController interface
@@ -152,3 +200,41 @@ func (v *Provider) StartCharge() error {
 func (v *Provider) StopCharge() error {
 	return v.action(ActionChargeStop)
 }
+
+var _ api.VehicleClimater = (*Provider)(nil)
+
+// Climater implements the api.VehicleClimater interface
+func (v *Provider) Climater() (bool, error) {
+	res, err := v.hvacG()
+
+	if err == nil {
+		return res.HvacStatus == "on", nil
+	}
+
+	return false, err
+}
+
+var _ api.VehicleClimateTemperature = (*Provider)(nil)
+
+// ClimateTemperature implements the api.VehicleClimateTemperature interface
+func (v *Provider) ClimateTemperature() (float64, float64, error) {
+	res, err := v.hvacG()
+
+	if err == nil {
+		return v.temperature, res.ExternalTemperature, nil
+	}
+
+	return 0, 0, err
+}
+
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// StartClimate implements the api.VehicleClimateController interface
+func (v *Provider) StartClimate() error {
+	return v.hvacAction(ActionHvacStart)
+}
+
+// StopClimate implements the api.VehicleClimateController interface
+func (v *Provider) StopClimate() error {
+	return v.hvacAction(ActionHvacStop)
+}
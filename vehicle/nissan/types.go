@@ -91,6 +91,31 @@ type Attributes struct {
 	RemainingToFullSlow   int       `json:"timeRequiredToFullSlow"`
 }
 
+// CockpitResponse structure for kamereon api
+type CockpitResponse struct {
+	ID           string
+	TotalMileage float64 `json:"totalMileage"`
+	Errors       []Error
+}
+
+// LocationResponse structure for kamereon api
+type LocationResponse struct {
+	ID             string
+	GpsLatitude    float64   `json:"gpsLatitude"`
+	GpsLongitude   float64   `json:"gpsLongitude"`
+	LastUpdateTime Timestamp `json:"lastUpdateTime"`
+	Errors         []Error
+}
+
+// HvacResponse structure for kamereon api
+type HvacResponse struct {
+	ID                  string
+	HvacStatus          string    `json:"hvacStatus"`
+	ExternalTemperature float64   `json:"externalTemperature"`
+	LastUpdateTime      Timestamp `json:"lastUpdateTime"`
+	Errors              []Error
+}
+
 type ActionResponse struct {
 	Data struct {
 		Type, ID string // battery refresh
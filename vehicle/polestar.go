@@ -0,0 +1,60 @@
+package vehicle
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/vehicle/polestar"
+)
+
+// Polestar is an api.Vehicle implementation for Polestar cars
+type Polestar struct {
+	*embed
+	*polestar.Provider
+}
+
+func init() {
+	registry.Add("polestar", NewPolestarFromConfig)
+}
+
+// NewPolestarFromConfig creates a new vehicle
+func NewPolestarFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	cc := struct {
+		embed               `mapstructure:",squash"`
+		User, Password, VIN string
+		Cache               time.Duration
+	}{
+		Cache: interval,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.User == "" || cc.Password == "" {
+		return nil, api.ErrMissingCredentials
+	}
+
+	v := &Polestar{
+		embed: &cc.embed,
+	}
+
+	log := util.NewLogger("polestar").Redact(cc.User, cc.Password, cc.VIN)
+	identity := polestar.NewIdentity(log)
+
+	err := identity.Login(cc.User, cc.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	api := polestar.NewAPI(log, identity)
+
+	cc.VIN, err = ensureVehicle(cc.VIN, api.Vehicles)
+
+	if err == nil {
+		v.Provider = polestar.NewProvider(api, cc.VIN, cc.Cache)
+	}
+
+	return v, err
+}
@@ -0,0 +1,83 @@
+package polestar
+
+import (
+	"context"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/shurcooL/graphql"
+	"golang.org/x/oauth2"
+)
+
+const ApiURI = "https://pc-api.polestar.com/eu-north-1/mystar-v2/"
+
+// API is the Polestar api client
+type API struct {
+	client *graphql.Client
+}
+
+// NewAPI creates a new api client
+func NewAPI(log *util.Logger, ts oauth2.TokenSource) *API {
+	ctx := context.WithValue(
+		context.Background(),
+		oauth2.HTTPClient,
+		request.NewClient(log),
+	)
+
+	v := &API{
+		client: graphql.NewClient(ApiURI, oauth2.NewClient(ctx, ts)),
+	}
+
+	return v
+}
+
+// Vehicles returns the list of user vehicles
+func (v *API) Vehicles() ([]string, error) {
+	var res struct {
+		Cars []ConsumerCar `graphql:"getConsumerCarsV2"`
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	err := v.client.Query(ctx, &res, nil)
+
+	var vins []string
+	for _, car := range res.Cars {
+		vins = append(vins, car.VIN)
+	}
+
+	return vins, err
+}
+
+// Battery returns the vehicles battery status
+func (v *API) Battery(vin string) (BatteryData, error) {
+	var res struct {
+		BatteryData `graphql:"getBatteryData(vin: $vin)"`
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	err := v.client.Query(ctx, &res, map[string]interface{}{
+		"vin": graphql.String(vin),
+	})
+
+	return res.BatteryData, err
+}
+
+// Odometer returns the vehicles odometer status
+func (v *API) Odometer(vin string) (OdometerData, error) {
+	var res struct {
+		OdometerData `graphql:"getOdometerData(vin: $vin)"`
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	err := v.client.Query(ctx, &res, map[string]interface{}{
+		"vin": graphql.String(vin),
+	})
+
+	return res.OdometerData, err
+}
@@ -0,0 +1,186 @@
+package polestar
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
+	"github.com/evcc-io/evcc/util/request"
+	cv "github.com/nirasan/go-oauth-pkce-code-verifier"
+	"golang.org/x/net/publicsuffix"
+	"golang.org/x/oauth2"
+)
+
+// https://github.com/leeyuentuen/polestar_api
+
+const (
+	OAuthURI    = "https://polestarid.eu.polestar.com"
+	RedirectURI = "https://www.polestar.com/sign-in-callback"
+	ClientID    = "l3oopkc_10"
+)
+
+type Identity struct {
+	*request.Helper
+	oauth2.TokenSource
+	user, password string
+}
+
+// NewIdentity creates Polestar identity
+func NewIdentity(log *util.Logger) *Identity {
+	v := &Identity{
+		Helper: request.NewHelper(log),
+	}
+
+	return v
+}
+
+func (v *Identity) Login(user, password string) error {
+	v.user = user
+	v.password = password
+
+	token, err := v.RefreshToken(nil)
+
+	if err == nil {
+		v.TokenSource = oauth.RefreshTokenSource(token, v)
+	}
+
+	return err
+}
+
+func (v *Identity) retrieveToken(data url.Values) (*oauth2.Token, error) {
+	var tok struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+
+	uri := fmt.Sprintf("%s/as/token.oauth2", OAuthURI)
+	req, err := request.New(http.MethodPost, uri, strings.NewReader(data.Encode()), request.URLEncoding)
+
+	if err == nil {
+		err = v.DoJSON(req, &tok)
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		Expiry:       time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second),
+	}
+
+	return token, err
+}
+
+func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
+	if token == nil || token.RefreshToken == "" {
+		return v.login()
+	}
+
+	data := url.Values{
+		"client_id":     {ClientID},
+		"refresh_token": {token.RefreshToken},
+		"grant_type":    {"refresh_token"},
+	}
+
+	return v.retrieveToken(data)
+}
+
+// redirect returns the query parameters of the redirect location
+func (v *Identity) redirect(resp *http.Response) (url.Values, error) {
+	resp.Body.Close()
+
+	u, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Query(), nil
+}
+
+func (v *Identity) login() (*oauth2.Token, error) {
+	// don't follow redirects
+	v.Client.CheckRedirect = func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse }
+	defer func() { v.Client.CheckRedirect = nil }()
+
+	cv, err := cv.CreateCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	v.Jar, err = cookiejar.New(&cookiejar.Options{
+		PublicSuffixList: publicsuffix.List,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := url.Values{
+		"client_id":             {ClientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {RedirectURI},
+		"scope":                 {"openid profile email customer:attributes"},
+		"state":                 {"ea5aa2860f894a9287a4592b7ae14f62"},
+		"code_challenge_method": {"S256"},
+		"code_challenge":        {cv.CodeChallengeS256()},
+	}
+
+	uri := fmt.Sprintf("%s/as/authorization.oauth2?%s", OAuthURI, data.Encode())
+	req, err := request.New(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := v.redirect(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	resume := query.Get("resumePath")
+	if resume == "" {
+		return nil, errors.New("resume path not found")
+	}
+
+	params := url.Values{
+		"pf.username": {v.user},
+		"pf.pass":     {v.password},
+	}
+
+	uri = fmt.Sprintf("%s/as/%s/resume/as/authorization.ping?client_id=%s", OAuthURI, resume, ClientID)
+	req, err = request.New(http.MethodPost, uri, strings.NewReader(params.Encode()), request.URLEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp, err = v.Do(req); err != nil {
+		return nil, err
+	}
+
+	if query, err = v.redirect(resp); err != nil {
+		return nil, err
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return nil, errors.New("authorization code not found")
+	}
+
+	data = url.Values{
+		"client_id":     {ClientID},
+		"code":          {code},
+		"redirect_uri":  {RedirectURI},
+		"grant_type":    {"authorization_code"},
+		"code_verifier": {cv.CodeChallengePlain()},
+	}
+
+	return v.retrieveToken(data)
+}
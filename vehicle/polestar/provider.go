@@ -0,0 +1,79 @@
+package polestar
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/provider"
+)
+
+// Provider implements the vehicle api
+type Provider struct {
+	batteryG  func() (BatteryData, error)
+	odometerG func() (OdometerData, error)
+}
+
+// NewProvider creates a vehicle api provider
+func NewProvider(api *API, vin string, cache time.Duration) *Provider {
+	impl := &Provider{
+		batteryG: provider.Cached(func() (BatteryData, error) {
+			return api.Battery(vin)
+		}, cache),
+		odometerG: provider.Cached(func() (OdometerData, error) {
+			return api.Odometer(vin)
+		}, cache),
+	}
+	return impl
+}
+
+// Soc implements the api.Vehicle interface
+func (v *Provider) Soc() (float64, error) {
+	res, err := v.batteryG()
+	return res.BatteryChargeLevelPercentage, err
+}
+
+var _ api.ChargeState = (*Provider)(nil)
+
+// Status implements the api.ChargeState interface
+func (v *Provider) Status() (api.ChargeStatus, error) {
+	status := api.StatusA // disconnected
+
+	res, err := v.batteryG()
+	if err == nil {
+		if res.ChargerConnectionStatus == "CHARGER_CONNECTION_STATUS_CONNECTED" {
+			status = api.StatusB
+		}
+		if res.ChargingStatus == "CHARGING_STATUS_CHARGING" {
+			status = api.StatusC
+		}
+	}
+
+	return status, err
+}
+
+var _ api.VehicleRange = (*Provider)(nil)
+
+// Range implements the api.VehicleRange interface
+func (v *Provider) Range() (int64, error) {
+	res, err := v.batteryG()
+	return res.EstimatedDistanceToEmptyKm, err
+}
+
+var _ api.VehicleFinishTimer = (*Provider)(nil)
+
+// FinishTime implements the api.VehicleFinishTimer interface
+func (v *Provider) FinishTime() (time.Time, error) {
+	res, err := v.batteryG()
+	if err == nil && res.ChargingStatus != "CHARGING_STATUS_CHARGING" {
+		err = api.ErrNotAvailable
+	}
+	return time.Now().Add(time.Duration(res.EstimatedChargingTimeToFullMinutes) * time.Minute), err
+}
+
+var _ api.VehicleOdometer = (*Provider)(nil)
+
+// Odometer implements the api.VehicleOdometer interface
+func (v *Provider) Odometer() (float64, error) {
+	res, err := v.odometerG()
+	return res.OdometerMeters / 1e3, err
+}
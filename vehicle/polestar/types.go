@@ -0,0 +1,19 @@
+package polestar
+
+type ConsumerCar struct {
+	VIN                       string `graphql:"vin"`
+	InternalVehicleIdentifier string `graphql:"internalVehicleIdentifier"`
+	ModelYear                 string `graphql:"modelYear"`
+}
+
+type BatteryData struct {
+	BatteryChargeLevelPercentage       float64 `graphql:"batteryChargeLevelPercentage"`
+	ChargerConnectionStatus            string  `graphql:"chargerConnectionStatus"` // CHARGER_CONNECTION_STATUS_CONNECTED, CHARGER_CONNECTION_STATUS_DISCONNECTED
+	ChargingStatus                     string  `graphql:"chargingStatus"`          // CHARGING_STATUS_CHARGING, CHARGING_STATUS_IDLE, CHARGING_STATUS_DONE
+	EstimatedChargingTimeToFullMinutes int     `graphql:"estimatedChargingTimeToFullMinutes"`
+	EstimatedDistanceToEmptyKm         int64   `graphql:"estimatedDistanceToEmptyKm"`
+}
+
+type OdometerData struct {
+	OdometerMeters float64 `graphql:"odometerMeters"`
+}
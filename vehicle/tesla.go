@@ -3,9 +3,11 @@ package vehicle
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/bogosj/tesla"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/util"
@@ -16,8 +18,11 @@ import (
 // Tesla is an api.Vehicle implementation for Tesla cars
 type Tesla struct {
 	*embed
-	vehicle *tesla.Vehicle
-	dataG   func() (*tesla.VehicleData, error)
+	vehicle  *tesla.Vehicle
+	dataG    func() (*tesla.VehicleData, error)
+	mu       sync.Mutex      // guards wake-up backoff
+	wakeup   backoff.BackOff // wake-up call backoff
+	wakeNext time.Time
 }
 
 func init() {
@@ -39,25 +44,37 @@ func NewTeslaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
-	if err := cc.Tokens.Error(); err != nil {
-		return nil, err
+	// prefer tokens stored by `evcc token` or refresh since Tesla rotates refresh tokens
+	store := TokenStore("tesla", cc.VIN)
+
+	token, err := store.Load()
+	if err != nil {
+		if err := cc.Tokens.Error(); err != nil {
+			return nil, err
+		}
+
+		token = &oauth2.Token{
+			AccessToken:  cc.Tokens.Access,
+			RefreshToken: cc.Tokens.Refresh,
+			Expiry:       time.Now(),
+		}
 	}
 
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Minute
+	bo.MaxInterval = 30 * time.Minute
+	bo.MaxElapsedTime = 0
+
 	v := &Tesla{
-		embed: &cc.embed,
+		embed:  &cc.embed,
+		wakeup: bo,
 	}
 
 	// authenticated http client with logging injected to the Tesla client
-	log := util.NewLogger("tesla").Redact(cc.Tokens.Access, cc.Tokens.Refresh)
+	log := util.NewLogger("tesla").Redact(token.AccessToken, token.RefreshToken)
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewClient(log))
 
-	options := []tesla.ClientOption{tesla.WithToken(&oauth2.Token{
-		AccessToken:  cc.Tokens.Access,
-		RefreshToken: cc.Tokens.Refresh,
-		Expiry:       time.Now(),
-	})}
-
-	client, err := tesla.NewClient(ctx, options...)
+	client, err := tesla.NewClient(ctx, tesla.WithToken(token))
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +96,20 @@ func NewTeslaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	v.dataG = provider.Cached(func() (*tesla.VehicleData, error) {
 		res, err := v.vehicle.Data()
+		if err == nil {
+			// persist refreshed tokens
+			if token, err := client.Token(); err == nil {
+				if err := store.Save(token); err != nil {
+					log.ERROR.Printf("token: %v", err)
+				}
+			}
+
+			// vehicle is awake
+			v.mu.Lock()
+			v.wakeup.Reset()
+			v.wakeNext = time.Time{}
+			v.mu.Unlock()
+		}
 		return res, v.apiError(err)
 	}, cc.Cache)
 
@@ -147,6 +178,28 @@ func (v *Tesla) Range() (int64, error) {
 	return int64(kmPerMile * res.Response.ChargeState.BatteryRange), nil
 }
 
+var _ api.VehicleChargePort = (*Tesla)(nil)
+
+// ChargePortOpen implements the api.VehicleChargePort interface
+func (v *Tesla) ChargePortOpen() (bool, error) {
+	res, err := v.dataG()
+	if err != nil {
+		return false, err
+	}
+	return res.Response.ChargeState.ChargePortDoorOpen, nil
+}
+
+var _ api.VehicleCableLock = (*Tesla)(nil)
+
+// CableLocked implements the api.VehicleCableLock interface
+func (v *Tesla) CableLocked() (bool, error) {
+	res, err := v.dataG()
+	if err != nil {
+		return false, err
+	}
+	return res.Response.ChargeState.ChargePortLatch == "Engaged", nil
+}
+
 var _ api.VehicleOdometer = (*Tesla)(nil)
 
 // Odometer implements the api.VehicleOdometer interface
@@ -194,6 +247,13 @@ func (v *Tesla) TargetSoc() (float64, error) {
 	return float64(res.Response.ChargeState.ChargeLimitSoc), nil
 }
 
+var _ api.SocLimitController = (*Tesla)(nil)
+
+// SetSocLimit implements the api.SocLimitController interface
+func (v *Tesla) SetSocLimit(soc int) error {
+	return v.apiError(v.vehicle.SetChargeLimit(soc))
+}
+
 var _ api.CurrentLimiter = (*Tesla)(nil)
 
 // StartCharge implements the api.VehicleChargeController interface
@@ -203,7 +263,18 @@ func (v *Tesla) MaxCurrent(current int64) error {
 
 var _ api.Resurrector = (*Tesla)(nil)
 
+// WakeUp implements the api.Resurrector interface.
+// Repeated wake-up calls are throttled using exponential backoff until the vehicle responds.
 func (v *Tesla) WakeUp() error {
+	v.mu.Lock()
+	if time.Now().Before(v.wakeNext) {
+		v.mu.Unlock()
+		return api.ErrMustRetry
+	}
+
+	v.wakeNext = time.Now().Add(v.wakeup.NextBackOff())
+	v.mu.Unlock()
+
 	_, err := v.vehicle.Wakeup()
 	return v.apiError(err)
 }
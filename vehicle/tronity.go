@@ -199,8 +199,11 @@ func (v *Tronity) status() (api.ChargeStatus, error) {
 	res, err := v.bulkG()
 
 	if err == nil {
-		if res.Charging == "Charging" {
+		switch res.Charging {
+		case "Charging", "Starting":
 			status = api.StatusC
+		case "Complete", "Stopped", "NoPower":
+			status = api.StatusB
 		}
 	}
 
@@ -30,7 +30,7 @@ type Bulk struct {
 	Odometer float64
 	Range    float64
 	Level    float64
-	Charging string // Charging
+	Charging string // Charging, Starting, Complete, Stopped, NoPower, Disconnected
 	// Latitude  float64/string
 	// Longitude float64/string
 	Timestamp int64
@@ -1,6 +1,12 @@
 package vehicle
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util/oauth"
+)
 
 // ClientCredentials contains OAuth2 client id and secret
 type ClientCredentials struct {
@@ -33,3 +39,13 @@ func (t *Tokens) Error() error {
 
 	return nil
 }
+
+// TokenStore returns the persistent store for the OAuth tokens of the given vehicle type and vin
+func TokenStore(typ, vin string) *oauth.TokenStore {
+	key := "vehicle." + strings.ToLower(typ) + ".token"
+	if vin != "" {
+		key += "." + strings.ToUpper(vin)
+	}
+
+	return oauth.NewTokenStore(settings.NewStore(key))
+}
@@ -90,6 +90,24 @@ func (v *API) Action(vin, action, value string) error {
 	return err
 }
 
+// ChargeSettings updates the vehicles target soc
+func (v *API) ChargeSettings(vin string, targetSoc int) error {
+	uri := fmt.Sprintf("%s/vehicles/%s/%s/%s", BaseURL, vin, ActionCharge, ActionChargeSettings)
+
+	data := map[string]int{
+		"targetSOC_pct": targetSoc,
+	}
+
+	req, err := request.New(http.MethodPut, uri, request.MarshalJSON(data), request.JSONEncoding)
+
+	if err == nil {
+		var res interface{}
+		err = v.DoJSON(req, &res)
+	}
+
+	return err
+}
+
 // Any implements any api response
 func (v *API) Any(uri, vin string) (interface{}, error) {
 	if strings.Contains(uri, "%s") {
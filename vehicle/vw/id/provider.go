@@ -11,8 +11,9 @@ import (
 
 // Provider is an api.Vehicle implementation for VW ID cars
 type Provider struct {
-	statusG func() (Status, error)
-	action  func(action, value string) error
+	statusG  func() (Status, error)
+	action   func(action, value string) error
+	settings func(targetSoc int) error
 }
 
 // NewProvider creates a vehicle api provider
@@ -24,6 +25,9 @@ func NewProvider(api *API, vin string, cache time.Duration) *Provider {
 		action: func(action, value string) error {
 			return api.Action(vin, action, value)
 		},
+		settings: func(targetSoc int) error {
+			return api.ChargeSettings(vin, targetSoc)
+		},
 	}
 	return impl
 }
@@ -68,6 +72,22 @@ func (v *Provider) Status() (api.ChargeStatus, error) {
 	return status, err
 }
 
+var _ api.VehicleCableLock = (*Provider)(nil)
+
+// CableLocked implements the api.VehicleCableLock interface
+func (v *Provider) CableLocked() (bool, error) {
+	res, err := v.statusG()
+	if err == nil && res.Charging == nil {
+		err = errors.New("missing charging status")
+	}
+
+	if err == nil {
+		return res.Charging.PlugStatus.Value.PlugLockState == "locked", nil
+	}
+
+	return false, err
+}
+
 var _ api.VehicleFinishTimer = (*Provider)(nil)
 
 // FinishTime implements the api.VehicleFinishTimer interface
@@ -160,6 +180,25 @@ func (v *Provider) TargetSoc() (float64, error) {
 	return 0, err
 }
 
+var _ api.SocLimitController = (*Provider)(nil)
+
+// SetSocLimit implements the api.SocLimitController interface
+func (v *Provider) SetSocLimit(soc int) error {
+	return v.settings(soc)
+}
+
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// StartClimate implements the api.VehicleClimateController interface
+func (v *Provider) StartClimate() error {
+	return v.action(ActionClimatisation, ActionClimatisationStart)
+}
+
+// StopClimate implements the api.VehicleClimateController interface
+func (v *Provider) StopClimate() error {
+	return v.action(ActionClimatisation, ActionClimatisationStop)
+}
+
 var _ api.VehicleChargeController = (*Provider)(nil)
 
 // StartCharge implements the api.VehicleChargeController interface
@@ -79,6 +79,17 @@ func (v *Provider) Status() (api.ChargeStatus, error) {
 	return status, err
 }
 
+var _ api.VehicleCableLock = (*Provider)(nil)
+
+// CableLocked implements the api.VehicleCableLock interface
+func (v *Provider) CableLocked() (bool, error) {
+	res, err := v.chargerG()
+	if err == nil {
+		return res.Charger.Status.PlugStatusData.LockState.Content == "locked", nil
+	}
+	return false, err
+}
+
 var _ api.VehicleFinishTimer = (*Provider)(nil)
 
 // FinishTime implements the api.VehicleFinishTimer interface
@@ -32,7 +32,8 @@ type ChargerResponse struct {
 				EnergyFlow               TimedString // on, off
 			}
 			PlugStatusData struct {
-				PlugState TimedString // connected
+				PlugState TimedString // connected, disconnected
+				LockState TimedString // locked, unlocked
 			}
 			CruisingRangeStatusData struct {
 				EngineTypeFirstEngine  TimedString // typeIsElectric, petrolGasoline
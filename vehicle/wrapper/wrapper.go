@@ -1,15 +1,21 @@
 package wrapper
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 )
 
 // Wrapper wraps an api.Vehicle to capture initialization errors
 type Wrapper struct {
+	mu        sync.RWMutex
+	vehicle   api.Vehicle // recovered vehicle
 	err       error
 	title     string
 	icon      string
@@ -19,7 +25,7 @@ type Wrapper struct {
 }
 
 // New creates a new Vehicle
-func New(name string, other map[string]interface{}, err error) api.Vehicle {
+func New(name string, other map[string]interface{}, err error) *Wrapper {
 	var cc struct {
 		Title    string
 		Icon     string
@@ -48,10 +54,59 @@ func New(name string, other map[string]interface{}, err error) api.Vehicle {
 	return v
 }
 
+// Retry attempts to create the vehicle in the background using exponential backoff.
+// Once created, the wrapper delegates to the recovered vehicle.
+func (v *Wrapper) Retry(log *util.Logger, create func() (api.Vehicle, error)) {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Minute
+	bo.MaxInterval = time.Hour
+	bo.MaxElapsedTime = 0
+
+	vehicle, err := backoff.RetryNotifyWithData(func() (api.Vehicle, error) {
+		vehicle, err := create()
+
+		// configuration errors will not recover
+		var ce *util.ConfigError
+		if errors.As(err, &ce) {
+			err = backoff.Permanent(err)
+		}
+
+		return vehicle, err
+	}, bo, func(err error, d time.Duration) {
+		log.WARN.Printf("%s: %v, retry in %v", v.Title(), err, d.Truncate(time.Second))
+	})
+	if err != nil {
+		log.ERROR.Printf("%s: %v", v.Title(), err)
+		return
+	}
+
+	v.mu.Lock()
+	v.vehicle = vehicle
+	v.mu.Unlock()
+
+	log.INFO.Printf("%s: vehicle available", vehicle.Title())
+}
+
+// recovered returns the recovered vehicle or nil
+func (v *Wrapper) recovered() api.Vehicle {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.vehicle
+}
+
+// Recovered returns the recovered vehicle or nil.
+// The wrapper only provides the basic vehicle api, users must swap in the recovered vehicle for further interfaces.
+func (v *Wrapper) Recovered() api.Vehicle {
+	return v.recovered()
+}
+
 var _ api.Vehicle = (*Wrapper)(nil)
 
 // Title implements the api.Vehicle interface
 func (v *Wrapper) Title() string {
+	if vv := v.recovered(); vv != nil {
+		return vv.Title()
+	}
 	return v.title
 }
 
@@ -62,26 +117,41 @@ func (v *Wrapper) SetTitle(title string) {
 
 // Icon implements the api.Vehicle interface
 func (v *Wrapper) Icon() string {
+	if vv := v.recovered(); vv != nil {
+		return vv.Icon()
+	}
 	return v.icon
 }
 
 // Capacity implements the api.Vehicle interface
 func (v *Wrapper) Capacity() float64 {
+	if vv := v.recovered(); vv != nil {
+		return vv.Capacity()
+	}
 	return v.capacity
 }
 
 // Phases implements the api.Vehicle interface
 func (v *Wrapper) Phases() int {
+	if vv := v.recovered(); vv != nil {
+		return vv.Phases()
+	}
 	return v.phases
 }
 
 // Identifiers implements the api.Vehicle interface
 func (v *Wrapper) Identifiers() []string {
+	if vv := v.recovered(); vv != nil {
+		return vv.Identifiers()
+	}
 	return nil
 }
 
 // OnIdentified implements the api.Vehicle interface
 func (v *Wrapper) OnIdentified() api.ActionConfig {
+	if vv := v.recovered(); vv != nil {
+		return vv.OnIdentified()
+	}
 	return api.ActionConfig{}
 }
 
@@ -89,6 +159,12 @@ var _ api.FeatureDescriber = (*Wrapper)(nil)
 
 // Features implements the api.FeatureDescriber interface
 func (v *Wrapper) Features() []api.Feature {
+	if vv := v.recovered(); vv != nil {
+		if fd, ok := vv.(api.FeatureDescriber); ok {
+			return fd.Features()
+		}
+		return nil
+	}
 	return []api.Feature{api.Offline}
 }
 
@@ -96,5 +172,8 @@ var _ api.Battery = (*Wrapper)(nil)
 
 // Soc implements the api.Battery interface
 func (v *Wrapper) Soc() (float64, error) {
+	if vv := v.recovered(); vv != nil {
+		return vv.Soc()
+	}
 	return 0, v.err
 }
@@ -0,0 +1,40 @@
+package wrapper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapperRecovered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("foo").AnyTimes()
+	vehicle.EXPECT().Soc().Return(50.0, nil)
+
+	v := New("foo", nil, errors.New("offline"))
+	assert.Nil(t, v.Recovered())
+
+	_, err := v.Soc()
+	assert.ErrorContains(t, err, "offline")
+
+	// placeholder must not pretend to support optional interfaces
+	_, ok := any(v).(api.ChargeState)
+	assert.False(t, ok)
+
+	v.Retry(util.NewLogger("foo"), func() (api.Vehicle, error) {
+		return vehicle, nil
+	})
+
+	assert.Equal(t, vehicle, v.Recovered())
+
+	soc, err := v.Soc()
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, soc)
+}